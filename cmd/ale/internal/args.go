@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Args are the options and command that the ale command was started with
+type Args struct {
+	Profile  string
+	File     string
+	Disasm   bool
+	Writable bool
+}
+
+var (
+	ErrUnknownOption  = errors.New("unknown option")
+	ErrMissingProfile = errors.New("--profile requires an output file")
+	ErrMissingFile    = errors.New("a source file is required")
+	ErrProfileDisasm  = errors.New("--profile can't be used with disasm")
+)
+
+// ParseArgs parses the arguments that the ale command was started with,
+// excluding the name of the program. Options can appear before or after the
+// disasm command, but the arguments that follow the source file are left for
+// the script to interpret
+func ParseArgs(args []string) (*Args, error) {
+	res := &Args{}
+	for ; len(args) > 0 && res.File == ""; args = args[1:] {
+		switch a := args[0]; {
+		case a == "--writable":
+			res.Writable = true
+		case a == "--profile":
+			if len(args) < 2 {
+				return nil, ErrMissingProfile
+			}
+			res.Profile = args[1]
+			args = args[1:]
+		case a == "disasm" && !res.Disasm:
+			res.Disasm = true
+		case strings.HasPrefix(a, "--"):
+			return nil, fmt.Errorf("%w: %s", ErrUnknownOption, a)
+		default:
+			res.File = a
+		}
+	}
+	if res.Disasm && res.Profile != "" {
+		return nil, ErrProfileDisasm
+	}
+	if (res.Disasm || res.Profile != "") && res.File == "" {
+		return nil, ErrMissingFile
+	}
+	return res, nil
+}
@@ -0,0 +1,59 @@
+package internal_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/ale/cmd/ale/internal"
+	"github.com/kode4food/ale/internal/assert"
+)
+
+func TestParseArgs(t *testing.T) {
+	as := assert.New(t)
+
+	parse := func(args ...string) *internal.Args {
+		res, err := internal.ParseArgs(args)
+		as.NoError(err)
+		return res
+	}
+
+	as.Equal(&internal.Args{}, parse())
+	as.Equal(&internal.Args{Writable: true}, parse("--writable"))
+	as.Equal(&internal.Args{File: "f.ale", Writable: true},
+		parse("--writable", "f.ale"),
+	)
+	as.Equal(&internal.Args{File: "f.ale", Profile: "out.pprof", Writable: true},
+		parse("--profile", "out.pprof", "--writable", "f.ale"),
+	)
+	as.Equal(&internal.Args{File: "f.ale", Disasm: true, Writable: true},
+		parse("disasm", "--writable", "f.ale"),
+	)
+	as.Equal(&internal.Args{File: "f.ale", Disasm: true, Writable: true},
+		parse("--writable", "disasm", "f.ale"),
+	)
+	as.Equal(&internal.Args{File: "f.ale"},
+		parse("f.ale", "--writable", "--other"),
+	)
+	as.Equal(&internal.Args{File: "disasm", Disasm: true},
+		parse("disasm", "disasm"),
+	)
+}
+
+func TestParseArgsErrors(t *testing.T) {
+	as := assert.New(t)
+
+	parse := func(args ...string) error {
+		_, err := internal.ParseArgs(args)
+		return err
+	}
+
+	as.EqualError(parse("--other", "f.ale"),
+		fmt.Sprintf("%s: --other", internal.ErrUnknownOption),
+	)
+	as.ErrorIs(parse("--profile"), internal.ErrMissingProfile)
+	as.ErrorIs(parse("--profile", "out.pprof"), internal.ErrMissingFile)
+	as.ErrorIs(parse("disasm", "--writable"), internal.ErrMissingFile)
+	as.ErrorIs(parse("disasm", "--profile", "out.pprof", "f.ale"),
+		internal.ErrProfileDisasm,
+	)
+}
@@ -35,8 +35,9 @@ func TestREPLDoNamespaced(t *testing.T) {
 	r := NewREPL()
 	res, idx := r.Do([]rune("(al'(1 2 3 4)"), 3)
 	as.Equal(0, idx)
-	as.Equal(1, len(res))
+	as.Equal(2, len(res))
 	as.Equal("e/", string(res[0]))
+	as.Equal("ias ", string(res[1]))
 
 	res, idx = r.Do([]rune("(ale/seq->"), 10)
 	as.Equal(0, idx)
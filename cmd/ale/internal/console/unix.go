@@ -25,6 +25,7 @@ const (
 	Code    = esc + "94m"        // Light Blue
 	Result  = esc + "32m"        // Green
 	Error   = esc + "31m"        // Red
+	Warning = esc + "33m"        // Yellow
 	NewLine = esc + "90m" + "␤"  // Dark Gray
 	Paired  = esc + "7m"         // Invert
 )
@@ -18,6 +18,7 @@ const (
 	Code    = ""
 	Result  = ""
 	Error   = ""
+	Warning = ""
 	NewLine = ""
 	Paired  = ""
 )
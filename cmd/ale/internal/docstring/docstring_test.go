@@ -36,7 +36,8 @@ func TestDocumentedBuiltinsExist(t *testing.T) {
 		if strings.Contains(d, "draft: true") {
 			continue
 		}
-		res, in, err := env.ResolveSymbol(ns, LS(name))
+		sym := data.MustParseSymbol(S(name))
+		res, in, err := env.ResolveSymbol(ns, sym)
 		_ = as.NoError(err) && as.NotNil(res) && as.NotNil(in)
 	}
 }
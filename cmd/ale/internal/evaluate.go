@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -13,10 +15,12 @@ import (
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
 	"github.com/kode4food/ale/eval"
+	"github.com/kode4food/ale/internal/compiler/bytecode"
 	"github.com/kode4food/ale/internal/debug"
 	"github.com/kode4food/ale/internal/lang/lex"
 	"github.com/kode4food/ale/internal/lang/parse"
 	"github.com/kode4food/ale/internal/sequence"
+	"github.com/kode4food/ale/internal/stream"
 	"github.com/kode4food/ale/read"
 )
 
@@ -29,6 +33,8 @@ const (
 )
 
 var (
+	writableFileSystem bool
+
 	recoverable = []string{
 		parse.ErrListNotClosed.Error(),
 		parse.ErrVectorNotClosed.Error(),
@@ -45,7 +51,7 @@ func EvaluateStdIn() {
 	defer exitWithError()
 
 	buffer, _ := io.ReadAll(os.Stdin)
-	mustEvalBuffer(buffer)
+	mustEvalBuffer("", buffer)
 }
 
 // EvaluateFile reads the specific source file and evaluates it
@@ -57,7 +63,48 @@ func EvaluateFile(filename string) {
 		fmt.Println(fmt.Errorf(ErrFileNotFound, filename))
 		os.Exit(-1)
 	}
-	mustEvalBuffer(buffer)
+	mustEvalBuffer(filename, buffer)
+}
+
+// ProfileFile reads the specific source file and evaluates it with a
+// profiler, writing the profile to the named output file in the format read
+// by the pprof tool, and a flat text report of it to StdErr
+func ProfileFile(filename string, output string) {
+	defer exitWithError()
+
+	buffer, err := os.ReadFile(filename)
+	if err != nil {
+		fmt.Println(fmt.Errorf(ErrFileNotFound, filename))
+		os.Exit(-1)
+	}
+	out, err := os.Create(output)
+	if err != nil {
+		panic(err)
+	}
+	defer func() { _ = out.Close() }()
+
+	ctx, stop := eval.WithProfiler(
+		context.Background(), eval.DefaultProfilePeriod,
+	)
+	defer writeProfile(out, stop)
+	mustEvalBufferContext(ctx, filename, buffer)
+}
+
+// DisassembleFile reads the specific source file and writes the instructions
+// of its compiled forms to StdOut
+func DisassembleFile(filename string) {
+	defer exitWithError()
+
+	buffer, err := os.ReadFile(filename)
+	if err != nil {
+		fmt.Println(fmt.Errorf(ErrFileNotFound, filename))
+		os.Exit(-1)
+	}
+	ns := makeUserNamespace()
+	r := read.MustFromSource(ns, filename, data.String(buffer))
+	if err := eval.Disassemble(ns, r, os.Stdout); err != nil {
+		panic(err)
+	}
 }
 
 func (r *REPL) evalBuffer() (completed bool) {
@@ -104,7 +151,8 @@ func (r *REPL) evalForm(ns env.Namespace, f ale.Value) error {
 		}
 	}()
 
-	res, err := eval.Value(ns, f)
+	ctx := eval.WithWarnings(context.Background(), r.outputWarning)
+	res, err := eval.ValueContext(ctx, ns, f)
 	if err != nil {
 		return err
 	}
@@ -137,29 +185,70 @@ func isRecoverable(err error) bool {
 	return strings.HasPrefix(msg, notPaired)
 }
 
-func mustEvalBuffer(src []byte) {
-	if err := evalBuffer(src); err != nil {
+func mustEvalBuffer(name string, src []byte) {
+	mustEvalBufferContext(context.Background(), name, src)
+}
+
+func mustEvalBufferContext(ctx context.Context, name string, src []byte) {
+	if err := evalBufferContext(ctx, name, src); err != nil {
 		panic(err)
 	}
 }
 
-func evalBuffer(src []byte) error {
+func evalBuffer(name string, src []byte) error {
+	return evalBufferContext(context.Background(), name, src)
+}
+
+func evalBufferContext(ctx context.Context, name string, src []byte) error {
 	ns := makeUserNamespace()
-	r := read.MustFromString(ns, data.String(src))
-	if _, err := eval.Block(ns, r); err != nil {
+	ctx = eval.WithWarnings(ctx, printWarning)
+	if bytecode.IsPrecompiled(src) {
+		_, err := eval.LoadContext(ctx, ns, bytes.NewReader(src))
+		return err
+	}
+	r := read.MustFromSource(ns, name, data.String(src))
+	if _, err := eval.BlockContext(ctx, ns, r); err != nil {
 		return err
 	}
 	return nil
 }
 
+func writeProfile(w io.Writer, stop func() *eval.Profile) {
+	p := stop()
+	if err := p.WritePprof(w); err != nil {
+		panic(err)
+	}
+	if err := p.WriteReport(os.Stderr); err != nil {
+		panic(err)
+	}
+}
+
+func printWarning(w *eval.Warning) {
+	_, _ = fmt.Fprintln(os.Stderr, w)
+}
+
+// EnableWritableFileSystem allows the scripts and REPL sessions that are
+// started afterward to create, modify, and remove files in the current
+// directory. Otherwise, the current directory is bound read-only
+func EnableWritableFileSystem() {
+	writableFileSystem = true
+}
+
 func makeUserNamespace() env.Namespace {
 	ns := env.MustGetQualified(bootstrap.TopLevelEnvironment(), UserDomain)
 	cwd, err := os.Getwd()
 	if err != nil {
 		panic(err)
 	}
-	fs := os.DirFS(cwd)
-	bootstrap.MustBindFileSystem(ns, fs)
+	if !writableFileSystem {
+		bootstrap.MustBindFileSystem(ns, os.DirFS(cwd))
+		return ns
+	}
+	root, err := os.OpenRoot(cwd)
+	if err != nil {
+		panic(err)
+	}
+	bootstrap.MustBindWritableFileSystem(ns, stream.RootFS(root))
 	return ns
 }
 
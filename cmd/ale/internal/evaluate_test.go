@@ -1,12 +1,18 @@
 package internal
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/eval"
 	"github.com/kode4food/ale/internal/assert"
+	"github.com/kode4food/ale/internal/compiler/bytecode"
 	"github.com/kode4food/ale/internal/lang/parse"
+	"github.com/kode4food/ale/read"
 )
 
 func panicWithRec(t *testing.T, recoverable bool, msg string, fn func()) {
@@ -44,17 +50,49 @@ func TestReplEvalBuffer(t *testing.T) {
 func TestEvalBuffer(t *testing.T) {
 	as := assert.New(t)
 
-	as.NoError(evalBuffer([]byte(`"hello world"`)))
+	as.NoError(evalBuffer("", []byte(`"hello world"`)))
 	as.EqualError(
-		evalBuffer([]byte(`(unknown)`)),
+		evalBuffer("", []byte(`(unknown)`)),
 		fmt.Sprintf(env.ErrNameNotDeclared, "unknown"),
 	)
 
 	panicWith(t, "boom", func() {
-		mustEvalBuffer([]byte(`(raise "boom")`))
+		mustEvalBuffer("", []byte(`(raise "boom")`))
 	})
 
 	panicWithRecoverable(t, parse.ErrListNotClosed.Error(), func() {
-		mustEvalBuffer([]byte(`(no-close `))
+		mustEvalBuffer("", []byte(`(no-close `))
 	})
 }
+
+func TestEvalPrecompiledBuffer(t *testing.T) {
+	as := assert.New(t)
+
+	ns := makeUserNamespace()
+	var buf bytes.Buffer
+	src := read.MustFromString(ns, `(define x 42) (+ x 1)`)
+	as.NoError(eval.Compile(ns, src, &buf))
+
+	as.NoError(evalBuffer("", buf.Bytes()))
+	as.Error(evalBuffer("", []byte(bytecode.Magic+"\x09")))
+}
+
+func TestUserFileSystem(t *testing.T) {
+	as := assert.New(t)
+
+	has := func(ns env.Namespace, op string) ale.Value {
+		res, err := eval.String(ns, data.String("(contains? *fs* "+op+")"))
+		as.NoError(err)
+		return res
+	}
+
+	ns := makeUserNamespace()
+	as.False(has(ns, ":create"))
+	as.True(has(ns, ":open"))
+
+	EnableWritableFileSystem()
+	defer func() { writableFileSystem = false }()
+
+	ns = makeUserNamespace()
+	as.True(has(ns, ":create"))
+}
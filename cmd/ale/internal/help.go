@@ -24,7 +24,7 @@ var doc = compiler.Call(func(e encoder.Encoder, args ...ale.Value) error {
 	if len(args) == 0 {
 		docSymbolList()
 	} else {
-		docSymbol(args[0].(data.Local))
+		docSymbol(args[0].(data.Symbol))
 	}
 	return generate.Literal(e, nothing)
 })
@@ -43,7 +43,7 @@ func help(...ale.Value) ale.Value {
 }
 
 func docSymbol(sym data.Symbol) {
-	name := string(sym.Local())
+	name := data.ToString(sym)
 	if name == "doc" {
 		docSymbolList()
 		return
@@ -6,6 +6,7 @@ import (
 
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/cmd/ale/internal/console"
+	"github.com/kode4food/ale/eval"
 )
 
 type sentinel struct{}
@@ -18,6 +19,7 @@ const (
 	output = console.Bold + "%s" + console.Reset
 	good   = domain + console.Result + "[%d]= " + output
 	bad    = domain + console.Error + "[%d]! " + output
+	warned = domain + console.Warning + "[%d]? " + output
 
 	stripped = "%s [%d]= "
 )
@@ -61,6 +63,11 @@ func (r *REPL) outputError(err error) {
 	fmt.Println(res)
 }
 
+func (r *REPL) outputWarning(w *eval.Warning) {
+	res := fmt.Sprintf(warned, r.nsSpace(), r.idx, w.Message)
+	fmt.Println(res)
+}
+
 func (sentinel) Equal(ale.Value) bool {
 	return false
 }
@@ -26,6 +26,7 @@ func NewREPL() *REPL {
 	repl := &REPL{
 		ns: makeUserNamespace(),
 	}
+	repl.ns.Environment().SetLateBinding(true)
 	repl.registerBuiltIns()
 
 	rl, err := readline.NewEx(&readline.Config{
@@ -1,19 +1,32 @@
 package main
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/kode4food/ale/cmd/ale/internal"
 )
 
 func main() {
+	args, err := internal.ParseArgs(os.Args[1:])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	if args.Writable {
+		internal.EnableWritableFileSystem()
+	}
 	switch {
 	case isStdInPiped():
 		internal.EvaluateStdIn()
-	case len(os.Args) < 2:
+	case args.Disasm:
+		internal.DisassembleFile(args.File)
+	case args.Profile != "":
+		internal.ProfileFile(args.File, args.Profile)
+	case args.File == "":
 		internal.NewREPL().Run()
 	default:
-		internal.EvaluateFile(os.Args[1])
+		internal.EvaluateFile(args.File)
 	}
 }
 
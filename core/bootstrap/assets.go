@@ -1,11 +1,14 @@
 package bootstrap
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/kode4food/ale/core/source"
 	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
 	"github.com/kode4food/ale/eval"
 	"github.com/kode4food/ale/read"
 )
@@ -31,13 +34,49 @@ func (b *bootstrap) populateAssets() {
 		panic(err)
 	}
 
-	src, err := source.Assets.ReadFile(InitFile)
-	if err != nil {
+	if err := b.evalAssets(ns); err != nil {
 		panic(err)
 	}
+}
 
-	seq := read.MustFromString(ns, data.String(src))
-	if _, err := eval.Block(ns, seq); err != nil {
-		panic(err)
+func (b *bootstrap) evalAssets(ns env.Namespace) error {
+	if img, ok := currentImage(); ok {
+		ctx := eval.WithNatives(context.Background(), b.natives())
+		_, err := eval.LoadContext(ctx, ns, bytes.NewReader(img))
+		return err
+	}
+	return evalSource(ns)
+}
+
+// natives returns the built-ins that the core sources bind using the
+// definers, so that the core image can refer to them by name
+func (b *bootstrap) natives() eval.Natives {
+	res := eval.Natives{}
+	for n, v := range b.procMap {
+		res[n] = v
+	}
+	for n, v := range b.specialMap {
+		res[n] = v
+	}
+	for n, v := range b.macroMap {
+		res[n] = v
+	}
+	return res
+}
+
+func evalSource(ns env.Namespace) error {
+	seq, err := readSource(ns)
+	if err != nil {
+		return err
+	}
+	_, err = eval.Block(ns, seq)
+	return err
+}
+
+func readSource(ns env.Namespace) (data.Sequence, error) {
+	src, err := source.Assets.ReadFile(InitFile)
+	if err != nil {
+		return nil, err
 	}
+	return read.FromSource(ns, InitFile, data.String(src))
 }
@@ -45,6 +45,12 @@ var (
 // up your own Environments. Otherwise, calls to TopLevelEnvironment and
 // DevNullEnvironment will perform this action for you.
 func Into(e *env.Environment) {
+	newBootstrap(e).populateAssets()
+}
+
+// newBootstrap populates the root namespace of an Environment with the
+// built-ins that are implemented in Go, leaving the core sources for later
+func newBootstrap(e *env.Environment) *bootstrap {
 	b := &bootstrap{
 		environment: e,
 		macroMap:    macroMap{},
@@ -54,7 +60,7 @@ func Into(e *env.Environment) {
 	b.populateDefiners()
 	b.populateSpecialForms()
 	b.populateBuiltins()
-	b.populateAssets()
+	return b
 }
 
 // ProcessEnv binds *env* to the operating system's environment variables
@@ -115,14 +121,7 @@ func DevNullEnvironment() *env.Environment {
 // BindFileSystem binds a file system to a Namespace to enable source includes
 // and other file operations. The file system is private to the Namespace
 func BindFileSystem(ns env.Namespace, f fs.FS) error {
-	e, err := ns.Private(lang.FS)
-	if err != nil {
-		return fmt.Errorf(ErrCannotDeclareFS, err)
-	}
-	if err = e.Bind(stream.WrapFileSystem(f)); err != nil {
-		return fmt.Errorf(ErrCannotBindFS, err)
-	}
-	return nil
+	return bindFileSystem(ns, stream.WrapFileSystem(f))
 }
 
 // MustBindFileSystem binds a file system to a Namespace or panics if it can't
@@ -131,3 +130,35 @@ func MustBindFileSystem(ns env.Namespace, f fs.FS) {
 		panic(err)
 	}
 }
+
+// BindWritableFileSystem binds a writable file system to a Namespace. In
+// addition to the operations provided by BindFileSystem, the Namespace will be
+// able to create, modify, and remove files. The file system is private to the
+// Namespace
+func BindWritableFileSystem(ns env.Namespace, f stream.WritableFS) error {
+	return bindFileSystem(ns, stream.WrapWritableFileSystem(f))
+}
+
+// MustBindWritableFileSystem binds a writable file system to a Namespace or
+// panics if it can't
+func MustBindWritableFileSystem(ns env.Namespace, f stream.WritableFS) {
+	if err := BindWritableFileSystem(ns, f); err != nil {
+		panic(err)
+	}
+}
+
+func bindFileSystem(ns env.Namespace, fs *data.Object) error {
+	if v, err := env.ResolveValue(ns, lang.FS); err == nil {
+		if d, ok := v.(*compiler.Denied); ok {
+			return fmt.Errorf(ErrCannotBindFS, d)
+		}
+	}
+	e, err := ns.Private(lang.FS)
+	if err != nil {
+		return fmt.Errorf(ErrCannotDeclareFS, err)
+	}
+	if err = e.Bind(fs); err != nil {
+		return fmt.Errorf(ErrCannotBindFS, err)
+	}
+	return nil
+}
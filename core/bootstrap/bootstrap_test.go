@@ -1,14 +1,18 @@
 package bootstrap_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/kode4food/ale/core/bootstrap"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/eval"
 	"github.com/kode4food/ale/internal/assert"
 	"github.com/kode4food/ale/internal/compiler"
 	lang "github.com/kode4food/ale/internal/lang/env"
+	"github.com/kode4food/ale/internal/stream"
 )
 
 func TestDevNullEnvironment(t *testing.T) {
@@ -41,10 +45,41 @@ func TestBootstrapInto(t *testing.T) {
 	e := bootstrap.TopLevelEnvironment()
 	ns := e.GetRoot()
 
-	_, ok := as.IsBound(ns, "%define").(compiler.Call)
+	_, ok := as.IsBound(ns, "%define").(*compiler.Special)
 	as.True(ok)
 }
 
+func TestBindWritableFileSystem(t *testing.T) {
+	as := assert.New(t)
+
+	dir := t.TempDir()
+	root, err := os.OpenRoot(dir)
+	as.NoError(err)
+	defer func() { _ = root.Close() }()
+
+	e := env.NewEnvironment()
+	bootstrap.DevNull(e)
+	bootstrap.Into(e)
+	ns := env.MustGetQualified(e, "writer")
+	as.NoError(bootstrap.BindWritableFileSystem(ns, stream.RootFS(root)))
+
+	res, err := eval.String(ns, `
+		(with-open [w (: *fs* :create "out.txt")]
+			(: w :write "hello " 42))
+		(: *fs* :open "out.txt" :read-string)
+	`)
+	as.NoError(err)
+	as.String("hello 42", res)
+
+	b, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	as.NoError(err)
+	as.Equal("hello 42", string(b))
+
+	other := env.MustGetQualified(e, "reader")
+	_, err = eval.String(other, `(: *fs* :create "out.txt")`)
+	as.NotNil(err)
+}
+
 func BenchmarkBootstrapping(b *testing.B) {
 	for range b.N {
 		e := env.NewEnvironment()
@@ -1,6 +1,9 @@
 package bootstrap
 
 import (
+	"math"
+
+	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/core/builtin"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/lang/env"
@@ -20,29 +23,115 @@ const (
 
 func (b *bootstrap) populateBuiltins() {
 	b.functions(map[data.Local]data.Procedure{
-		env.Bytes:       builtin.Bytes,
-		env.Chan:        builtin.Chan,
-		env.CurrentTime: builtin.CurrentTime,
-		env.Defer:       builtin.Defer,
-		env.GenSym:      builtin.GenSym,
-		env.Go:          builtin.Go,
-		env.IsA:         builtin.IsA,
-		env.List:        builtin.List,
-		env.Macro:       builtin.Macro,
-		env.Object:      builtin.Object,
-		env.Read:        builtin.Read,
-		env.Recover:     builtin.Recover,
-		env.ReaderStr:   builtin.ReaderStr,
-		env.Set:         builtin.Set,
-		env.Str:         builtin.Str,
-		env.Sym:         builtin.Sym,
-		env.TypeOf:      builtin.TypeOf,
-		env.Vector:      builtin.Vector,
+		env.AddWatch:      builtin.AddWatch,
+		env.Atom:          builtin.Atom,
+		env.Bytes:         builtin.Bytes,
+		env.Cancelled:     builtin.IsCancelled,
+		env.Chan:          builtin.Chan,
+		env.Compare:       builtin.Compare,
+		env.CompareAndSet: builtin.CompareAndSet,
+		env.CurrentTime:   builtin.CurrentTime,
+		env.Defer:         builtin.Defer,
+		env.Deref:         builtin.Deref,
+		env.Disasm:        builtin.Disasm,
+		env.DoneChan:      builtin.DoneChan,
+		env.Error:         builtin.Error,
+		env.ErrorCause:    builtin.ErrorCause,
+		env.ErrorKind:     builtin.ErrorKind,
+		env.GenSym:        builtin.GenSym,
+		env.Go:            builtin.Go,
+		env.IsA:           builtin.IsA,
+		env.List:          builtin.List,
+		env.Macro:         builtin.Macro,
+		env.Object:        builtin.Object,
+		env.Profile:       builtin.Profile,
+		env.Read:          builtin.Read,
+		env.Recover:       builtin.Recover,
+		env.ReaderStr:     builtin.ReaderStr,
+		env.RemoveWatch:   builtin.RemoveWatch,
+		env.Reset:         builtin.Reset,
+		env.Select:        builtin.Select,
+		env.Set:           builtin.Set,
+		env.Sort:          builtin.Sort,
+		env.Str:           builtin.Str,
+		env.Swap:          builtin.Swap,
+		env.Sym:           builtin.Sym,
+		env.TypeOf:        builtin.TypeOf,
+		env.Vector:        builtin.Vector,
 	})
 
 	b.macros(map[data.Local]macro.Call{
 		env.SyntaxQuote: builtin.SyntaxQuote,
 	})
+
+	b.qualified(env.JSONDomain, map[data.Local]ale.Value{
+		env.JSONRead:      builtin.JSONRead,
+		env.JSONReadLines: builtin.JSONReadLines,
+		env.JSONWrite:     builtin.JSONWrite,
+	})
+
+	b.qualified(env.MathDomain, map[data.Local]ale.Value{
+		env.MathAbs:            builtin.MathAbs,
+		env.MathAcos:           builtin.MathAcos,
+		env.MathAsin:           builtin.MathAsin,
+		env.MathAtan:           builtin.MathAtan,
+		env.MathBitAnd:         builtin.MathBitAnd,
+		env.MathBitNot:         builtin.MathBitNot,
+		env.MathBitOr:          builtin.MathBitOr,
+		env.MathBitXor:         builtin.MathBitXor,
+		env.MathCeil:           builtin.MathCeil,
+		env.MathCos:            builtin.MathCos,
+		env.MathDenominator:    builtin.MathDenominator,
+		env.MathE:              data.Float(math.E),
+		env.MathExactToInexact: builtin.MathExactToInexact,
+		env.MathExp:            builtin.MathExp,
+		env.MathExpt:           builtin.MathPow,
+		env.MathFloor:          builtin.MathFloor,
+		env.MathGCD:            builtin.MathGCD,
+		env.MathInexactToExact: builtin.MathInexactToExact,
+		env.MathLCM:            builtin.MathLCM,
+		env.MathLog:            builtin.MathLog,
+		env.MathMax:            builtin.MathMax,
+		env.MathMin:            builtin.MathMin,
+		env.MathNumerator:      builtin.MathNumerator,
+		env.MathPi:             data.Float(math.Pi),
+		env.MathPow:            builtin.MathPow,
+		env.MathRound:          builtin.MathRound,
+		env.MathShiftLeft:      builtin.MathShiftLeft,
+		env.MathShiftRight:     builtin.MathShiftRight,
+		env.MathSin:            builtin.MathSin,
+		env.MathSqrt:           builtin.MathSqrt,
+		env.MathTan:            builtin.MathTan,
+		env.MathTruncate:       builtin.MathTruncate,
+	})
+
+	b.qualified(env.RegexDomain, map[data.Local]ale.Value{
+		env.RegexCompile: builtin.RegexCompile,
+		env.RegexFind:    builtin.RegexFind,
+		env.RegexFindAll: builtin.RegexFindAll,
+		env.RegexGroups:  builtin.RegexGroups,
+		env.RegexIsMatch: builtin.RegexIsMatch,
+		env.RegexReplace: builtin.RegexReplace,
+		env.RegexSplit:   builtin.RegexSplit,
+	})
+
+	b.qualified(env.StringDomain, map[data.Local]ale.Value{
+		env.StringEndsWith:    builtin.StringEndsWith,
+		env.StringFormat:      builtin.StringFormat,
+		env.StringIndexOf:     builtin.StringIndexOf,
+		env.StringJoin:        builtin.StringJoin,
+		env.StringLastIndexOf: builtin.StringLastIndexOf,
+		env.StringLower:       builtin.StringLower,
+		env.StringPadLeft:     builtin.StringPadLeft,
+		env.StringPadRight:    builtin.StringPadRight,
+		env.StringReplace:     builtin.StringReplace,
+		env.StringSplit:       builtin.StringSplit,
+		env.StringStartsWith:  builtin.StringStartsWith,
+		env.StringTrim:        builtin.StringTrim,
+		env.StringTrimLeft:    builtin.StringTrimLeft,
+		env.StringTrimRight:   builtin.StringTrimRight,
+		env.StringUpper:       builtin.StringUpper,
+	})
 }
 
 func (b *bootstrap) functions(f map[data.Local]data.Procedure) {
@@ -55,6 +144,16 @@ func (b *bootstrap) function(name data.Local, call data.Procedure) {
 	b.procMap[name] = call
 }
 
+func (b *bootstrap) qualified(domain data.Local, m map[data.Local]ale.Value) {
+	ns, err := b.environment.NewQualified(domain)
+	if err != nil {
+		panic(err)
+	}
+	for k, v := range m {
+		mustBindPublic(ns, k, v)
+	}
+}
+
 func (b *bootstrap) macros(m map[data.Local]macro.Call) {
 	for k, v := range m {
 		b.macro(k, v)
@@ -17,6 +17,10 @@ const (
 	defMacroName   = "def-macro"
 )
 
+// definerNames are the private root entries that bind built-ins by name, and
+// which would otherwise allow a sandbox to restore the built-ins it's denied
+var definerNames = data.Locals{defBuiltInName, defSpecialName, defMacroName}
+
 func (b *bootstrap) populateDefiners() {
 	ns := b.environment.GetRoot()
 
@@ -2,4 +2,6 @@
 // populates a new Environment with all core functions, macros, special forms,
 // and standard library definitions. Create a new `*env.Environment` and pass
 // it to `Into` to initialize a complete Ale runtime ready for code evaluation.
+// To run untrusted code, use `Sandboxed` to create an Environment that is only
+// granted the capabilities it needs.
 package bootstrap
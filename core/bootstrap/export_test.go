@@ -0,0 +1,53 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/core/source"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/eval"
+	"github.com/kode4food/ale/internal/runtime/isa"
+)
+
+// FromSource bootstraps an Environment by evaluating the core sources, rather
+// than by loading the embedded image
+func FromSource(e *env.Environment) {
+	newBootstrap(e)
+	ns := e.GetRoot()
+	MustBindFileSystem(ns, source.Assets)
+	if err := evalSource(ns); err != nil {
+		panic(err)
+	}
+}
+
+// ImageDigest hashes the core sources along with the provided bytecode version
+// and instruction effects, as the prefix of the embedded image would be
+func ImageDigest(
+	version int, effects map[isa.Opcode]*isa.Effect,
+) ([]byte, error) {
+	return digest(version, effects)
+}
+
+// ImageForm bootstraps an Environment by loading the embedded image, and
+// returns the first form that it contains, which would have to be compiled
+// again when loaded. If the image contains no forms, nil is returned
+func ImageForm(e *env.Environment) (ale.Value, error) {
+	b := newBootstrap(e)
+	ns := e.GetRoot()
+	MustBindFileSystem(ns, source.Assets)
+	img, _ := currentImage()
+
+	var res ale.Value
+	ctx := eval.WithNatives(context.Background(), b.natives())
+	ctx = eval.WithLoadedForms(ctx, func(v ale.Value) {
+		if res == nil {
+			res = v
+		}
+	})
+	if _, err := eval.LoadContext(ctx, ns, bytes.NewReader(img)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
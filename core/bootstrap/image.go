@@ -0,0 +1,100 @@
+package bootstrap
+
+//go:generate go run ./internal/image core.img
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"slices"
+
+	"github.com/kode4food/ale/core/source"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/eval"
+	"github.com/kode4food/ale/internal/compiler/bytecode"
+	"github.com/kode4food/ale/internal/runtime/isa"
+)
+
+// ImageFile is the name of the precompiled core image that is embedded in
+// this package. It's regenerated from the core sources using go generate
+const ImageFile = "core.img"
+
+//go:embed core.img
+var image []byte
+
+// WriteImage compiles the core sources into an image that Into can load
+// without reading, expanding, or optimizing them again. The image begins with
+// a digest of the sources, the bytecode Version, and the instruction set, so
+// that a stale image is ignored rather than loaded. It's compiled in an
+// Environment that has no I/O streams bound, so the core procedures continue
+// to look those up when they're called
+func WriteImage(w io.Writer) error {
+	d, err := imageDigest()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(d); err != nil {
+		return err
+	}
+
+	e := env.NewEnvironment()
+	b := newBootstrap(e)
+	ns := e.GetRoot()
+	if err := BindFileSystem(ns, source.Assets); err != nil {
+		return err
+	}
+	seq, err := readSource(ns)
+	if err != nil {
+		return err
+	}
+	ctx := eval.WithNatives(context.Background(), b.natives())
+	return eval.CompileContext(ctx, ns, seq, w)
+}
+
+func currentImage() ([]byte, bool) {
+	d, err := imageDigest()
+	if err != nil || !bytes.HasPrefix(image, d) {
+		return nil, false
+	}
+	return image[len(d):], true
+}
+
+func imageDigest() ([]byte, error) {
+	return digest(bytecode.Version, isa.Effects)
+}
+
+// digest hashes the core sources, and the format and instructions used to
+// encode them. It can't cover the compiler and optimizer that produced the
+// bytecode, so TestImageCurrent compares the image against a fresh compile
+func digest(version int, effects map[isa.Opcode]*isa.Effect) ([]byte, error) {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d\x00", version)
+	for _, oc := range slices.Sorted(maps.Keys(effects)) {
+		_, _ = fmt.Fprintf(h, "%d %s %+v\x00", oc, oc, *effects[oc])
+	}
+	err := fs.WalkDir(source.Assets, ".",
+		func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			src, err := source.Assets.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			_, _ = io.WriteString(h, path)
+			_, _ = h.Write([]byte{0})
+			_, _ = h.Write(src)
+			_, _ = h.Write([]byte{0})
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
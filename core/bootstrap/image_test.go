@@ -0,0 +1,126 @@
+package bootstrap_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/core/bootstrap"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/internal/assert"
+	"github.com/kode4food/ale/internal/compiler"
+	"github.com/kode4food/ale/internal/compiler/bytecode"
+	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/runtime/vm"
+)
+
+func TestImageCurrent(t *testing.T) {
+	as := assert.New(t)
+
+	img, err := os.ReadFile(bootstrap.ImageFile)
+	as.NoError(err)
+
+	// The digest can't cover the compiler and optimizer, so the image is
+	// compared against a fresh compile of the core sources as well
+	var buf bytes.Buffer
+	as.NoError(bootstrap.WriteImage(&buf))
+	fresh := buf.Bytes()
+	as.True(len(img) > sha256.Size)
+	as.True(len(fresh) > sha256.Size)
+	switch {
+	case !bytes.Equal(fresh[:sha256.Size], img[:sha256.Size]):
+		t.Fatal("core image digest is stale, regenerate it using go generate")
+	case !bytes.Equal(fresh[sha256.Size:], img[sha256.Size:]):
+		t.Fatal("core image doesn't match the compiler's output, regenerate " +
+			"it using go generate")
+	}
+}
+
+func TestImageDigest(t *testing.T) {
+	as := assert.New(t)
+
+	img, err := os.ReadFile(bootstrap.ImageFile)
+	as.NoError(err)
+
+	d, err := bootstrap.ImageDigest(bytecode.Version, isa.Effects)
+	as.NoError(err)
+	as.True(bytes.HasPrefix(img, d))
+
+	d, err = bootstrap.ImageDigest(bytecode.Version+1, isa.Effects)
+	as.NoError(err)
+	as.False(bytes.HasPrefix(img, d))
+
+	effects := maps.Clone(isa.Effects)
+	effects[isa.Add] = &isa.Effect{Pop: 2, Push: 2}
+	d, err = bootstrap.ImageDigest(bytecode.Version, effects)
+	as.NoError(err)
+	as.False(bytes.HasPrefix(img, d))
+
+	effects = maps.Clone(isa.Effects)
+	delete(effects, isa.Sub)
+	d, err = bootstrap.ImageDigest(bytecode.Version, effects)
+	as.NoError(err)
+	as.False(bytes.HasPrefix(img, d))
+}
+
+func TestImageHasNoForms(t *testing.T) {
+	as := assert.New(t)
+	form, err := bootstrap.ImageForm(env.NewEnvironment())
+	as.NoError(err)
+	as.Nil(form)
+}
+
+func TestImageMatchesSource(t *testing.T) {
+	as := assert.New(t)
+
+	fromImage := env.NewEnvironment()
+	bootstrap.Into(fromImage)
+	fromSource := env.NewEnvironment()
+	bootstrap.FromSource(fromSource)
+
+	domains := fromSource.Domains()
+	as.Equal(slices.Sorted(slices.Values(domains)),
+		slices.Sorted(slices.Values(fromImage.Domains())),
+	)
+	for _, d := range domains {
+		expected := env.MustGetQualified(fromSource, d).Entries()
+		actual := env.MustGetQualified(fromImage, d).Entries()
+		as.Equal(len(expected), len(actual))
+		for n, e := range expected {
+			a, ok := actual[n]
+			as.True(ok)
+			if !ok {
+				continue
+			}
+			as.Equal(e.IsPrivate(), a.IsPrivate())
+			as.Equal(e.IsBound(), a.IsBound())
+			ev, _ := e.Value()
+			av, _ := a.Value()
+			assertSameValue(as, ev, av)
+		}
+	}
+}
+
+func assertSameValue(as *assert.Wrapper, expected, actual ale.Value) {
+	as.Equal(fmt.Sprintf("%T", expected), fmt.Sprintf("%T", actual))
+	switch expected := expected.(type) {
+	case *vm.Closure:
+		actual := actual.(*vm.Closure)
+		as.Equal(expected.Name, actual.Name)
+		as.Equal(expected.Code, actual.Code)
+		as.Equal(expected.StackSize, actual.StackSize)
+		as.Equal(expected.LocalCount, actual.LocalCount)
+		as.Equal(len(expected.Constants), len(actual.Constants))
+	case *compiler.Special:
+		as.Equal(expected.Cases, actual.(*compiler.Special).Cases)
+	case data.Procedure, *data.Object:
+	default:
+		as.Equal(data.ToString(expected), data.ToString(actual))
+	}
+}
@@ -0,0 +1,26 @@
+// Generates the precompiled core image that the bootstrap package embeds
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/kode4food/ale/core/bootstrap"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		_, _ = fmt.Fprintln(os.Stderr, "usage: image <output file>")
+		os.Exit(1)
+	}
+	var buf bytes.Buffer
+	if err := bootstrap.WriteImage(&buf); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(os.Args[1], buf.Bytes(), 0644); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
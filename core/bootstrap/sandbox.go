@@ -0,0 +1,187 @@
+package bootstrap
+
+import (
+	"fmt"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/internal/compiler"
+	lang "github.com/kode4food/ale/internal/lang/env"
+)
+
+type (
+	// Capability identifies a group of built-ins that give Ale code access
+	// to the host system, or to the internals of the runtime
+	Capability string
+
+	// Option configures the Environment that is created by Sandboxed
+	Option func(*sandbox)
+
+	sandbox struct {
+		granted map[Capability]bool
+		allowed map[data.Local]bool
+	}
+
+	// denials maps the domain and name of each entry that a sandboxed
+	// Environment withholds to the error that a reference to it raises
+	denials map[data.Local]map[data.Local]error
+)
+
+// Capabilities that can be granted to a sandboxed Environment
+const (
+	// Concurrency grants goroutines and channels, including go, chan,
+	// generate, future, select, and spawn
+	Concurrency Capability = "concurrency"
+
+	// FileSystem grants the use of a file system bound to a Namespace with
+	// BindFileSystem or BindWritableFileSystem, including #include
+	FileSystem Capability = "filesystem"
+
+	// Internals grants the raw asm and special forms, disasm, and the forms
+	// that inspect and manage namespace entries
+	Internals Capability = "internals"
+
+	// OSEnvironment grants *env* and *args*
+	OSEnvironment Capability = "os-environment"
+
+	// StdIO grants *in*, *out*, *err*, and the functions that print to them.
+	// Without it, those streams are routed to the bit bucket device
+	StdIO Capability = "stdio"
+
+	// Time grants current-time, %profile, and the time and profile macros
+	Time Capability = "time"
+)
+
+// Error messages
+const (
+	// ErrCapabilityNotGranted is raised when a sandboxed Environment refers
+	// to a built-in whose capability hasn't been granted
+	ErrCapabilityNotGranted = "capability not granted: %s requires %s"
+
+	// ErrBuiltInNotAllowed is raised when a sandboxed Environment refers to
+	// a built-in that isn't in its allow-list
+	ErrBuiltInNotAllowed = "capability not granted: %s is not allowed"
+)
+
+var capabilities = map[Capability]data.Locals{
+	Concurrency: {
+		lang.Chan, lang.Go, lang.Select, "go", "go!", "go-with-monitor",
+		"future", "generate", "select", "spawn",
+	},
+	FileSystem: {lang.FS},
+	Internals: {
+		lang.Asm, lang.Special, lang.Disasm, lang.Entries, lang.Resolve,
+		lang.Unbind, lang.Undeclare, lang.RemoveNamespace,
+	},
+	OSEnvironment: {lang.Env, lang.Args},
+	StdIO: {
+		lang.In, lang.Out, lang.Err, "pr", "prn", "print", "println",
+	},
+	Time: {lang.CurrentTime, lang.Profile, "profile", "time"},
+}
+
+// Grant is an Option that grants Capabilities to a sandboxed Environment
+func Grant(caps ...Capability) Option {
+	return func(s *sandbox) {
+		for _, c := range caps {
+			s.granted[c] = true
+		}
+	}
+}
+
+// Allow is an Option that exposes only the named built-ins. The entries of
+// qualified namespaces, such as string/split, are named by their qualified
+// names. It can be used more than once, but can't expose a built-in whose
+// Capability hasn't been granted
+func Allow(names ...data.Local) Option {
+	return func(s *sandbox) {
+		if s.allowed == nil {
+			s.allowed = map[data.Local]bool{}
+		}
+		for _, n := range names {
+			s.allowed[n] = true
+		}
+	}
+}
+
+// Sandboxed configures a bootstrapped Environment that only has the
+// Capabilities granted by the provided Options. Compiling a reference to a
+// built-in that hasn't been granted fails with a "capability not granted"
+// error. The built-ins of the core library continue to work internally, but
+// the definers that bind them are never available, whatever is granted
+func Sandboxed(opts ...Option) *env.Environment {
+	s := &sandbox{
+		granted: map[Capability]bool{},
+	}
+	for _, o := range opts {
+		o(s)
+	}
+
+	var base *env.Environment
+	switch {
+	case s.granted[StdIO]:
+		base = TopLevelEnvironment()
+	case s.granted[OSEnvironment]:
+		base = DevNullEnvironment()
+		ProcessEnv(base)
+		ProcessArgs(base)
+	default:
+		base = DevNullEnvironment()
+	}
+
+	denied := s.denied(base)
+	res := base.Restrict(func(d data.Local, e *env.Entry) bool {
+		_, ok := denied[d][e.Name()]
+		return !ok
+	})
+	for d, names := range denied {
+		ns := env.MustGetQualified(res, d)
+		for n, err := range names {
+			mustBindPublic(ns, n, compiler.NewDenied(err))
+		}
+	}
+	return res
+}
+
+func (s *sandbox) denied(e *env.Environment) denials {
+	res := denials{}
+	if s.allowed != nil {
+		for _, d := range e.Domains() {
+			ns := env.MustGetQualified(e, d)
+			for _, n := range ns.Declared() {
+				if q := qualifiedName(d, n); !s.allowed[q] {
+					res.add(d, n, fmt.Errorf(ErrBuiltInNotAllowed, q))
+				}
+			}
+		}
+	}
+	for _, n := range definerNames {
+		res.add(lang.RootDomain, n, fmt.Errorf(ErrBuiltInNotAllowed, n))
+	}
+	for c, names := range capabilities {
+		if s.granted[c] {
+			continue
+		}
+		for _, n := range names {
+			err := fmt.Errorf(ErrCapabilityNotGranted, n, c)
+			res.add(lang.RootDomain, n, err)
+		}
+	}
+	return res
+}
+
+func (d denials) add(domain, name data.Local, err error) {
+	names, ok := d[domain]
+	if !ok {
+		names = map[data.Local]error{}
+		d[domain] = names
+	}
+	names[name] = err
+}
+
+func qualifiedName(domain, name data.Local) data.Local {
+	if domain == lang.RootDomain {
+		return name
+	}
+	return data.Local(data.ToString(data.NewQualifiedSymbol(name, domain)))
+}
@@ -0,0 +1,202 @@
+package bootstrap_test
+
+import (
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"github.com/kode4food/ale/core/bootstrap"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/eval"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestSandboxed(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.Sandboxed().GetAnonymous()
+	res, err := eval.String(ns, `
+		(define (fib n)
+		  (cond
+		    [(< n 2) n]
+		    [:else   (+ (fib (- n 1)) (fib (- n 2)))]))
+		(let [x (atom 0)]
+		  (swap! x + (fib 10))
+		  (try
+		    (raise "boom")
+		    (catch [e string?] (str (deref x) ":" e))))
+	`)
+	if as.NoError(err) {
+		as.String("55:boom", res)
+	}
+
+	for src, msg := range map[string]string{
+		`(go (+ 1 2))`:             "go requires concurrency",
+		`(map chan [1 2])`:         "chan requires concurrency",
+		`(asm const 1)`:            "asm requires internals",
+		`(current-time)`:           "current-time requires time",
+		`(%profile (lambda () 1))`: "%profile requires time",
+		`(profile 1)`:              "profile requires time",
+		`(println "hello")`:        "println requires stdio",
+		`(: *out* :write "hello")`: "*out* requires stdio",
+		`*env*`:                    "*env* requires os-environment",
+		`(: *fs* :list ".")`:       "*fs* requires filesystem",
+	} {
+		_, err := eval.String(ns, data.String(src))
+		as.EqualError(err, "capability not granted: "+msg)
+	}
+
+	_, err = eval.String(ns, `(ale/chan)`)
+	as.EqualError(err, "capability not granted: chan requires concurrency")
+
+	for _, src := range []string{
+		`(def-builtin current-time)`,
+		`(ale/def-builtin %go)`,
+		`(def-special asm)`,
+		`(def-macro time)`,
+	} {
+		_, err := eval.String(ns, data.String(src))
+		as.ErrorContains(err, "capability not granted: def-")
+		as.ErrorContains(err, " is not allowed")
+	}
+	for src, msg := range map[string]string{
+		`(entries ale)`:        "entries requires internals",
+		`(resolve chan)`:       "resolve requires internals",
+		`(unbind x)`:           "unbind requires internals",
+		`(undeclare x)`:        "undeclare requires internals",
+		`(remove-namespace x)`: "remove-namespace requires internals",
+	} {
+		_, err := eval.String(ns, data.String(src))
+		as.EqualError(err, "capability not granted: "+msg)
+	}
+
+	ns = bootstrap.Sandboxed(bootstrap.Grant(bootstrap.Internals)).
+		GetAnonymous()
+	_, err = eval.String(ns, `(def-builtin current-time)`)
+	as.EqualError(err, "capability not granted: def-builtin is not allowed")
+	_, err = eval.String(ns, `(current-time)`)
+	as.EqualError(err, "capability not granted: current-time requires time")
+
+	err = bootstrap.BindFileSystem(ns, fstest.MapFS{})
+	as.NotNil(err)
+	as.ErrorContains(err, "capability not granted: *fs* requires filesystem")
+}
+
+func TestSandboxedGrant(t *testing.T) {
+	as := assert.New(t)
+
+	e := bootstrap.Sandboxed(
+		bootstrap.Grant(bootstrap.Concurrency, bootstrap.FileSystem),
+	)
+	ns := e.GetAnonymous()
+	res, err := eval.String(ns, `
+		(seq->vector (generate (emit 1 2 3)))
+	`)
+	if as.NoError(err) {
+		as.Equal(V(I(1), I(2), I(3)), res)
+	}
+
+	_, err = eval.String(ns, `(current-time)`)
+	as.EqualError(err, "capability not granted: current-time requires time")
+
+	fs := env.MustGetQualified(e, "files")
+	as.NoError(bootstrap.BindFileSystem(fs, fstest.MapFS{
+		"hello.txt": {Data: []byte("hello")},
+	}))
+	res, err = eval.String(fs, `(: *fs* :open "hello.txt" :read-string)`)
+	if as.NoError(err) {
+		as.String("hello", res)
+	}
+
+	ns = bootstrap.Sandboxed(bootstrap.Grant(bootstrap.OSEnvironment)).
+		GetAnonymous()
+	res, err = eval.String(ns, `(vector? *args*)`)
+	if as.NoError(err) {
+		as.True(res)
+	}
+	ns = bootstrap.Sandboxed(bootstrap.Grant(bootstrap.Time)).GetAnonymous()
+	res, err = eval.String(ns, `(:result (%profile (lambda () :done)))`)
+	if as.NoError(err) {
+		as.Equal(K("done"), res)
+	}
+}
+
+func TestSandboxedInternals(t *testing.T) {
+	as := assert.New(t)
+
+	e := bootstrap.Sandboxed(bootstrap.Grant(bootstrap.Internals))
+	ns := e.GetAnonymous()
+	res, err := eval.String(ns, `
+		(define (valued? e) (contains? e :value))
+		(define (entry n)
+		  (first (filter (lambda (e) (eq (:name e) n)) (entries ale))))
+		(define (failed src)
+		  (try (eval src) (catch [e error?] (:message e))))
+		[(seq->vector (filter :private (entries ale)))
+		 (valued? (entry 'chan))
+		 (valued? (entry 'def-builtin))
+		 (valued? (entry 'when))
+		 (:private (resolve when))
+		 (valued? (resolve when))
+		 (failed '(resolve def-builtin))
+		 (failed '(resolve ale/def-macro))
+		 (failed '(resolve chan))
+		 (failed '(remove-namespace ale))]
+	`)
+	if as.NoError(err) {
+		as.Equal(V(V(), data.False, data.False, data.True, data.False, data.True,
+			S("capability not granted: def-builtin is not allowed"),
+			S("capability not granted: def-macro is not allowed"),
+			S("capability not granted: chan requires concurrency"),
+			S("root namespace can't be removed: ale"),
+		), res)
+	}
+
+	root := e.GetRoot()
+	for _, n := range []string{"current-time", "def-builtin"} {
+		for _, src := range []string{"(unbind %s)", "(undeclare %s)"} {
+			_, err = eval.String(root, data.String(fmt.Sprintf(src, n)))
+			as.EqualError(err, "root namespace entries can't be changed: "+n)
+		}
+	}
+}
+
+func TestSandboxedAllow(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.Sandboxed(bootstrap.Allow(
+		"+", "*", "lambda", "let", "current-time", "string/upper",
+	)).GetAnonymous()
+
+	res, err := eval.String(ns, `(let [sq (lambda (x) (* x x))] (+ (sq 3) 1))`)
+	if as.NoError(err) {
+		as.Number(10, res)
+	}
+
+	_, err = eval.String(ns, `(- 3 1)`)
+	as.EqualError(err, "capability not granted: - is not allowed")
+
+	_, err = eval.String(ns, `(current-time)`)
+	as.EqualError(err, "capability not granted: current-time requires time")
+
+	res, err = eval.String(ns, `(string/upper "still here")`)
+	if as.NoError(err) {
+		as.String("STILL HERE", res)
+	}
+
+	_, err = eval.String(ns, `(string/split "a,b" ",")`)
+	as.EqualError(err, "capability not granted: string/split is not allowed")
+
+	ns = bootstrap.Sandboxed(bootstrap.Allow("+")).GetAnonymous()
+	for _, src := range []string{
+		`(string/split "a,b" ",")`,
+		`(math/sqrt 4)`,
+		`(regex/compile "a+")`,
+		`(json/write 1)`,
+	} {
+		_, err = eval.String(ns, data.String(src))
+		as.ErrorContains(err, " is not allowed")
+	}
+}
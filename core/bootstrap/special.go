@@ -9,6 +9,7 @@ import (
 
 func (b *bootstrap) populateSpecialForms() {
 	b.specials(map[data.Local]compiler.Call{
+		env.Alias:        special.Alias,
 		env.Asm:          special.Asm,
 		env.Eval:         special.Eval,
 		env.Import:       special.Import,
@@ -17,10 +18,17 @@ func (b *bootstrap) populateSpecialForms() {
 		env.LetMutual:    special.LetMutual,
 		env.MacroExpand1: special.MacroExpand1,
 		env.MacroExpand:  special.MacroExpand,
+		env.Require:      special.Require,
 		env.Special:      special.Special,
+		env.Warn:         special.Warn,
 
-		env.Declared:      special.Declared,
-		env.MakeNamespace: special.MakeNamespace,
+		env.Declared:        special.Declared,
+		env.Entries:         special.Entries,
+		env.MakeNamespace:   special.MakeNamespace,
+		env.RemoveNamespace: special.RemoveNamespace,
+		env.Resolve:         special.Resolve,
+		env.Unbind:          special.Unbind,
+		env.Undeclare:       special.Undeclare,
 	})
 }
 
@@ -0,0 +1,65 @@
+package builtin
+
+import (
+	"context"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/runtime"
+	"github.com/kode4food/ale/internal/sync"
+)
+
+// Atom creates a new shared mutable reference to the provided value
+var Atom = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	return sync.NewAtom(args[0])
+}, 1)
+
+// Deref returns the current value of an atom, or forces a promise
+var Deref = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
+	if p, ok := args[0].(*sync.Promise); ok {
+		return p.CallContext(ctx)
+	}
+	return args[0].(*sync.Atom).Deref()
+}, 1)
+
+// Reset changes the value of an atom, returning the new value
+var Reset = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
+	return args[0].(*sync.Atom).ResetContext(ctx, args[1])
+}, 2)
+
+// Swap changes the value of an atom by applying a procedure to its current
+// value and any additional arguments, returning the new value
+var Swap = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
+	a := args[0].(*sync.Atom)
+	fn := args[1].(data.Procedure)
+	return a.SwapContext(ctx, fn, args[2:]...)
+}, 2, data.OrMore)
+
+// CompareAndSet changes the value of an atom only if its current value is
+// equal to the expected one, returning whether the change was made
+var CompareAndSet = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
+	a := args[0].(*sync.Atom)
+	return data.Bool(a.CompareAndSetContext(ctx, args[1], args[2]))
+}, 3)
+
+// AddWatch registers a procedure to be called whenever an atom changes
+var AddWatch = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	a := args[0].(*sync.Atom)
+	a.AddWatch(args[1], args[2].(data.Procedure))
+	return a
+}, 3)
+
+// RemoveWatch unregisters a procedure previously added with AddWatch
+var RemoveWatch = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	a := args[0].(*sync.Atom)
+	a.RemoveWatch(args[1])
+	return a
+}, 2)
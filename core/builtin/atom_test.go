@@ -0,0 +1,62 @@
+package builtin_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestAtomEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(str (atom 1))`, S("#<atom 1>"))
+	as.MustEvalTo(`(str (atom {:a "b"}))`, S(`#<atom {:a "b"}>`))
+
+	as.MustEvalTo(`
+		(let [a (atom 1)]
+			(swap! a + 2 3)
+			(deref a))
+	`, I(6))
+
+	as.MustEvalTo(`
+		(let [a (atom [])]
+			(reset! a [1])
+			(swap! a conj 2))
+	`, V(I(1), I(2)))
+
+	as.MustEvalTo(`
+		(let* ([a  (atom 1)]
+		       [r1 (compare-and-set! a 2 3)]
+		       [r2 (compare-and-set! a 1 3)])
+			[r1 r2 (deref a)])
+	`, V(data.False, data.True, I(3)))
+
+	as.MustEvalTo(`
+		(let* ([a   (atom 0)]
+		       [log (atom [])])
+			(add-watch a :log
+				(lambda (k r old new)
+					(swap! log conj [k (eq r a) old new])))
+			(swap! a inc)
+			(reset! a 10)
+			(remove-watch a :log)
+			(reset! a 20)
+			(deref log))
+	`, V(
+		V(K("log"), data.True, I(0), I(1)),
+		V(K("log"), data.True, I(1), I(10)),
+	))
+
+	as.MustEvalTo(`
+		(let* ([a    (atom 0)]
+		       [done (chan)])
+			(for-each [i (range 0 50)]
+				(go (swap! a inc) (: done :emit i)))
+			(seq->vector (take 50 (:seq done)))
+			(deref a))
+	`, I(50))
+
+	as.MustEvalTo(`(deref (delay 42))`, I(42))
+	as.PanicWith(`(deref 1)`, "got integer, expected atom")
+}
@@ -1,10 +1,11 @@
 package builtin
 
 import (
+	"context"
+
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
-	"github.com/kode4food/ale/internal/debug"
 	"github.com/kode4food/ale/internal/runtime"
 	"github.com/kode4food/ale/internal/sequence"
 	"github.com/kode4food/ale/read"
@@ -12,34 +13,35 @@ import (
 
 var emptyNamespace = env.NewEnvironment().GetRoot()
 
-// Recover invokes a function and runs a recovery function if Go panics
-var Recover = data.MakeProcedure(func(args ...ale.Value) (res ale.Value) {
+// Recover invokes a function and runs a recovery function if Go panics. If
+// the calling context is already done, the recovery function still runs, but
+// the cancellation is raised again once it returns
+var Recover = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) (res ale.Value) {
 	body := args[0].(data.Procedure)
 	rescue := args[1].(data.Procedure)
 
 	defer func() {
 		if rec := recover(); rec != nil {
-			switch rec := runtime.NormalizeGoRuntimeError(rec).(type) {
-			case ale.Value:
-				res = rescue.Call(rec)
-			case error:
-				res = rescue.Call(data.String(rec.Error()))
-			default:
-				panic(debug.ProgrammerError("recover returned invalid result"))
-			}
+			res = callUncancelled(ctx, rescue, runtime.Recovered(rec))
 		}
 	}()
 
-	return body.Call()
+	return runtime.Call(ctx, body)
 }, 2)
 
-// Defer invokes a cleanup function, no matter what has happened
-var Defer = data.MakeProcedure(func(args ...ale.Value) (res ale.Value) {
+// Defer invokes a cleanup function, no matter what has happened. If the
+// calling context is already done, the cleanup function still runs, but the
+// cancellation is raised again once it returns
+var Defer = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) (res ale.Value) {
 	body := args[0].(data.Procedure)
 	cleanup := args[1].(data.Procedure)
 
-	defer cleanup.Call()
-	return body.Call()
+	defer callUncancelled(ctx, cleanup)
+	return runtime.Call(ctx, body)
 }, 2)
 
 // Read performs the standard LISP read of a string
@@ -52,3 +54,17 @@ var Read = data.MakeProcedure(func(args ...ale.Value) ale.Value {
 	}
 	return data.Null
 }, 1)
+
+// callUncancelled calls a Procedure that has to run even if the context is
+// done. While the context is live, the Procedure is subject to it like any
+// other. Otherwise, it runs detached from the cancellation, though it's still
+// metered, and the cancellation is raised again once it returns
+func callUncancelled(
+	ctx context.Context, fn data.Procedure, args ...ale.Value,
+) ale.Value {
+	if ctx.Err() == nil {
+		return runtime.Call(ctx, fn, args...)
+	}
+	runtime.Call(context.WithoutCancel(ctx), fn, args...)
+	panic(runtime.ContextError(ctx))
+}
@@ -99,3 +99,50 @@ func TestReadEval(t *testing.T) {
 		(eval (read "(str \"hello\" \"you\" \"test\")"))
 	`, S("helloyoutest"))
 }
+
+func TestTryCatchEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(try 1 (catch [e string?] e))`, F(1))
+	as.MustEvalTo(`
+		(try (raise "boom")
+		  (catch [e number?] :number)
+		  (catch [e string?] (str "caught " e)))
+	`, S("caught boom"))
+	as.MustEvalTo(`(try (+ 1 2) (finally 99))`, F(3))
+	as.PanicWith(`(try (raise "boom") (catch [e number?] e))`, "boom")
+}
+
+func TestTryExpandEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(try)`, data.Null)
+	as.MustEvalTo(`(try 1)`, F(1))
+	as.MustEvalTo(`(try 1 (finally 2))`, F(1))
+	as.MustEvalTo(`(seq? (macroexpand '(try 1 (finally 2))))`, data.True)
+	as.MustEvalTo(`
+		(let [log (atom [])]
+		  (try
+		    (try (raise "boom")
+		      (finally (swap! log conj :inner)))
+		    (catch [e string?] (swap! log conj e))
+		    (finally (swap! log conj :outer)))
+		  (deref log))
+	`, data.NewVector(K("inner"), S("boom"), K("outer")))
+}
+
+func TestCatchStackEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(define (inner x)
+		  (if (= x 0)
+		      (begin (+ x "boom") x)
+		      (inner (- x 1))))
+		(try (inner 2)
+		  (catch [e :type-error]
+		    (seq->vector
+		      (map (lambda (f) [(:name f) (:args f)])
+		           (take 2 (:stack e))))))
+	`, data.NewVector(
+		data.NewVector(LS("+"), I(2)),
+		data.NewVector(LS("inner"), I(1)),
+	))
+}
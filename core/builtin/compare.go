@@ -0,0 +1,71 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/runtime"
+	"github.com/kode4food/ale/internal/sequence"
+)
+
+type comparator func(l, r ale.Value) int
+
+// Compare returns -1, 0, or 1 depending on whether the first Value is ordered
+// before, the same as, or after the second Value
+var Compare = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	return data.Integer(compareValues(args[0], args[1]))
+}, 2)
+
+// Sort returns a vector of the elements of a sequence in ascending order. The
+// sort is stable, and can be performed using a custom comparator procedure
+var Sort = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
+	cmp := compareValues
+	if len(args) > 1 {
+		cmp = makeComparator(ctx, args[0].(data.Procedure))
+	}
+	seq := args[len(args)-1].(data.Sequence)
+	res := slices.Clone(sequence.ToVector(seq))
+	slices.SortStableFunc(res, cmp)
+	return res
+}, 1, 2)
+
+func compareValues(l, r ale.Value) int {
+	c := data.Compare(l, r)
+	if c == data.Incomparable {
+		panic(data.NewError(
+			data.TypeErrorKind,
+			fmt.Sprintf(data.ErrIncomparable,
+				data.ToQuotedString(l), data.ToQuotedString(r),
+			),
+			data.NewVector(l, r),
+		))
+	}
+	return int(c)
+}
+
+// makeComparator adapts a comparator procedure to Go. The procedure either
+// returns a number whose sign orders the Values, as compare does, or a
+// boolean that reports whether the first Value is ordered before the second
+func makeComparator(ctx context.Context, fn data.Procedure) comparator {
+	return func(l, r ale.Value) int {
+		res := runtime.Call(ctx, fn, l, r)
+		if n, ok := res.(data.Number); ok {
+			if c := n.Cmp(data.Integer(0)); c != data.Incomparable {
+				return int(c)
+			}
+			return 0
+		}
+		if res != data.False {
+			return -1
+		}
+		if runtime.Call(ctx, fn, r, l) != data.False {
+			return 1
+		}
+		return 0
+	}
+}
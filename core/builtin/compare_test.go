@@ -0,0 +1,70 @@
+package builtin_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestCompareValuesEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(compare 1 2)`, I(-1))
+	as.MustEvalTo(`(compare "b" "a")`, I(1))
+	as.MustEvalTo(`(compare [1 :a] '(1 :a))`, I(0))
+	as.MustEvalTo(`(compare false true)`, I(-1))
+
+	as.PanicWith(`(compare 1 "a")`,
+		errors.New(`values can't be compared: 1 and "a"`),
+	)
+	as.MustEvalTo(`
+		(try (compare :a 'a) (catch [e :type-error] (:data e)))
+	`, V(K("a"), LS("a")))
+}
+
+func TestSortEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(sort [3 1/2 2.0 -1])`, V(I(-1), R(1, 2), F(2), I(3)))
+	as.MustEvalTo(`(sort '("b" "a" "c"))`, V(S("a"), S("b"), S("c")))
+	as.MustEvalTo(`(sort [[1 2] [1] [0 5] []])`,
+		V(V(), V(I(0), I(5)), V(I(1)), V(I(1), I(2))),
+	)
+	as.MustEvalTo(`(sort {:b 2 :a 1})`,
+		V(C(K("a"), I(1)), C(K("b"), I(2))),
+	)
+	as.MustEvalTo(`(sort (map inc [3 1 2]))`, V(I(2), I(3), I(4)))
+	as.MustEvalTo(`(sort [])`, V())
+	as.MustEvalTo(`
+		(let [v [3 1 2]]
+			(sort v)
+			v)
+	`, V(I(3), I(1), I(2)))
+
+	as.MustEvalTo(`(sort > [1 3 2])`, V(I(3), I(2), I(1)))
+	as.MustEvalTo(`(sort (lambda (l r) (compare r l)) [1 3 2])`,
+		V(I(3), I(2), I(1)),
+	)
+}
+
+func TestSortByEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(let [people [{:n 1 :age 30} {:n 2 :age 20} {:n 3 :age 30}]]
+			(seq->vector (map :n (sort-by :age people))))
+	`, V(I(2), I(1), I(3)))
+	as.MustEvalTo(`
+		(let [people [{:n 1 :age 30} {:n 2 :age 20} {:n 3 :age 30}]]
+			(seq->vector (map :n (sort-by :age > people))))
+	`, V(I(1), I(3), I(2)))
+}
+
+func TestMinMaxByEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(:n (min-by :age [{:n 1 :age 30} {:n 2 :age 20} {:n 3 :age 20}]))
+	`, I(2))
+	as.MustEvalTo(`(max-by length ["a" "bbb" "cc" "ddd"])`, S("bbb"))
+	as.MustEvalTo(`(min-by length [])`, data.Null)
+}
@@ -1,7 +1,11 @@
 package builtin
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"slices"
+	"time"
 
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
@@ -10,26 +14,104 @@ import (
 	"github.com/kode4food/ale/internal/sync"
 )
 
-// Go runs the provided function asynchronously
-var Go = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+// Go runs the provided function asynchronously, with the context of the
+// calling evaluation. If that context is done, the function is stopped
+var Go = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
 	fn := args[0].(data.Procedure)
 	callArgs := slices.Clone(args[1:])
+	ctx = runtime.ForkContext(ctx)
 	go func() {
 		defer runtime.NormalizeGoRuntimeErrors()
-		fn.Call(callArgs...)
+		defer runtime.IgnoreCancelled(ctx)
+		runtime.Call(ctx, fn, callArgs...)
 	}()
 	return data.Null
 }, 1, data.OrMore)
 
-// Chan instantiates a new go channel
-var Chan = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+// Chan instantiates a new go channel that observes the context of the
+// calling evaluation
+var Chan = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
 	var size int
 	if len(args) != 0 {
 		size = int(args[0].(data.Integer))
 	}
-	return stream.NewChannel(size)
+	return stream.NewChannelContext(ctx, size)
 }, 0, 1)
 
+// Error messages
+const (
+	// ErrBadSelectCase is raised when a select case is malformed
+	ErrBadSelectCase = "invalid select case: %s"
+
+	// ErrMultipleSelectExpiry is raised when a select includes more than one
+	// timeout or default case
+	ErrMultipleSelectExpiry = "select can only have one :timeout or :default"
+)
+
+const (
+	RecvKey    = data.Keyword("recv")
+	SendKey    = data.Keyword("send")
+	TimeoutKey = data.Keyword("timeout")
+	DefaultKey = data.Keyword("default")
+)
+
+// Select waits until one of the provided channel operations can proceed and
+// performs it, returning a vector of the chosen case's index and any received
+// value. Cases are vectors of the form [:recv seq], [:send emitter value],
+// [:timeout ms], or [:default]
+var Select = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
+	cases := make([]stream.SelectCase, 0, len(args))
+	caseIdx := make([]int, 0, len(args))
+	timeout := stream.Forever
+	expired := -1
+	for i, a := range args {
+		c, ok := a.(data.Vector)
+		if !ok || len(c) == 0 {
+			panic(fmt.Errorf(ErrBadSelectCase, data.ToString(a)))
+		}
+		switch {
+		case c[0] == RecvKey && len(c) == 2:
+			cases = append(cases, stream.RecvCase(c[1].(data.Sequence)))
+			caseIdx = append(caseIdx, i)
+		case c[0] == SendKey && len(c) == 3:
+			sc, err := stream.SendCase(c[1], c[2])
+			if err != nil {
+				panic(err)
+			}
+			cases = append(cases, sc)
+			caseIdx = append(caseIdx, i)
+		case c[0] == TimeoutKey && len(c) == 2:
+			expired = selectExpiry(expired, i)
+			ms := c[1].(data.Integer)
+			timeout = max(time.Duration(ms)*time.Millisecond, 0)
+		case c[0] == DefaultKey && len(c) == 1:
+			expired = selectExpiry(expired, i)
+			timeout = 0
+		default:
+			panic(fmt.Errorf(ErrBadSelectCase, data.ToString(a)))
+		}
+	}
+
+	i, res := stream.SelectContext(ctx, cases, timeout)
+	if i < 0 {
+		return data.NewVector(data.Integer(expired), data.Null)
+	}
+	return data.NewVector(data.Integer(caseIdx[i]), res)
+}, 1, data.OrMore)
+
+func selectExpiry(prev, idx int) int {
+	if prev != -1 {
+		panic(errors.New(ErrMultipleSelectExpiry))
+	}
+	return idx
+}
+
 // isResolved returns whether the specified promise has been resolved
 func isResolved(v ale.Value) bool {
 	if p, ok := v.(*sync.Promise); ok {
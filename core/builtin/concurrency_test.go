@@ -1,6 +1,8 @@
 package builtin_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/kode4food/ale"
@@ -60,6 +62,26 @@ func TestGenerateEval(t *testing.T) {
 	`, F(1199))
 }
 
+func TestGenerateErrorEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(seq->vector (generate
+			(emit 1 2)
+			(raise "boom")
+			(emit 3)))
+	`, V(I(1), I(2)))
+}
+
+func TestCancelledEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(cancelled?)`, data.False)
+	as.MustEvalTo(`
+		(select
+		  [(:recv _ (done-chan)) :done]
+		  [:default :running])
+	`, K("running"))
+}
+
 func TestDelayEval(t *testing.T) {
 	as := assert.New(t)
 	as.MustEvalTo(`
@@ -86,3 +108,54 @@ func TestFutureEval(t *testing.T) {
 		(p)
 	`, S("hello"))
 }
+
+func TestSelectEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(let ([c1 (chan)] [c2 (chan)])
+			(go ((:emit c2) "two"))
+			(select
+				[(:recv v (:seq c1)) [:c1 v]]
+				[(:recv v (:seq c2)) [:c2 v]]))
+	`, V(K("c2"), S("two")))
+
+	as.MustEvalTo(`
+		(let [c (chan)]
+			[(select [(:recv v (:seq c)) v] [:default :nothing])
+			 (select [(:recv v (:seq c)) v] [(:timeout 10) :timed-out])])
+	`, V(K("nothing"), K("timed-out")))
+
+	as.MustEvalTo(`
+		(let* ([c (chan 1)]
+		       [r1 (select [(:send (:emit c) 42) :sent] [:default :full])]
+		       [r2 (select [(:send (:emit c) 43) :sent] [:default :full])])
+			[r1 r2 (first (:seq c))])
+	`, V(K("sent"), K("full"), I(42)))
+
+	as.MustEvalTo(`
+		(define c1 (chan))
+		(define c2 (chan))
+		(go (: c1 :emit 1 2 3))
+		(go (: c2 :emit 10 20))
+		(define (fan-in s1 s2 acc count)
+			(if (= count 0)
+				acc
+				(select
+					[(:recv v s1) (fan-in (rest s1) s2 (+ acc v) (dec count))]
+					[(:recv v s2) (fan-in s1 (rest s2) (+ acc v) (dec count))])))
+		(fan-in (:seq c1) (:seq c2) 0 5)
+	`, I(36))
+
+	as.PanicWith(`(select [(:recv 1 [1]) 1])`,
+		"invalid select clause: [(:recv 1 [1]) 1]",
+	)
+	as.PanicWith(`(select [(:send 1 2) 1])`,
+		fmt.Errorf(stream.ErrExpectedEmitter, "1"),
+	)
+	as.PanicWith(`(select [(:bogus) 1])`,
+		fmt.Errorf(builtin.ErrBadSelectCase, "[:bogus]"),
+	)
+	as.PanicWith(`(select [:default 1] [(:timeout 1) 2])`,
+		errors.New(builtin.ErrMultipleSelectExpiry),
+	)
+}
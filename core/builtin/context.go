@@ -0,0 +1,26 @@
+package builtin
+
+import (
+	"context"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/runtime"
+	"github.com/kode4food/ale/internal/stream"
+)
+
+// IsCancelled returns whether the context of the calling evaluation has been
+// cancelled or its deadline exceeded
+var IsCancelled = runtime.MakeContextProcedure(func(
+	ctx context.Context, _ ...ale.Value,
+) ale.Value {
+	return data.Bool(ctx.Err() != nil)
+}, 0)
+
+// DoneChan returns a channel sequence that ends when the context of the
+// calling evaluation is done. It can be used as a select case
+var DoneChan = runtime.MakeContextProcedure(func(
+	ctx context.Context, _ ...ale.Value,
+) ale.Value {
+	return stream.NewDoneSequence(ctx)
+}, 0)
@@ -0,0 +1,19 @@
+package builtin
+
+import (
+	"strings"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/compiler/asm"
+)
+
+// Disasm returns the instructions of a compiled procedure as a string, in the
+// syntax of the asm special form
+var Disasm = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	var buf strings.Builder
+	if err := asm.Disassemble(&buf, args[0]); err != nil {
+		panic(err)
+	}
+	return data.String(buf.String())
+}, 1)
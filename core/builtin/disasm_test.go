@@ -0,0 +1,26 @@
+package builtin_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/compiler/asm"
+)
+
+func TestDisasm(t *testing.T) {
+	as := assert.New(t)
+
+	res := as.MustEval(`(disasm (lambda () 42))`)
+	as.Contains("(lambda ()", res)
+	as.Contains("pos-int 42", res)
+	as.Contains("(asm", res)
+
+	as.MustEvalTo(`
+		(define (inc x) (+ x 1))
+		((eval (read (disasm inc))) 41)
+	`, I(42))
+
+	as.PanicWith(`(disasm 42)`, fmt.Errorf(asm.ErrExpectedProcedure, "42"))
+}
@@ -0,0 +1,43 @@
+package builtin
+
+import (
+	"errors"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+)
+
+// Error constructs a new first-class Error from a kind, a message, and an
+// optional data Value and cause
+var Error = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	kind := args[0].(data.Keyword)
+	msg := data.ToString(args[1])
+	var d ale.Value = data.Null
+	if len(args) > 2 {
+		d = args[2]
+	}
+	res := data.NewError(kind, msg, d)
+	if len(args) > 3 {
+		return res.WithCause(toError(args[3]))
+	}
+	return res
+}, 2, 4)
+
+// ErrorKind returns the Keyword that identifies the kind of Error
+var ErrorKind = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	return args[0].(*data.Error).Kind()
+}, 1)
+
+// ErrorCause returns the Error that caused the provided Error, or null if
+// there isn't one
+var ErrorCause = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	res, _ := args[0].(*data.Error).Get(data.CauseKey)
+	return res
+}, 1)
+
+func toError(v ale.Value) error {
+	if err, ok := v.(error); ok {
+		return err
+	}
+	return errors.New(data.ToString(v))
+}
@@ -0,0 +1,68 @@
+package builtin_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestErrorEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(error-kind (error :bad "message"))`, K("bad"))
+	as.MustEvalTo(`(:message (error :bad "message"))`, S("message"))
+	as.MustEvalTo(`(:data (error :bad "message" {:a 1}))`, O(C(K("a"), I(1))))
+	as.MustEvalTo(`(error-cause (error :bad "message"))`, data.Null)
+	as.MustEvalTo(`
+		(error-kind (error-cause (error :outer "o" null (error :inner "i"))))
+	`, K("inner"))
+	as.MustEvalTo(`(:message (error-cause (error :outer "o" null "text")))`,
+		S("text"),
+	)
+	as.MustEvalTo(`(error? (error :bad "message"))`, data.True)
+	as.MustEvalTo(`(!error? "message")`, data.True)
+
+	as.PanicWith(`(error-kind "not an error")`,
+		errors.New("got string, expected error"),
+	)
+}
+
+func TestCatchKindEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(try (raise (error :custom "bad" 42))
+		  (catch [e :other] :other)
+		  (catch [e :custom] (:data e)))
+	`, I(42))
+	as.MustEvalTo(`
+		(try (raise "boom")
+		  (catch [e :error] :error)
+		  (catch [e string?] e))
+	`, S("boom"))
+	as.MustEvalTo(`(try (raise 42) (catch [e number?] e))`, I(42))
+	as.MustEvalTo(`(try (+ 1 "x") (catch [e :type-error] (:message e)))`,
+		S("got string, expected number"),
+	)
+	as.MustEvalTo(`(try (+ 1 "x") (catch [e error?] (:message e)))`,
+		S("got string, expected number"),
+	)
+	as.PanicWith(`(try (+ 1 "x") (catch [e object?] e))`,
+		data.NewError(
+			data.TypeErrorKind, "got string, expected number", data.Null,
+		),
+	)
+	as.MustEvalTo(`(object? (error :custom "bad"))`, data.False)
+	as.MustEvalTo(`(try ((lambda (x) x)) (catch [e :arity-error] :arity))`,
+		K("arity"),
+	)
+	as.MustEvalTo(`
+		(try (raise (error :custom "bad"))
+		  (catch [e :custom] (vector? (:stack e))))
+	`, data.True)
+
+	as.PanicWith(`(try (raise (error :custom "bad")) (catch [e :other] e))`,
+		errors.New("bad"),
+	)
+}
@@ -58,3 +58,21 @@ func TestIOEval(t *testing.T) {
 		(pr "hello" 99)
 	`, "\"hello\" 99")
 }
+
+func TestWithOpenEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(with-open [] 1)`, I(1))
+	as.MustEvalTo(`
+		(with-open [x 1 y {:a 2}]
+			(+ x (:a y)))
+	`, I(3))
+	as.MustEvalTo(`
+		(let [ch (chan 1)]
+			(with-open [c ch]
+				(: c :emit 1))
+			(seq->vector (:seq ch)))
+	`, V(I(1)))
+	as.PanicWith(`(with-open [x 1 y] x)`,
+		"invalid with-open bindings: [x 1 y]",
+	)
+}
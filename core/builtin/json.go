@@ -0,0 +1,102 @@
+package builtin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/sequence"
+	"github.com/kode4food/ale/internal/stream"
+)
+
+// ErrUnknownJSONOption is raised when a JSON procedure is called with an
+// option that it doesn't recognize
+const ErrUnknownJSONOption = "unknown json option: %s"
+
+// PrettyKey is the JSON option used to request pretty-printed output
+const PrettyKey = data.Keyword("pretty")
+
+const jsonIndent = "  "
+
+// JSONRead decodes JSON text into a Value. The keys of decoded objects are
+// keywords, unless the :string option is provided
+var JSONRead = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	keys := jsonKeys(args[1:])
+	res, err := stream.ReadJSON(jsonText(args[0]), keys)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}, 1, 2)
+
+// JSONReadLines lazily decodes newline-delimited JSON. The source is either
+// text or a sequence of lines, such as the one returned by a line reader
+var JSONReadLines = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	keys := jsonKeys(args[1:])
+	switch src := args[0].(type) {
+	case data.String:
+		r := strings.NewReader(string(src))
+		return stream.NewReader(r, stream.JSONInput(keys))
+	default:
+		return readJSONLines(src.(data.Sequence), keys)
+	}
+}, 1, 2)
+
+// JSONWrite encodes a Value as JSON text. The text is pretty-printed if the
+// :pretty option is provided
+var JSONWrite = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	var indent string
+	if len(args) > 1 {
+		if args[1] != PrettyKey {
+			panic(unknownJSONOption(args[1]))
+		}
+		indent = jsonIndent
+	}
+	res, err := stream.WriteJSON(args[0], indent)
+	if err != nil {
+		panic(err)
+	}
+	return data.String(res)
+}, 1, 2)
+
+func jsonKeys(opts []ale.Value) stream.JSONKeys {
+	if len(opts) == 0 {
+		return stream.KeywordKeys
+	}
+	switch opts[0] {
+	case KeywordKey:
+		return stream.KeywordKeys
+	case StringKey:
+		return stream.StringKeys
+	default:
+		panic(unknownJSONOption(opts[0]))
+	}
+}
+
+func unknownJSONOption(opt ale.Value) error {
+	msg := fmt.Sprintf(ErrUnknownJSONOption, opt)
+	return data.NewError(stream.JSONErrorKind, msg, opt)
+}
+
+func jsonText(v ale.Value) []byte {
+	if b, ok := v.(data.Bytes); ok {
+		return b
+	}
+	return []byte(v.(data.String))
+}
+
+func readJSONLines(s data.Sequence, keys stream.JSONKeys) data.Sequence {
+	return sequence.NewLazy(func() (ale.Value, data.Sequence, bool) {
+		for f, r, ok := s.Split(); ok; f, r, ok = r.Split() {
+			if l := strings.TrimSpace(data.ToString(f)); l != "" {
+				res, err := stream.ReadJSON([]byte(l), keys)
+				if err != nil {
+					panic(err)
+				}
+				return res, readJSONLines(r, keys), true
+			}
+		}
+		return data.Null, data.Null, false
+	})
+}
@@ -0,0 +1,74 @@
+package builtin_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/ale/core/builtin"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/stream"
+)
+
+func TestJSONReadEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(json/read "{\"a\": [1, 2.5, null, true]}")`,
+		O(C(K("a"), V(I(1), F(2.5), data.Null, data.True))),
+	)
+	as.MustEvalTo(`(json/read "{\"a\": 1}" :string)`, O(C(S("a"), I(1))))
+	as.MustEvalTo(`(json/read "{\"a\": 1}" :keyword)`, O(C(K("a"), I(1))))
+	as.MustEvalTo(`(json/read (bytes 91 49 93))`, V(I(1)))
+	as.MustEvalTo(`(:b (json/read "{\"b\": \"c\"}"))`, S("c"))
+
+	as.PanicWith(`(json/read "1" :bad)`,
+		fmt.Errorf(builtin.ErrUnknownJSONOption, K("bad")),
+	)
+	as.PanicWith(`(json/read "[1")`, errors.New("unexpected EOF"))
+}
+
+func TestJSONWriteEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(json/write {:b [1 2.0 1/2] "a" null})`,
+		S(`{"a":null,"b":[1,2.0,0.5]}`),
+	)
+	as.MustEvalTo(`(json/write [:kw "s" true])`, S(`["kw","s",true]`))
+	as.MustEvalTo(`(json/write (map inc [1 2]))`, S(`[2,3]`))
+	as.MustEvalTo(`(json/write {:a [1]} :pretty)`,
+		S("{\n  \"a\": [\n    1\n  ]\n}"),
+	)
+	as.MustEvalTo(`
+		(let [v {:a [1 {:b "c"}] :d 12345678901234567890}]
+			(eq v (json/read (json/write v))))
+	`, data.True)
+
+	as.PanicWith(`(json/write nan)`,
+		errors.New("value can't be represented as JSON: nan"),
+	)
+	as.PanicWith(`(json/write 1 :ugly)`,
+		fmt.Errorf(builtin.ErrUnknownJSONOption, K("ugly")),
+	)
+	as.MustEvalTo(`
+		[(try (json/read "[1") (catch [e :json-error] (:kind e)))
+		 (try (json/read "1 2") (catch [e :json-error] (:message e)))
+		 (try (json/write nan) (catch [e :json-error] (:kind e)))
+		 (try (json/write 1 :ugly) (catch [e :json-error] (:data e)))
+		 (try (json/read "1" :bad) (catch [e :json-error] (:kind e)))]
+	`, V(
+		stream.JSONErrorKind, S(stream.ErrTrailingJSON), stream.JSONErrorKind,
+		K("ugly"), stream.JSONErrorKind,
+	))
+}
+
+func TestJSONReadLinesEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(seq->vector (json/read-lines "{\"a\": 1}\n\n{\"a\": 2}\n"))
+	`, V(O(C(K("a"), I(1))), O(C(K("a"), I(2)))))
+	as.MustEvalTo(`
+		(seq->vector (json/read-lines ["{\"a\": 1}" " " "[2]"] :string))
+	`, V(O(C(S("a"), I(1))), V(I(2))))
+	as.MustEvalTo(`(first (json/read-lines ["1" "{"]))`, I(1))
+	as.MustEvalTo(`(empty? (json/read-lines ""))`, data.True)
+}
@@ -0,0 +1,388 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+)
+
+// ErrExpectedFinite is raised when an exact conversion is attempted on a
+// Float that is either infinite or not a number
+const ErrExpectedFinite = "value is not finite: %s"
+
+var (
+	bigOne  = big.NewInt(1)
+	bigHalf = big.NewRat(1, 2)
+)
+
+// MathAbs returns the absolute value of a number
+var MathAbs = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	switch n := args[0].(data.Number).(type) {
+	case data.Integer:
+		if n >= 0 {
+			return n
+		}
+		return data.Integer(0).Sub(n)
+	case data.Float:
+		return data.Float(math.Abs(float64(n)))
+	case *data.BigInt:
+		return normalizeInt(new(big.Int).Abs((*big.Int)(n)))
+	case *data.Ratio:
+		return normalizeRat(new(big.Rat).Abs((*big.Rat)(n)))
+	default:
+		return n
+	}
+}, 1)
+
+// MathFloor returns the largest integer that is not greater than a number
+var MathFloor = makeRounder(math.Floor, func(r *big.Rat) *big.Int {
+	return new(big.Int).Div(r.Num(), r.Denom())
+})
+
+// MathCeil returns the smallest integer that is not less than a number
+var MathCeil = makeRounder(math.Ceil, func(r *big.Rat) *big.Int {
+	res := new(big.Int).Div(r.Num(), r.Denom())
+	return res.Add(res, bigOne)
+})
+
+// MathRound returns the integer nearest to a number. Halfway cases are
+// rounded away from zero
+var MathRound = makeRounder(math.Round, func(r *big.Rat) *big.Int {
+	res := new(big.Rat).Abs(r)
+	res.Add(res, bigHalf)
+	i := new(big.Int).Div(res.Num(), res.Denom())
+	if r.Sign() < 0 {
+		return i.Neg(i)
+	}
+	return i
+})
+
+// MathTruncate returns the integer part of a number, rounding toward zero
+var MathTruncate = makeRounder(math.Trunc, func(r *big.Rat) *big.Int {
+	return new(big.Int).Quo(r.Num(), r.Denom())
+})
+
+// MathMin returns the smallest of the provided numbers
+var MathMin = makeExtremum(data.LessThan)
+
+// MathMax returns the largest of the provided numbers
+var MathMax = makeExtremum(data.GreaterThan)
+
+// MathSqrt returns the square root of a number. The result is exact if the
+// number is an exact perfect square
+var MathSqrt = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	switch n := args[0].(data.Number).(type) {
+	case data.Integer, *data.BigInt:
+		if res, ok := exactSqrt(toBigInt(n)); ok {
+			return normalizeInt(res)
+		}
+	case *data.Ratio:
+		r := (*big.Rat)(n)
+		num, nok := exactSqrt(r.Num())
+		den, dok := exactSqrt(r.Denom())
+		if nok && dok {
+			return normalizeRat(new(big.Rat).SetFrac(num, den))
+		}
+	}
+	return data.Float(math.Sqrt(float64(toFloat(args[0]))))
+}, 1)
+
+// MathPow raises a base to the power of an exponent. The result is exact if
+// the base is exact and the exponent is an integer
+var MathPow = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	base := args[0].(data.Number)
+	exp := args[1].(data.Number)
+	if e, ok := exp.(data.Integer); ok && isExact(base) {
+		return exactPow(base, e)
+	}
+	return data.Float(math.Pow(
+		float64(toFloat(base)), float64(toFloat(exp)),
+	))
+}, 2)
+
+// MathExp returns e raised to the power of a number
+var MathExp = makeFloatFunc(math.Exp)
+
+// MathLog returns the natural logarithm of a number, or its logarithm in the
+// provided base
+var MathLog = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	res := math.Log(float64(toFloat(args[0])))
+	if len(args) > 1 {
+		res /= math.Log(float64(toFloat(args[1])))
+	}
+	return data.Float(res)
+}, 1, 2)
+
+// MathSin returns the sine of an angle in radians
+var MathSin = makeFloatFunc(math.Sin)
+
+// MathCos returns the cosine of an angle in radians
+var MathCos = makeFloatFunc(math.Cos)
+
+// MathTan returns the tangent of an angle in radians
+var MathTan = makeFloatFunc(math.Tan)
+
+// MathAsin returns the arcsine of a number in radians
+var MathAsin = makeFloatFunc(math.Asin)
+
+// MathAcos returns the arccosine of a number in radians
+var MathAcos = makeFloatFunc(math.Acos)
+
+// MathAtan returns the arctangent of a number in radians. If two numbers are
+// provided, the arctangent of y/x is returned, using their signs to determine
+// the quadrant
+var MathAtan = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	y := float64(toFloat(args[0]))
+	if len(args) > 1 {
+		return data.Float(math.Atan2(y, float64(toFloat(args[1]))))
+	}
+	return data.Float(math.Atan(y))
+}, 1, 2)
+
+// MathGCD returns the greatest common divisor of the provided integers
+var MathGCD = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	res := new(big.Int)
+	for _, a := range args {
+		res.GCD(nil, nil, res, new(big.Int).Abs(toBigInt(a)))
+	}
+	return normalizeInt(res)
+}, 0, data.OrMore)
+
+// MathLCM returns the least common multiple of the provided integers
+var MathLCM = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	res := big.NewInt(1)
+	for _, a := range args {
+		i := new(big.Int).Abs(toBigInt(a))
+		if i.Sign() == 0 {
+			return data.Integer(0)
+		}
+		gcd := new(big.Int).GCD(nil, nil, res, i)
+		res.Mul(res, i.Quo(i, gcd))
+	}
+	return normalizeInt(res)
+}, 0, data.OrMore)
+
+// MathNumerator returns the numerator of a rational number
+var MathNumerator = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	switch n := args[0].(data.Number).(type) {
+	case *data.Ratio:
+		return normalizeInt(new(big.Int).Set((*big.Rat)(n).Num()))
+	case data.Float:
+		r := toRat(n)
+		return toFloat(normalizeInt(new(big.Int).Set(r.Num())))
+	default:
+		return normalizeInt(toBigInt(n))
+	}
+}, 1)
+
+// MathDenominator returns the denominator of a rational number
+var MathDenominator = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	switch n := args[0].(data.Number).(type) {
+	case *data.Ratio:
+		return normalizeInt(new(big.Int).Set((*big.Rat)(n).Denom()))
+	case data.Float:
+		r := toRat(n)
+		return toFloat(normalizeInt(new(big.Int).Set(r.Denom())))
+	default:
+		toBigInt(n)
+		return data.Integer(1)
+	}
+}, 1)
+
+// MathExactToInexact converts a number to its floating point equivalent
+var MathExactToInexact = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	return toFloat(args[0])
+}, 1)
+
+// MathInexactToExact converts a number to the exact integer or ratio that it
+// represents
+var MathInexactToExact = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	if f, ok := args[0].(data.Float); ok {
+		return normalizeRat(toRat(f))
+	}
+	return args[0].(data.Number)
+}, 1)
+
+// MathBitAnd returns the bitwise AND of the provided integers
+var MathBitAnd = makeBitwise((*big.Int).And, -1)
+
+// MathBitOr returns the bitwise inclusive OR of the provided integers
+var MathBitOr = makeBitwise((*big.Int).Or, 0)
+
+// MathBitXor returns the bitwise exclusive OR of the provided integers
+var MathBitXor = makeBitwise((*big.Int).Xor, 0)
+
+// MathBitNot returns the bitwise complement of an integer
+var MathBitNot = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	return normalizeInt(new(big.Int).Not(toBigInt(args[0])))
+}, 1)
+
+// MathShiftLeft shifts the bits of an integer to the left
+var MathShiftLeft = makeShift((*big.Int).Lsh, (*big.Int).Rsh)
+
+// MathShiftRight performs an arithmetic shift of the bits of an integer to
+// the right
+var MathShiftRight = makeShift((*big.Int).Rsh, (*big.Int).Lsh)
+
+func makeRounder(
+	float func(float64) float64, exact func(*big.Rat) *big.Int,
+) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		switch n := args[0].(data.Number).(type) {
+		case data.Float:
+			return data.Float(float(float64(n)))
+		case *data.Ratio:
+			return normalizeInt(exact((*big.Rat)(n)))
+		default:
+			return n
+		}
+	}, 1)
+}
+
+func makeExtremum(keep data.Comparison) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		res := args[0].(data.Number)
+		for _, a := range args[1:] {
+			n := a.(data.Number)
+			if res.IsNaN() || n.IsNaN() {
+				res = data.Float(math.NaN())
+				continue
+			}
+			if n.Cmp(res) == keep {
+				res = n
+			}
+		}
+		return res
+	}, 1, data.OrMore)
+}
+
+func makeFloatFunc(fn func(float64) float64) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		return data.Float(fn(float64(toFloat(args[0]))))
+	}, 1)
+}
+
+func makeBitwise(
+	fn func(z, x, y *big.Int) *big.Int, identity int64,
+) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		res := big.NewInt(identity)
+		for _, a := range args {
+			fn(res, res, toBigInt(a))
+		}
+		return normalizeInt(res)
+	}, 0, data.OrMore)
+}
+
+func makeShift(
+	fn, inverse func(z, x *big.Int, n uint) *big.Int,
+) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		i := toBigInt(args[0])
+		n := int(args[1].(data.Integer))
+		if n < 0 {
+			return normalizeInt(inverse(new(big.Int), i, uint(-n)))
+		}
+		return normalizeInt(fn(new(big.Int), i, uint(n)))
+	}, 2)
+}
+
+func exactPow(base data.Number, exp data.Integer) data.Number {
+	e := big.NewInt(int64(exp))
+	if exp < 0 {
+		e.Neg(e)
+	}
+	var res *big.Rat
+	switch b := base.(type) {
+	case *data.Ratio:
+		r := (*big.Rat)(b)
+		num := new(big.Int).Exp(r.Num(), e, nil)
+		den := new(big.Int).Exp(r.Denom(), e, nil)
+		res = new(big.Rat).SetFrac(num, den)
+	default:
+		res = new(big.Rat).SetInt(new(big.Int).Exp(toBigInt(b), e, nil))
+	}
+	if exp >= 0 {
+		return normalizeRat(res)
+	}
+	if res.Sign() == 0 {
+		panic(data.NewDivideByZeroError())
+	}
+	return normalizeRat(res.Inv(res))
+}
+
+func exactSqrt(i *big.Int) (*big.Int, bool) {
+	if i.Sign() < 0 {
+		return nil, false
+	}
+	res := new(big.Int).Sqrt(i)
+	if new(big.Int).Mul(res, res).Cmp(i) == 0 {
+		return res, true
+	}
+	return nil, false
+}
+
+func isExact(n data.Number) bool {
+	switch n.(type) {
+	case data.Integer, *data.BigInt, *data.Ratio:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat(v ale.Value) data.Float {
+	switch n := v.(data.Number).(type) {
+	case data.Float:
+		return n
+	case data.Integer:
+		return data.Float(n)
+	case *data.BigInt:
+		f, _ := new(big.Float).SetInt((*big.Int)(n)).Float64()
+		return data.Float(f)
+	case *data.Ratio:
+		f, _ := (*big.Rat)(n).Float64()
+		return data.Float(f)
+	default:
+		return data.Float(math.NaN())
+	}
+}
+
+func toRat(f data.Float) *big.Rat {
+	if res := new(big.Rat).SetFloat64(float64(f)); res != nil {
+		return res
+	}
+	panic(data.NewError(
+		data.TypeErrorKind, fmt.Sprintf(ErrExpectedFinite, f), f,
+	))
+}
+
+func toBigInt(v ale.Value) *big.Int {
+	switch n := v.(type) {
+	case data.Integer:
+		return big.NewInt(int64(n))
+	case *data.BigInt:
+		return (*big.Int)(n)
+	default:
+		panic(data.NewError(
+			data.TypeErrorKind, fmt.Sprintf(data.ErrExpectedInteger, v), v,
+		))
+	}
+}
+
+func normalizeInt(i *big.Int) data.Number {
+	if i.IsInt64() {
+		return data.Integer(i.Int64())
+	}
+	return (*data.BigInt)(i)
+}
+
+func normalizeRat(r *big.Rat) data.Number {
+	if r.IsInt() {
+		return normalizeInt(r.Num())
+	}
+	return (*data.Ratio)(r)
+}
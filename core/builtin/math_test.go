@@ -0,0 +1,127 @@
+package builtin_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestMathAbsRoundingEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(math/abs -5)`, I(5))
+	as.MustEvalTo(`(math/abs -1/2)`, R(1, 2))
+	as.MustEvalTo(`(math/abs -2.5)`, F(2.5))
+	as.MustEvalTo(`
+		(= (math/abs -9223372036854775808) 9223372036854775808)
+	`, data.True)
+
+	as.MustEvalTo(`(math/floor 7/2)`, I(3))
+	as.MustEvalTo(`(math/floor -7/2)`, I(-4))
+	as.MustEvalTo(`(math/ceil 7/2)`, I(4))
+	as.MustEvalTo(`(math/ceil -7/2)`, I(-3))
+	as.MustEvalTo(`(math/round 5/2)`, I(3))
+	as.MustEvalTo(`(math/round -5/2)`, I(-3))
+	as.MustEvalTo(`(math/round 4/3)`, I(1))
+	as.MustEvalTo(`(math/truncate -7/2)`, I(-3))
+	as.MustEvalTo(`(math/floor 2.7)`, F(2))
+	as.MustEvalTo(`(math/round 3)`, I(3))
+	as.MustEvalTo(`(inf? (math/floor +inf))`, data.True)
+	as.MustEvalTo(`(nan? (math/round nan))`, data.True)
+}
+
+func TestMathMinMaxEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(math/min 3 1/2 2.0)`, R(1, 2))
+	as.MustEvalTo(`(math/max 3 1/2 2.0)`, I(3))
+	as.MustEvalTo(`(math/max 7)`, I(7))
+	as.MustEvalTo(`(math/max -inf 3)`, I(3))
+	as.MustEvalTo(`(nan? (math/min 1 nan 0))`, data.True)
+}
+
+func TestMathPowersEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(math/sqrt 16)`, I(4))
+	as.MustEvalTo(`(math/sqrt 4/9)`, R(2, 3))
+	as.MustEvalTo(`(math/sqrt 2.25)`, F(1.5))
+	as.MustEvalTo(`(math/sqrt 2)`, F(1.4142135623730951))
+	as.MustEvalTo(`(nan? (math/sqrt -4))`, data.True)
+
+	as.MustEvalTo(`(math/pow 2 10)`, I(1024))
+	as.MustEvalTo(`(math/pow 2/3 2)`, R(4, 9))
+	as.MustEvalTo(`(math/pow 2 -2)`, R(1, 4))
+	as.MustEvalTo(`(math/pow 2.0 3)`, F(8))
+	as.MustEvalTo(`(math/pow 4 0.5)`, F(2))
+	as.MustEvalTo(`(math/expt 10 3)`, I(1000))
+	as.MustEvalTo(`
+		(= (math/pow 2 100) 1267650600228229401496703205376)
+	`, data.True)
+	as.PanicWith(`(math/pow 0 -1)`, errors.New(data.ErrDivideByZero))
+	as.MustEvalTo(`
+		[(try (math/pow 0 -1) (catch [e :arithmetic-error] (:kind e)))
+		 (try (/ 1 0) (catch [e :arithmetic-error] (:kind e)))]
+	`, V(data.ArithmeticErrorKind, data.ArithmeticErrorKind))
+
+	as.MustEvalTo(`(math/exp 0)`, F(1))
+	as.MustEvalTo(`(math/log math/e)`, F(1))
+	as.MustEvalTo(`(math/log 8 2)`, F(3))
+}
+
+func TestMathTrigEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(math/sin 0)`, F(0))
+	as.MustEvalTo(`(math/cos math/pi)`, F(-1))
+	as.MustEvalTo(`(math/tan 0)`, F(0))
+	as.MustEvalTo(`(math/asin 1)`, F(1.5707963267948966))
+	as.MustEvalTo(`(math/acos 1)`, F(0))
+	as.MustEvalTo(`(math/atan 1 1)`, F(0.7853981633974483))
+	as.MustEvalTo(`(math/atan 0)`, F(0))
+}
+
+func TestMathIntegerEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(math/gcd 12 18)`, I(6))
+	as.MustEvalTo(`(math/gcd -12 8)`, I(4))
+	as.MustEvalTo(`(math/gcd)`, I(0))
+	as.MustEvalTo(`(math/lcm 4 6)`, I(12))
+	as.MustEvalTo(`(math/lcm 0 5)`, I(0))
+	as.MustEvalTo(`(math/lcm)`, I(1))
+
+	as.MustEvalTo(`(math/bit-and 12 10)`, I(8))
+	as.MustEvalTo(`(math/bit-or 12 10)`, I(14))
+	as.MustEvalTo(`(math/bit-xor 12 10)`, I(6))
+	as.MustEvalTo(`(math/bit-not 0)`, I(-1))
+	as.MustEvalTo(`(math/shift-left 1 4)`, I(16))
+	as.MustEvalTo(`(math/shift-left 8 -2)`, I(2))
+	as.MustEvalTo(`(math/shift-right -8 1)`, I(-4))
+	as.MustEvalTo(`
+		(= (math/shift-left 1 70) 1180591620717411303424)
+	`, data.True)
+
+	as.PanicWith(`(math/gcd 1.5)`, errors.New("value is not an integer: 1.5"))
+	as.PanicWith(`(math/bit-and 1 1/2)`,
+		errors.New("value is not an integer: 1/2"),
+	)
+}
+
+func TestMathExactnessEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(math/numerator 6/4)`, I(3))
+	as.MustEvalTo(`(math/denominator 6/4)`, I(2))
+	as.MustEvalTo(`(math/numerator 5)`, I(5))
+	as.MustEvalTo(`(math/denominator 5)`, I(1))
+	as.MustEvalTo(`(math/numerator 0.5)`, F(1))
+	as.MustEvalTo(`(math/denominator 0.5)`, F(2))
+
+	as.MustEvalTo(`(math/exact->inexact 1/4)`, F(0.25))
+	as.MustEvalTo(`(math/inexact->exact 0.25)`, R(1, 4))
+	as.MustEvalTo(`(math/inexact->exact 3.0)`, I(3))
+	as.MustEvalTo(`(math/inexact->exact 3)`, I(3))
+
+	as.MustEvalTo(`
+		(try (math/inexact->exact nan)
+			(catch [e :type-error] (:message e)))
+	`, S("value is not finite: nan"))
+}
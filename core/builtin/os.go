@@ -1,15 +1,29 @@
 package builtin
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/basics"
+	"github.com/kode4food/ale/internal/runtime"
 )
 
+// Keys of the object returned by Profile
+const (
+	ResultKey = data.Keyword("result")
+	ReportKey = data.Keyword("report")
+)
+
+// ErrExpectedProcedure is raised when Profile is called with a value that
+// isn't a procedure
+const ErrExpectedProcedure = "value is not a procedure: %s"
+
 var envPairRegex = regexp.MustCompile("^(?P<Key>[^=]+)=(?P<Value>.*)$")
 
 // Env returns an object containing the operating system's environment
@@ -34,3 +48,30 @@ func Args() ale.Value {
 var CurrentTime = data.MakeProcedure(func(...ale.Value) ale.Value {
 	return data.Integer(time.Now().UnixNano())
 }, 0)
+
+// Profile calls the provided function with a Profiler, returning an object
+// that holds the function's result and a flat text report of the profile. If
+// the caller is already being profiled, its profile also includes the call
+var Profile = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
+	fn, ok := args[0].(data.Procedure)
+	if !ok {
+		panic(data.NewError(
+			data.TypeErrorKind,
+			fmt.Sprintf(ErrExpectedProcedure, data.ToQuotedString(args[0])),
+			args[0],
+		))
+	}
+	ctx, stop := runtime.WithProfiler(ctx, runtime.DefaultProfilePeriod)
+	defer stop()
+	res := runtime.Call(ctx, fn)
+	var buf strings.Builder
+	if err := stop().WriteReport(&buf); err != nil {
+		panic(err)
+	}
+	return data.NewObject(
+		data.NewCons(ResultKey, res),
+		data.NewCons(ReportKey, data.String(buf.String())),
+	)
+}, 1)
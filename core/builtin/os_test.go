@@ -1,9 +1,11 @@
 package builtin_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/core/builtin"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/assert"
@@ -38,3 +40,25 @@ func TestCurrentTime(t *testing.T) {
 	t2 := int64(builtin.CurrentTime.Call().(data.Integer))
 	as.Equal(t1-(t1%1000000), t2-(t2%1000000))
 }
+
+func TestProfile(t *testing.T) {
+	as := assert.New(t)
+
+	as.MustEvalTo(`(profile (+ 1 2) (* 3 4))`, I(12))
+	as.MustEvalTo(`(:result (%profile (lambda () :done)))`, K("done"))
+
+	res := as.MustEval(`(:report (%profile (lambda () :done)))`)
+	as.Contains("self%", res)
+	as.Contains("procedure", res)
+
+	native := data.MakeProcedure(func(...ale.Value) ale.Value {
+		return S("native")
+	}, 0)
+	obj := builtin.Profile.Call(native).(*data.Object)
+	as.String("native", as.MustGet(obj, builtin.ResultKey))
+
+	as.PanicWith(`(%profile 99)`, data.NewError(
+		data.TypeErrorKind,
+		fmt.Sprintf(builtin.ErrExpectedProcedure, "99"), I(99),
+	))
+}
@@ -26,6 +26,7 @@ const (
 	BytesKey     = data.Keyword("bytes")
 	ConsKey      = data.Keyword("cons")
 	CountedKey   = data.Keyword("counted")
+	ErrorKey     = data.Keyword("error")
 	ProcedureKey = data.Keyword("procedure")
 	IndexedKey   = data.Keyword("indexed")
 	KeywordKey   = data.Keyword("keyword")
@@ -41,6 +42,7 @@ const (
 	PairKey      = data.Keyword("pair")
 	PromiseKey   = data.Keyword("promise")
 	QualifiedKey = data.Keyword("qualified")
+	RegexKey     = data.Keyword("regex")
 	ResolvedKey  = data.Keyword("resolved")
 	ReverserKey  = data.Keyword("reverser")
 	SequenceKey  = data.Keyword("sequence")
@@ -64,6 +66,7 @@ var (
 		BooleanKey:   data.MakeTypePredicate(types.BasicBoolean),
 		BytesKey:     data.MakeTypePredicate(types.BasicBytes),
 		ConsKey:      data.MakeTypePredicate(types.BasicCons),
+		ErrorKey:     data.MakeTypePredicate(types.BasicError),
 		ProcedureKey: data.MakeTypePredicate(types.BasicProcedure),
 		KeywordKey:   data.MakeTypePredicate(types.BasicKeyword),
 		ListKey:      data.MakeTypePredicate(listType),
@@ -72,6 +75,7 @@ var (
 		NumberKey:    data.MakeTypePredicate(types.BasicNumber),
 		ObjectKey:    data.MakeTypePredicate(types.BasicObject),
 		PromiseKey:   data.MakeTypePredicate(sync.PromiseType),
+		RegexKey:     data.MakeTypePredicate(types.BasicRegex),
 		SpecialKey:   data.MakeTypePredicate(compiler.CallType),
 		SetKey:       data.MakeTypePredicate(types.BasicSet),
 		StringKey:    data.MakeTypePredicate(types.BasicString),
@@ -0,0 +1,124 @@
+package builtin
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/runtime"
+	"github.com/kode4food/ale/internal/sequence"
+)
+
+// matchBatchSize is the number of matches that a lazy find-all sequence
+// initially retrieves. Each subsequent batch is twice as large
+const matchBatchSize = 16
+
+// RegexCompile compiles a string into a regular expression
+var RegexCompile = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	if r, ok := args[0].(*data.Regex); ok {
+		return r
+	}
+	res, err := data.CompileRegex(string(args[0].(data.String)))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}, 1)
+
+// RegexIsMatch returns whether a regular expression matches any part of a
+// string
+var RegexIsMatch = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	re, s := regexArgs(args)
+	return data.Bool(re.MatchString(s))
+}, 2)
+
+// RegexFind returns the first substring that a regular expression matches,
+// or false if there is no match
+var RegexFind = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	re, s := regexArgs(args)
+	if m := re.FindStringIndex(s); m != nil {
+		return data.String(s[m[0]:m[1]])
+	}
+	return data.False
+}, 2)
+
+// RegexFindAll returns a lazy sequence of the substrings that a regular
+// expression matches
+var RegexFindAll = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	re, s := regexArgs(args)
+	return findAll(re, s)
+}, 2)
+
+// RegexGroups returns an object of the named groups that a regular
+// expression captures in its first match, or false if there is no match
+var RegexGroups = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	re, s := regexArgs(args)
+	m := re.FindStringSubmatchIndex(s)
+	if m == nil {
+		return data.False
+	}
+	var res []data.Pair
+	for i, n := range re.SubexpNames() {
+		if n == "" || m[i*2] < 0 {
+			continue
+		}
+		g := data.String(s[m[i*2]:m[i*2+1]])
+		res = append(res, data.NewCons(data.Keyword(n), g))
+	}
+	return data.NewObject(res...)
+}, 2)
+
+// RegexReplace replaces the matches of a regular expression within a string.
+// The replacement is either a string that can reference groups using the $1
+// or ${name} syntax, or a procedure that is called with each match
+var RegexReplace = runtime.MakeContextProcedure(func(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
+	re, s := regexArgs(args)
+	if r, ok := args[2].(data.String); ok {
+		return data.String(re.ReplaceAllString(s, string(r)))
+	}
+	fn := args[2].(data.Procedure)
+	return data.String(re.ReplaceAllStringFunc(s, func(m string) string {
+		return data.ToString(runtime.Call(ctx, fn, data.String(m)))
+	}))
+}, 3)
+
+// RegexSplit splits a string into a vector of the substrings that are
+// separated by the matches of a regular expression. A limit, if provided,
+// must be at least one
+var RegexSplit = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	re, s := regexArgs(args)
+	parts := re.Split(s, splitLimit(args))
+	res := make(data.Vector, len(parts))
+	for i, p := range parts {
+		res[i] = data.String(p)
+	}
+	return res
+}, 2, 3)
+
+func regexArgs(args []ale.Value) (*regexp.Regexp, string) {
+	return args[0].(*data.Regex).Regexp(), string(args[1].(data.String))
+}
+
+func findAll(re *regexp.Regexp, s string) data.Sequence {
+	var resolver func(m [][]int, i, limit int) sequence.LazyResolver
+	resolver = func(m [][]int, i, limit int) sequence.LazyResolver {
+		return func() (ale.Value, data.Sequence, bool) {
+			if i == len(m) {
+				if m != nil && len(m) < limit {
+					return data.Null, data.Null, false
+				}
+				limit = max(limit*2, matchBatchSize)
+				m = re.FindAllStringIndex(s, limit)
+				if i >= len(m) {
+					return data.Null, data.Null, false
+				}
+			}
+			f := data.String(s[m[i][0]:m[i][1]])
+			return f, sequence.NewLazy(resolver(m, i+1, limit)), true
+		}
+	}
+	return sequence.NewLazy(resolver(nil, 0, 0))
+}
@@ -0,0 +1,81 @@
+package builtin_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/ale/core/builtin"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestRegexEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(regex? #"a+" (regex/compile "a+"))`, data.True)
+	as.MustEvalTo(`(!regex? "a+")`, data.True)
+	as.MustEvalTo(`(eq #"a+" (regex/compile "a+"))`, data.True)
+	as.MustEvalTo(`(str #"say \"hi\"")`, S(`#"say \"hi\""`))
+
+	as.MustEvalTo(`(regex/match? #"\d+" "ab12")`, data.True)
+	as.MustEvalTo(`(regex/match? #"^\d+$" "ab12")`, data.False)
+	as.MustEvalTo(`(regex/find #"\d+" "ab12cd345")`, S("12"))
+	as.MustEvalTo(`(regex/find #"\d" "abc")`, data.False)
+
+	as.PanicWith(`(regex/compile "(")`,
+		errors.New("error parsing regexp: missing closing )"),
+	)
+}
+
+func TestRegexFindAllEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(seq->vector (regex/find-all #"\d+" "1 22 333"))`,
+		V(S("1"), S("22"), S("333")),
+	)
+	as.MustEvalTo(`(seq->vector (regex/find-all #"^a" "aaa"))`, V(S("a")))
+	as.MustEvalTo(`(seq->vector (regex/find-all #"x*" "axxb"))`,
+		V(S(""), S("xx"), S("")),
+	)
+	as.MustEvalTo(`(empty? (regex/find-all #"\d" "abc"))`, data.True)
+	as.MustEvalTo(`
+		(length (seq->vector (regex/find-all #"." (string/pad-left "" 50 "x"))))
+	`, I(50))
+}
+
+func TestRegexGroupsEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(regex/groups #"(?P<level>[A-Z]+) (?P<msg>.*)" "ts=1 ERROR disk full")
+	`, O(
+		C(K("level"), S("ERROR")),
+		C(K("msg"), S("disk full")),
+	))
+	as.MustEvalTo(`(regex/groups #"(?P<a>x)|(?P<b>y)" "y")`,
+		O(C(K("b"), S("y"))),
+	)
+	as.MustEvalTo(`(regex/groups #"(?P<a>x)" "y")`, data.False)
+}
+
+func TestRegexReplaceSplitEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(regex/replace #"\d+" "a1b22" "#")`, S("a#b#"))
+	as.MustEvalTo(`(regex/replace #"(?P<n>\d+)" "a1b22" "<${n}>")`,
+		S("a<1>b<22>"),
+	)
+	as.MustEvalTo(`
+		(regex/replace #"\d+" "a1b22" (lambda (m) (* 2 (read m))))
+	`, S("a2b44"))
+
+	as.MustEvalTo(`(regex/split #"\s*,\s*" "a , b,c")`,
+		V(S("a"), S("b"), S("c")),
+	)
+	as.MustEvalTo(`(regex/split #"," "a,b,c" 2)`, V(S("a"), S("b,c")))
+	as.PanicWith(`(regex/split #"," "a,b" 0)`,
+		fmt.Errorf(builtin.ErrSplitLimit, 0),
+	)
+	as.MustEvalTo(`
+		(try (regex/split #"," "a,b" -1)
+			(catch [e :error] (:message e)))
+	`, S(fmt.Sprintf(builtin.ErrSplitLimit, -1)))
+}
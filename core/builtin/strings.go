@@ -1,7 +1,10 @@
 package builtin
 
 import (
+	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
@@ -9,7 +12,14 @@ import (
 	"github.com/kode4food/ale/internal/sequence"
 )
 
-const emptyString = data.String("")
+const (
+	emptyString = data.String("")
+	spaceString = data.String(lang.Space)
+)
+
+// ErrSplitLimit is raised when string/split or regex/split is given a limit
+// that would return no substrings
+const ErrSplitLimit = "split limit must be positive: %d"
 
 // Str converts the provided arguments to an undelimited string
 var Str = data.MakeProcedure(func(args ...ale.Value) ale.Value {
@@ -31,3 +41,180 @@ var ReaderStr = data.MakeProcedure(func(args ...ale.Value) ale.Value {
 	}
 	return data.String(b.String())
 })
+
+// StringSplit splits a string into a vector of the substrings that are
+// separated by sep. An empty separator splits the string into its characters.
+// A limit, if provided, must be at least one
+var StringSplit = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	s := string(args[0].(data.String))
+	sep := string(args[1].(data.String))
+	parts := strings.SplitN(s, sep, splitLimit(args))
+	res := make(data.Vector, len(parts))
+	for i, p := range parts {
+		res[i] = data.String(p)
+	}
+	return res
+}, 2, 3)
+
+// StringJoin concatenates the elements of a sequence into a string, placing
+// an optional separator between each of them
+var StringJoin = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	var sep string
+	if len(args) > 1 {
+		sep = string(args[0].(data.String))
+	}
+	var b strings.Builder
+	seq := args[len(args)-1].(data.Sequence)
+	for f, r, ok := seq.Split(); ok; f, r, ok = r.Split() {
+		b.WriteString(data.ToString(f))
+		if !r.IsEmpty() {
+			b.WriteString(sep)
+		}
+	}
+	return data.String(b.String())
+}, 1, 2)
+
+// StringTrim removes leading and trailing whitespace from a string, or the
+// characters of an optional cut set
+var StringTrim = makeTrimmer(strings.TrimSpace, strings.Trim)
+
+// StringTrimLeft removes leading whitespace from a string, or the characters
+// of an optional cut set
+var StringTrimLeft = makeTrimmer(
+	func(s string) string {
+		return strings.TrimLeftFunc(s, unicode.IsSpace)
+	},
+	strings.TrimLeft,
+)
+
+// StringTrimRight removes trailing whitespace from a string, or the
+// characters of an optional cut set
+var StringTrimRight = makeTrimmer(
+	func(s string) string {
+		return strings.TrimRightFunc(s, unicode.IsSpace)
+	},
+	strings.TrimRight,
+)
+
+// StringUpper returns a string with all of its characters in upper case
+var StringUpper = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	return data.String(strings.ToUpper(string(args[0].(data.String))))
+}, 1)
+
+// StringLower returns a string with all of its characters in lower case
+var StringLower = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	return data.String(strings.ToLower(string(args[0].(data.String))))
+}, 1)
+
+// StringReplace replaces occurrences of old in a string with new. If a count
+// is provided, only that many occurrences are replaced
+var StringReplace = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	s := string(args[0].(data.String))
+	o := string(args[1].(data.String))
+	n := string(args[2].(data.String))
+	c := -1
+	if len(args) > 3 {
+		c = int(args[3].(data.Integer))
+	}
+	return data.String(strings.Replace(s, o, n, c))
+}, 3, 4)
+
+// StringStartsWith returns whether a string begins with the provided prefix
+var StringStartsWith = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	s := string(args[0].(data.String))
+	p := string(args[1].(data.String))
+	return data.Bool(strings.HasPrefix(s, p))
+}, 2)
+
+// StringEndsWith returns whether a string ends with the provided suffix
+var StringEndsWith = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	s := string(args[0].(data.String))
+	p := string(args[1].(data.String))
+	return data.Bool(strings.HasSuffix(s, p))
+}, 2)
+
+// StringIndexOf returns the character index of the first occurrence of a
+// substring, or false if the substring isn't present
+var StringIndexOf = makeIndexer(strings.Index)
+
+// StringLastIndexOf returns the character index of the last occurrence of a
+// substring, or false if the substring isn't present
+var StringLastIndexOf = makeIndexer(strings.LastIndex)
+
+// StringPadLeft pads the beginning of a string until it is at least the
+// requested number of characters long
+var StringPadLeft = makePadder(func(s, pad string) string {
+	return pad + s
+})
+
+// StringPadRight pads the end of a string until it is at least the requested
+// number of characters long
+var StringPadRight = makePadder(func(s, pad string) string {
+	return s + pad
+})
+
+// StringFormat formats its arguments according to a Go-style format string
+var StringFormat = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	f := string(args[0].(data.String))
+	a := make([]any, len(args)-1)
+	for i, v := range args[1:] {
+		a[i] = v
+	}
+	return data.String(fmt.Sprintf(f, a...))
+}, 1, data.OrMore)
+
+func makeTrimmer(
+	space func(string) string, cut func(string, string) string,
+) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		s := string(args[0].(data.String))
+		if len(args) > 1 {
+			return data.String(cut(s, string(args[1].(data.String))))
+		}
+		return data.String(space(s))
+	}, 1, 2)
+}
+
+func makeIndexer(index func(string, string) int) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		s := string(args[0].(data.String))
+		sub := string(args[1].(data.String))
+		if i := index(s, sub); i >= 0 {
+			return data.Integer(utf8.RuneCountInString(s[:i]))
+		}
+		return data.False
+	}, 2)
+}
+
+func makePadder(join func(string, string) string) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		s := args[0].(data.String)
+		w := int(args[1].(data.Integer))
+		pad := []rune(spaceString)
+		if len(args) > 2 {
+			pad = []rune(args[2].(data.String))
+		}
+		n := w - utf8.RuneCountInString(string(s))
+		if n <= 0 || len(pad) == 0 {
+			return s
+		}
+		p := make([]rune, n)
+		for i := range p {
+			p[i] = pad[i%len(pad)]
+		}
+		return data.String(join(string(s), string(p)))
+	}, 2, 3)
+}
+
+func splitLimit(args []ale.Value) int {
+	if len(args) < 3 {
+		return -1
+	}
+	n := int(args[2].(data.Integer))
+	if n < 1 {
+		panic(data.NewError(
+			data.ErrorKind, fmt.Sprintf(ErrSplitLimit, n), args[2],
+		))
+	}
+	return n
+}
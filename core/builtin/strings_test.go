@@ -1,8 +1,10 @@
 package builtin_test
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/kode4food/ale/core/builtin"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/assert"
 	. "github.com/kode4food/ale/internal/assert/helpers"
@@ -29,3 +31,69 @@ func TestReadableStrEval(t *testing.T) {
 	as.MustEvalTo(`(str! "hello" "you")`, S(`"hello" "you"`))
 	as.MustEvalTo(`(str!)`, S(""))
 }
+
+func TestStringSplitJoinEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(string/split "a,b,,c" ",")`, V(S("a"), S("b"), S(""), S("c")))
+	as.MustEvalTo(`(string/split "a,b,c" "," 2)`, V(S("a"), S("b,c")))
+	as.MustEvalTo(`(string/split "héllo" "")`,
+		V(S("h"), S("é"), S("l"), S("l"), S("o")),
+	)
+	as.MustEvalTo(`(string/join ", " [1 "b" :c])`, S("1, b, :c"))
+	as.MustEvalTo(`(string/join '(1 2 3))`, S("123"))
+	as.MustEvalTo(`(string/join "-" [])`, S(""))
+}
+
+func TestStringTrimCaseEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(string/trim "  hi \n")`, S("hi"))
+	as.MustEvalTo(`(string/trim "xxhixx" "x")`, S("hi"))
+	as.MustEvalTo(`(string/trim-left "  hi ")`, S("hi "))
+	as.MustEvalTo(`(string/trim-right "  hi ")`, S("  hi"))
+	as.MustEvalTo(`(string/trim-right "hi!?!" "!?")`, S("hi"))
+	as.MustEvalTo(`(string/upper "crème")`, S("CRÈME"))
+	as.MustEvalTo(`(string/lower "ÀB")`, S("àb"))
+}
+
+func TestStringSearchEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(string/replace "aaa" "a" "b")`, S("bbb"))
+	as.MustEvalTo(`(string/replace "aaa" "a" "b" 2)`, S("bba"))
+	as.MustEvalTo(`(string/starts-with? "hello" "he")`, data.True)
+	as.MustEvalTo(`(string/ends-with? "hello" "lo")`, data.True)
+	as.MustEvalTo(`(string/ends-with? "hello" "he")`, data.False)
+	as.MustEvalTo(`(string/index-of "héllo wörld" "wö")`, I(6))
+	as.MustEvalTo(`(string/index-of "abc" "z")`, data.False)
+	as.MustEvalTo(`(string/last-index-of "äbäb" "b")`, I(3))
+	as.MustEvalTo(`
+		(let [s "naïve café"]
+			(nth s (string/index-of s "é")))
+	`, S("é"))
+}
+
+func TestStringPadFormatEval(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(string/pad-left "é" 3)`, S("  é"))
+	as.MustEvalTo(`(string/pad-right "ab" 5 "xy")`, S("abxyx"))
+	as.MustEvalTo(`(string/pad-left "abcdef" 3)`, S("abcdef"))
+	as.MustEvalTo(`(string/pad-left "42" 5 "0")`, S("00042"))
+	as.MustEvalTo(
+		`(string/format "%s is %d and %.2f %v" "x" 42 3.14159 [1 2])`,
+		S("x is 42 and 3.14 [1 2]"),
+	)
+}
+
+func TestStringErrorsEval(t *testing.T) {
+	as := assert.New(t)
+	as.ErrorWith(`(string/upper)`, fmt.Errorf(data.ErrFixedArity, 1, 0))
+	as.ErrorWith(`(string/split "a")`,
+		fmt.Errorf(data.ErrRangedArity, 2, 3, 1),
+	)
+	as.PanicWith(`(string/split "a,b" "," 0)`,
+		fmt.Errorf(builtin.ErrSplitLimit, 0),
+	)
+	as.MustEvalTo(`
+		(try (string/split "a,b" "," -1)
+			(catch [e :error] (:message e)))
+	`, S(fmt.Sprintf(builtin.ErrSplitLimit, -1)))
+}
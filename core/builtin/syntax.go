@@ -182,6 +182,9 @@ func (se *syntaxEnv) quoteElements(s data.Sequence) (ale.Value, error) {
 
 func (se *syntaxEnv) qualifySymbol(s data.Symbol) ale.Value {
 	if q, ok := s.(data.Qualified); ok {
+		if d, ok := se.ns.ResolveAlias(q.Domain()); ok {
+			return data.NewQualifiedSymbol(q.Local(), d)
+		}
 		return q
 	}
 	name := s.Local()
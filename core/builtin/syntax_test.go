@@ -15,3 +15,16 @@ func TestQuoteObject(t *testing.T) {
 		O(data.NewCons(K("hello"), I(99))),
 	)
 }
+
+func TestSyntaxQuoteAlias(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(define-namespace quoted.domain
+			(define x 99))
+		(alias q quoted.domain)
+		`+"`"+`[q/x other/x]
+	`, V(
+		data.NewQualifiedSymbol("x", "quoted.domain"),
+		data.NewQualifiedSymbol("x", "other"),
+	))
+}
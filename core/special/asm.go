@@ -5,7 +5,6 @@ import (
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/compiler/asm"
 	"github.com/kode4food/ale/internal/compiler/encoder"
-	"github.com/kode4food/ale/internal/lang/params"
 )
 
 // Asm provides indirect access to the Encoder's methods and generators
@@ -15,9 +14,5 @@ func Asm(e encoder.Encoder, args ...ale.Value) error {
 
 // Special emits an encoder function for the provided param cases
 func Special(e encoder.Encoder, args ...ale.Value) error {
-	pc, err := params.ParseCases(data.Vector(args))
-	if err != nil {
-		return err
-	}
-	return asm.Encode(e, asm.MakeSpecial(pc))
+	return asm.Encode(e, asm.MakeSpecial(data.Vector(args)))
 }
@@ -37,6 +37,11 @@ func performBinding(
 	if err != nil {
 		return err
 	}
+	if loc, ok := e.ArgLocation(0); ok && len(bindings) > 0 {
+		if bindings[0].Location == nil {
+			bindings[0].Location = loc
+		}
+	}
 	return b(e, bindings, func(e encoder.Encoder) error {
 		return generate.Block(e, body)
 	})
@@ -58,7 +63,8 @@ func parseLetBindings(v ale.Value) (generate.Bindings, error) {
 	case *data.List:
 		names := uniqueNames{}
 		res := generate.Bindings{}
-		for f, r, ok := v.Split(); ok; f, r, ok = r.Split() {
+		var s data.Sequence = v
+		for f, r, ok := s.Split(); ok; f, r, ok = r.Split() {
 			v, ok := f.(data.Vector)
 			if !ok {
 				return nil, fmt.Errorf("%w: %s", ErrUnexpectedLetSyntax, f)
@@ -70,7 +76,9 @@ func parseLetBindings(v ale.Value) (generate.Bindings, error) {
 			if err := names.markAsBound(b.Name); err != nil {
 				return nil, err
 			}
+			b.Location = bindingLocation(s)
 			res = append(res, b)
+			s = r
 		}
 		return res, nil
 	case data.Vector:
@@ -98,6 +106,19 @@ func parseLetBinding(v data.Vector) (*generate.Binding, error) {
 	}, nil
 }
 
+// bindingLocation returns the location of the binding that begins a List of
+// bindings. The parser associates every tail of a List with the location of
+// its first element, so each binding can be located, though the first is
+// located by the List itself
+func bindingLocation(s data.Sequence) *data.Location {
+	if l, ok := s.(*data.List); ok {
+		if loc, ok := l.Location(); ok {
+			return loc
+		}
+	}
+	return nil
+}
+
 func (u uniqueNames) markAsBound(n data.Local) error {
 	if _, ok := u[n]; ok {
 		return fmt.Errorf("%w: %s", ErrNameAlreadyBound, n)
@@ -0,0 +1,197 @@
+package special
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/internal/compiler"
+	"github.com/kode4food/ale/internal/compiler/encoder"
+	"github.com/kode4food/ale/internal/compiler/generate"
+	lang "github.com/kode4food/ale/internal/lang/env"
+	"github.com/kode4food/ale/internal/runtime/isa"
+)
+
+// Keys of the objects that describe namespace entries
+const (
+	NameKey    = data.Keyword("name")
+	DomainKey  = data.Keyword("domain")
+	PrivateKey = data.Keyword("private")
+	BoundKey   = data.Keyword("bound")
+	ValueKey   = data.Keyword("value")
+)
+
+// Error messages
+var (
+	ErrRootNamespace    = errors.New("root namespace entries can't be changed")
+	ErrCurrentNamespace = errors.New("current namespace can't be removed")
+)
+
+// Entries returns a description of every entry in the current namespace,
+// including its private entries, or of the public entries of the specified
+// namespace
+func Entries(e encoder.Encoder, args ...ale.Value) error {
+	if err := data.CheckRangedArity(0, 1, len(args)); err != nil {
+		return err
+	}
+	from := e.Globals()
+	ns := from
+	if len(args) > 0 {
+		name, ok := args[0].(data.Local)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrExpectedName, args[0])
+		}
+		var err error
+		ns, err = ns.Environment().GetQualified(name)
+		if err != nil {
+			return err
+		}
+	}
+	return emitNamespaceCall(e, func() (ale.Value, error) {
+		entries := ns.Entries()
+		names := make(data.Locals, 0, len(entries))
+		for n, entry := range entries {
+			if !entry.IsPrivate() || isSameDomain(from, ns) {
+				names = append(names, n)
+			}
+		}
+		slices.Sort(names)
+		res := make(data.Vector, len(names))
+		for i, n := range names {
+			res[i] = describeEntry(entries[n], ns, from)
+		}
+		return res, nil
+	})
+}
+
+// Resolve returns a description of the entry that a symbol resolves to from
+// the current namespace. Resolving an entry that has been denied raises the
+// error that referring to it would
+func Resolve(e encoder.Encoder, args ...ale.Value) error {
+	if err := data.CheckFixedArity(1, len(args)); err != nil {
+		return err
+	}
+	s, ok := args[0].(data.Symbol)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExpectedName, args[0])
+	}
+	ns := e.Globals()
+	return emitNamespaceCall(e, func() (ale.Value, error) {
+		entry, in, err := env.ResolveSymbol(ns, s)
+		if err != nil {
+			return nil, err
+		}
+		if v, err := entry.Value(); err == nil {
+			if d, ok := v.(*compiler.Denied); ok {
+				return nil, d
+			}
+		}
+		return describeEntry(entry, in, ns), nil
+	})
+}
+
+// Unbind replaces an entry of the current namespace with one that is unbound,
+// allowing the name to be bound again. Code compiled before the entry was
+// unbound only sees the new binding if the environment is late-binding. The
+// entries of the root namespace can't be unbound
+func Unbind(e encoder.Encoder, args ...ale.Value) error {
+	return updateEntry(e, env.Namespace.Unbind, args...)
+}
+
+// Undeclare removes an entry from the current namespace, unless it's the root
+// namespace
+func Undeclare(e encoder.Encoder, args ...ale.Value) error {
+	return updateEntry(e, env.Namespace.Undeclare, args...)
+}
+
+// RemoveNamespace removes the namespace of a domain from the environment. The
+// root namespace and the current namespace can't be removed
+func RemoveNamespace(e encoder.Encoder, args ...ale.Value) error {
+	if err := data.CheckFixedArity(1, len(args)); err != nil {
+		return err
+	}
+	name, ok := args[0].(data.Local)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExpectedName, args[0])
+	}
+	ns := e.Globals()
+	if name == ns.Domain() {
+		return fmt.Errorf("%w: %s", ErrCurrentNamespace, name)
+	}
+	environment := ns.Environment()
+	return emitNamespaceCall(e, func() (ale.Value, error) {
+		if err := environment.RemoveQualified(name); err != nil {
+			return nil, err
+		}
+		return name, nil
+	})
+}
+
+func updateEntry(
+	e encoder.Encoder, update func(env.Namespace, data.Local) error,
+	args ...ale.Value,
+) error {
+	if err := data.CheckFixedArity(1, len(args)); err != nil {
+		return err
+	}
+	name, ok := args[0].(data.Local)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExpectedName, args[0])
+	}
+	ns := e.Globals()
+	if ns.Domain() == lang.RootDomain {
+		return fmt.Errorf("%w: %s", ErrRootNamespace, name)
+	}
+	return emitNamespaceCall(e, func() (ale.Value, error) {
+		if err := update(ns, name); err != nil {
+			return nil, err
+		}
+		return name, nil
+	})
+}
+
+func emitNamespaceCall(
+	e encoder.Encoder, call func() (ale.Value, error),
+) error {
+	fn := data.MakeProcedure(func(...ale.Value) ale.Value {
+		res, err := call()
+		if err != nil {
+			panic(err)
+		}
+		return res
+	})
+	if err := generate.Literal(e, fn); err != nil {
+		return err
+	}
+	e.Emit(isa.Call0)
+	return nil
+}
+
+// describeEntry describes an entry of the namespace ns to code compiled in the
+// namespace from. A value is only included if code compiled in from could
+// resolve it, so the private entries of other namespaces and the entries that
+// have been denied are described without one
+func describeEntry(e *env.Entry, ns, from env.Namespace) ale.Value {
+	res := data.Pairs{
+		data.NewCons(NameKey, e.Name()),
+		data.NewCons(DomainKey, ns.Domain()),
+		data.NewCons(PrivateKey, data.Bool(e.IsPrivate())),
+		data.NewCons(BoundKey, data.Bool(e.IsBound())),
+	}
+	if e.IsPrivate() && !isSameDomain(from, ns) {
+		return data.NewObject(res...)
+	}
+	if v, err := e.Value(); err == nil {
+		if _, ok := v.(*compiler.Denied); !ok {
+			res = append(res, data.NewCons(ValueKey, v))
+		}
+	}
+	return data.NewObject(res...)
+}
+
+func isSameDomain(l, r env.Namespace) bool {
+	return l.Domain() == r.Domain()
+}
@@ -0,0 +1,186 @@
+package special_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/ale/core/bootstrap"
+	"github.com/kode4food/ale/core/special"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/eval"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestEntries(t *testing.T) {
+	as := assert.New(t)
+	later := O(
+		C(special.NameKey, LS("later")),
+		C(special.DomainKey, LS("listed")),
+		C(special.PrivateKey, data.False),
+		C(special.BoundKey, data.False),
+	)
+	as.MustEvalTo(`
+		(define-namespace listed
+			(define public-value 1)
+			(define :private private-value 2)
+			(declare later)
+			(define own (entries)))
+		[listed/own
+		 (seq->vector
+		   (map (lambda (e) [(:name e) (contains? e :value)]) (entries listed)))]
+	`, V(V(
+		later,
+		O(
+			C(special.NameKey, LS("private-value")),
+			C(special.DomainKey, LS("listed")),
+			C(special.PrivateKey, data.True),
+			C(special.BoundKey, data.True),
+			C(special.ValueKey, I(2)),
+		),
+		O(
+			C(special.NameKey, LS("public-value")),
+			C(special.DomainKey, LS("listed")),
+			C(special.PrivateKey, data.False),
+			C(special.BoundKey, data.True),
+			C(special.ValueKey, I(1)),
+		),
+	), V(
+		V(LS("later"), data.False),
+		V(LS("own"), data.True),
+		V(LS("public-value"), data.True),
+	)))
+
+	as.MustEvalTo(`
+		(define local-value 99)
+		(seq->vector (map :name (entries)))
+	`, V(LS("local-value")))
+}
+
+func TestResolve(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(define-namespace resolved
+			(define x 99))
+		(alias r resolved)
+		(define y 42)
+		[(dissoc (resolve r/x) :value) (:value (resolve r/x))
+		 (:domain (resolve resolve)) (:private (resolve when))
+		 (:value (resolve y))]
+	`, V(
+		O(
+			C(special.NameKey, LS("x")),
+			C(special.DomainKey, LS("resolved")),
+			C(special.PrivateKey, data.False),
+			C(special.BoundKey, data.True),
+		),
+		I(99),
+		LS("ale"),
+		data.False,
+		I(42),
+	))
+
+	as.PanicWith(`(resolve not-declared)`,
+		fmt.Errorf(env.ErrNameNotDeclared, LS("not-declared")),
+	)
+}
+
+func TestUnbindAndUndeclare(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(define reloaded 1)
+		(define (get) (eval 'reloaded))
+		(unbind reloaded)
+		(define reloaded 2)
+		(get)
+	`, I(2))
+
+	as.MustEvalTo(`
+		(define (early) 1)
+		(define (call-early) (early))
+		(unbind early)
+		(define (early) 2)
+		[(call-early) (early)]
+	`, V(I(1), I(2)))
+
+	as.MustEvalTo(`
+		(define removed 1)
+		(undeclare removed)
+		(seq->vector (map :name (entries)))
+	`, V())
+
+	as.PanicWith(`(undeclare not-declared)`,
+		fmt.Errorf(env.ErrNameNotDeclared, LS("not-declared")),
+	)
+	as.PanicWith(`(unbind when)`,
+		fmt.Errorf(env.ErrNameNotDeclared, LS("when")),
+	)
+}
+
+func TestRemoveNamespace(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(define-namespace temporary
+			(define x 1))
+		(remove-namespace temporary)
+		(define-namespace temporary
+			(define y 2))
+		(declared temporary)
+	`, V(LS("y")))
+
+	as.PanicWith(`(remove-namespace ale)`,
+		fmt.Errorf(env.ErrCannotRemoveRoot, LS("ale")),
+	)
+
+	ns := env.MustGetQualified(bootstrap.DevNullEnvironment(), "current")
+	_, err := eval.String(ns, `(remove-namespace current)`)
+	as.EqualError(err,
+		fmt.Sprintf("%s: %s", special.ErrCurrentNamespace, LS("current")),
+	)
+}
+
+func TestRootEntries(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		[(seq->vector (filter :private (entries ale)))
+		 (seq->vector
+		   (filter (lambda (e) (and (:bound e) (not (contains? e :value))))
+		           (entries ale)))]
+	`, V(V(), V()))
+	as.PanicWith(`(resolve def-builtin)`,
+		fmt.Errorf(env.ErrNameNotDeclared, LS("def-builtin")),
+	)
+
+	root := bootstrap.DevNullEnvironment().GetRoot()
+	for _, src := range []string{`(unbind when)`, `(undeclare when)`} {
+		_, err := eval.String(root, data.String(src))
+		as.EqualError(err,
+			fmt.Sprintf("%s: %s", special.ErrRootNamespace, LS("when")),
+		)
+	}
+}
+
+func TestEntriesErrors(t *testing.T) {
+	as := assert.New(t)
+	as.ErrorWith(`(entries a b)`, fmt.Errorf(data.ErrRangedArity, 0, 1, 2))
+	as.ErrorWith(`(entries 99)`,
+		fmt.Errorf("%w: %s", special.ErrExpectedName, I(99)),
+	)
+	as.ErrorWith(`(entries does-not-exist)`,
+		fmt.Errorf(env.ErrNamespaceNotFound, LS("does-not-exist")),
+	)
+	as.ErrorWith(`(resolve)`, fmt.Errorf(data.ErrFixedArity, 1, 0))
+	as.ErrorWith(`(resolve "x")`,
+		fmt.Errorf("%w: %s", special.ErrExpectedName, S("x")),
+	)
+	as.ErrorWith(`(unbind r/x)`, fmt.Errorf(
+		"%w: %s", special.ErrExpectedName, data.NewQualifiedSymbol("x", "r"),
+	))
+	as.ErrorWith(`(undeclare 99)`,
+		fmt.Errorf("%w: %s", special.ErrExpectedName, I(99)),
+	)
+	as.ErrorWith(`(remove-namespace 99)`,
+		fmt.Errorf("%w: %s", special.ErrExpectedName, I(99)),
+	)
+}
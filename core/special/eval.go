@@ -1,6 +1,8 @@
 package special
 
 import (
+	"context"
+
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
@@ -8,21 +10,25 @@ import (
 	"github.com/kode4food/ale/internal/compiler"
 	"github.com/kode4food/ale/internal/compiler/encoder"
 	"github.com/kode4food/ale/internal/compiler/generate"
+	"github.com/kode4food/ale/internal/runtime"
 	"github.com/kode4food/ale/internal/runtime/isa"
 	"github.com/kode4food/ale/macro"
 )
 
-type evalFunc func(env.Namespace, ale.Value) (ale.Value, error)
+type (
+	evalFunc   func(context.Context, env.Namespace, ale.Value) (ale.Value, error)
+	expandFunc func(env.Namespace, ale.Value) (ale.Value, error)
+)
 
 var (
 	// Eval encodes an immediate evaluation
-	Eval = makeEvaluator(eval.Value)
+	Eval = makeEvaluator(eval.ValueContext)
 
 	// MacroExpand performs macro expansion of a form until it can no longer
-	MacroExpand = makeEvaluator(macro.Expand)
+	MacroExpand = makeEvaluator(withoutContext(macro.Expand))
 
 	// MacroExpand1 performs a single-step macro expansion of a form
-	MacroExpand1 = makeEvaluator(macro.Expand1)
+	MacroExpand1 = makeEvaluator(withoutContext(macro.Expand1))
 )
 
 func makeEvaluator(eval evalFunc) compiler.Call {
@@ -34,8 +40,10 @@ func makeEvaluator(eval evalFunc) compiler.Call {
 			return err
 		}
 		ns := e.Globals()
-		fn := data.MakeProcedure(func(args ...ale.Value) ale.Value {
-			res, err := eval(ns, args[0])
+		fn := runtime.MakeContextProcedure(func(
+			ctx context.Context, args ...ale.Value,
+		) ale.Value {
+			res, err := eval(ctx, ns, args[0])
 			if err != nil {
 				panic(err)
 			}
@@ -48,3 +56,11 @@ func makeEvaluator(eval evalFunc) compiler.Call {
 		return nil
 	}
 }
+
+func withoutContext(expand expandFunc) evalFunc {
+	return func(_ context.Context, ns env.Namespace, v ale.Value) (
+		ale.Value, error,
+	) {
+		return expand(ns, v)
+	}
+}
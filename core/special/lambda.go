@@ -22,6 +22,8 @@ func Lambda(e encoder.Encoder, args ...ale.Value) error {
 		return err
 	}
 	fn.ArityChecker = pc.MakeArityChecker()
+	fn.Arity = &pc.Arity
+	fn.Signatures = makeSignatures(pc)
 	return nil
 }
 
@@ -37,7 +39,9 @@ func makeLambda(e encoder.Encoder, pc *params.ParamCases) error {
 func encodeCases(e encoder.Encoder, cases []*params.ParamCase) error {
 	switch len(cases) {
 	case 0:
-		noMatch := data.String(params.ErrNoMatchingParamPattern)
+		noMatch := data.NewError(
+			data.ArityErrorKind, params.ErrNoMatchingParamPattern, data.Null,
+		)
 		if err := generate.Literal(e, noMatch); err != nil {
 			return err
 		}
@@ -76,11 +80,19 @@ func encodePredicate(e encoder.Encoder, c *params.ParamCase) error {
 }
 
 func encodeConsequent(e encoder.Encoder, c *params.ParamCase) error {
-	e.PushParams(c.Params, c.Rest)
+	pushParams(e, c)
 	e.PushLocals()
+	if err := checkParamTypes(e, c); err != nil {
+		return err
+	}
+	start := len(e.Encode().Code)
 	if err := generate.Block(e, c.Body); err != nil {
 		return err
 	}
+	res := generate.CheckTypes(e, start, c.Types)
+	if err := checkResultType(e, c.Result, res); err != nil {
+		return err
+	}
 	e.Emit(isa.Return)
 	if err := e.PopLocals(); err != nil {
 		return err
@@ -88,3 +100,15 @@ func encodeConsequent(e encoder.Encoder, c *params.ParamCase) error {
 	e.PopParams()
 	return nil
 }
+
+// pushParams declares the parameters of a case at the location of its
+// parameter list, which is where any warnings about them are reported
+func pushParams(e encoder.Encoder, c *params.ParamCase) {
+	if l, ok := c.Signature.(*data.List); ok {
+		if loc, ok := l.Location(); ok {
+			prev := e.SetLocation(loc)
+			defer e.SetLocation(prev)
+		}
+	}
+	e.PushParams(c.Params, c.Rest)
+}
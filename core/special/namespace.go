@@ -59,6 +59,37 @@ func makeInNamespaceCall(ns env.Namespace) compiler.Call {
 	}
 }
 
+// Alias allows the namespace of a domain to be referred to by another name
+// when qualifying symbols in the current namespace
+func Alias(e encoder.Encoder, args ...ale.Value) error {
+	if err := data.CheckFixedArity(2, len(args)); err != nil {
+		return err
+	}
+	alias, ok := args[0].(data.Local)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExpectedName, args[0])
+	}
+	domain, ok := args[1].(data.Local)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExpectedName, args[1])
+	}
+	to := e.Globals()
+	if _, err := to.Environment().GetQualified(domain); err != nil {
+		return err
+	}
+	fn := data.MakeProcedure(func(...ale.Value) ale.Value {
+		if err := to.Alias(alias, domain); err != nil {
+			panic(err)
+		}
+		return domain
+	})
+	if err := generate.Literal(e, fn); err != nil {
+		return err
+	}
+	e.Emit(isa.Call0)
+	return nil
+}
+
 func Declared(e encoder.Encoder, args ...ale.Value) error {
 	if err := data.CheckRangedArity(0, 1, len(args)); err != nil {
 		return err
@@ -77,3 +77,35 @@ func TestMakeNamespaceErrors(t *testing.T) {
 		fmt.Errorf(env.ErrNameNotDeclared, LS("unknown-name")),
 	)
 }
+
+func TestAlias(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(define-namespace a.very.long.domain
+			(define x 99))
+		(alias v a.very.long.domain)
+		(alias v a.very.long.domain)
+		[v/x (eq v/x a.very.long.domain/x)]
+	`, V(I(99), data.True))
+}
+
+func TestAliasErrors(t *testing.T) {
+	as := assert.New(t)
+	as.ErrorWith(`(alias v)`, fmt.Errorf(data.ErrFixedArity, 2, 1))
+	as.ErrorWith(`(alias 99 core)`,
+		fmt.Errorf("%w: %s", special.ErrExpectedName, I(99)),
+	)
+	as.ErrorWith(`(alias v "core")`,
+		fmt.Errorf("%w: %s", special.ErrExpectedName, S("core")),
+	)
+	as.ErrorWith(`(alias v does-not-exist)`,
+		fmt.Errorf(env.ErrNamespaceNotFound, LS("does-not-exist")),
+	)
+
+	as.PanicWith(`
+		(define-namespace first-domain)
+		(define-namespace second-domain)
+		(alias taken first-domain)
+		(alias taken second-domain)
+	`, fmt.Errorf(env.ErrAliasAlreadyDeclared, LS("taken")))
+}
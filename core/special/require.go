@@ -0,0 +1,422 @@
+package special
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/eval"
+	"github.com/kode4food/ale/internal/compiler"
+	"github.com/kode4food/ale/internal/compiler/encoder"
+	"github.com/kode4food/ale/internal/compiler/generate"
+	lang "github.com/kode4food/ale/internal/lang/env"
+	"github.com/kode4food/ale/internal/runtime"
+	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/stream"
+	"github.com/kode4food/ale/read"
+)
+
+type (
+	requirement struct {
+		name   data.Local
+		alias  data.Local
+		refer  ale.Value
+		reload bool
+	}
+
+	loadingKey struct {
+		env  *env.Environment
+		name data.Local
+	}
+
+	// moduleLoad is a namespace that is being loaded by a requireChain.
+	// Chains that require the same namespace wait for it to be done
+	moduleLoad struct {
+		chain *requireChain
+		done  chan struct{}
+		ns    env.Namespace
+		err   error
+	}
+
+	// requireChain identifies the call chain of a require, which is carried
+	// by the context that modules are loaded with. A chain that requires a
+	// namespace it's already loading, or that would wait on a chain that is
+	// waiting on it, has encountered a cyclic require
+	requireChain struct {
+		waiting *moduleLoad
+	}
+
+	requireChainKey struct{}
+)
+
+const (
+	// ModuleExtension is appended to the path of a namespace when require
+	// searches for its source file
+	ModuleExtension = ".ale"
+
+	// DefaultRequirePath is searched by require when the requiring namespace
+	// doesn't resolve a *require-path*
+	DefaultRequirePath = "."
+)
+
+var (
+	ErrCyclicRequire        = errors.New("cyclic require of namespace")
+	ErrModuleNotFound       = errors.New("namespace not found on require path")
+	ErrUnexpectedRequire    = errors.New("unexpected require option")
+	ErrExpectedRequirePath  = errors.New("require path must contain strings")
+	ErrExpectedFileSystem   = errors.New("file system expected")
+	ErrMissingRequireOption = errors.New("require option is missing its value")
+)
+
+var loading = struct {
+	loads map[loadingKey]*moduleLoad
+	sync.Mutex
+}{
+	loads: map[loadingKey]*moduleLoad{},
+}
+
+// Require resolves a namespace, loading it from a file on the require path
+// of the current namespace's file system if it hasn't been loaded yet. The
+// namespace can then be aliased, and its public entries can be imported
+func Require(e encoder.Encoder, args ...ale.Value) error {
+	if err := data.CheckMinimumArity(1, len(args)); err != nil {
+		return err
+	}
+	r, err := parseRequirement(args...)
+	if err != nil {
+		return err
+	}
+	to := e.Globals()
+	var refer func(from env.Namespace) (imports, error)
+	if r.refer != nil {
+		if refer, err = getReferred(r.refer); err != nil {
+			return err
+		}
+	}
+	fn := runtime.MakeContextProcedure(func(
+		ctx context.Context, _ ...ale.Value,
+	) ale.Value {
+		from, err := require(ctx, to, r.name, r.reload)
+		if err != nil {
+			panic(err)
+		}
+		if r.alias != "" {
+			if err := to.Alias(r.alias, r.name); err != nil {
+				panic(err)
+			}
+		}
+		if refer != nil {
+			if err := referNames(from, to, refer, r.reload); err != nil {
+				panic(err)
+			}
+		}
+		return r.name
+	})
+	if err := generate.Literal(e, fn); err != nil {
+		return err
+	}
+	e.Emit(isa.Call0)
+	return nil
+}
+
+func parseRequirement(args ...ale.Value) (*requirement, error) {
+	name, err := parseModuleName(args[0])
+	if err != nil {
+		return nil, err
+	}
+	res := &requirement{name: name}
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case data.Keyword("reload"):
+			res.reload = true
+			continue
+		case data.Keyword("as"), data.Keyword("refer"):
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnexpectedRequire, args[i])
+		}
+		if i+1 == len(args) {
+			return nil, fmt.Errorf(
+				"%w: %s", ErrMissingRequireOption, args[i],
+			)
+		}
+		opt, v := args[i], args[i+1]
+		i++
+		if opt == data.Keyword("refer") {
+			res.refer = v
+			continue
+		}
+		alias, ok := v.(data.Local)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrExpectedName, v)
+		}
+		res.alias = alias
+	}
+	return res, nil
+}
+
+// parseModuleName returns the domain of the namespace that a require names.
+// A qualified symbol, such as util/strings, names the same namespace as its
+// dotted form, util.strings
+func parseModuleName(v ale.Value) (data.Local, error) {
+	switch v := v.(type) {
+	case data.Local:
+		return v, nil
+	case data.Qualified:
+		n := strings.ReplaceAll(data.ToString(v), "/", ".")
+		return data.Local(n), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrExpectedName, v)
+	}
+}
+
+func getReferred(
+	refer ale.Value,
+) (func(env.Namespace) (imports, error), error) {
+	if refer == data.Keyword("all") {
+		return func(from env.Namespace) (imports, error) {
+			names := localsToVector(from.Declared())
+			return buildImports(data.NewList(names...))
+		}, nil
+	}
+	var names *data.List
+	switch v := refer.(type) {
+	case data.Local:
+		names = data.NewList(v)
+	case data.Vector:
+		names = data.NewList(v...)
+	case *data.List:
+		names = v
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedImport, refer)
+	}
+	i, err := buildImports(names)
+	if err != nil {
+		return nil, err
+	}
+	return func(env.Namespace) (imports, error) {
+		return i, nil
+	}, nil
+}
+
+func referNames(
+	from, to env.Namespace, refer func(env.Namespace) (imports, error),
+	reload bool,
+) error {
+	i, err := refer(from)
+	if err != nil {
+		return err
+	}
+	if !reload {
+		return performImports(from, to, i)
+	}
+	declared := to.Entries()
+	res := imports{}
+	for alias, name := range i {
+		if _, ok := declared[alias]; !ok {
+			res[alias] = name
+		}
+	}
+	return performImports(from, to, res)
+}
+
+func require(
+	ctx context.Context, to env.Namespace, name data.Local, reload bool,
+) (env.Namespace, error) {
+	ctx, chain := withRequireChain(ctx)
+	e := to.Environment()
+	key := loadingKey{env: e, name: name}
+
+	loading.Lock()
+	if l, ok := loading.loads[key]; ok {
+		if l.chain.waitsOn(chain) {
+			loading.Unlock()
+			return nil, fmt.Errorf("%w: %s", ErrCyclicRequire, name)
+		}
+		chain.waiting = l
+		loading.Unlock()
+		return waitForLoad(ctx, chain, l)
+	}
+	if ns, err := e.GetQualified(name); err == nil && !reload {
+		loading.Unlock()
+		return ns, nil
+	}
+	l := &moduleLoad{
+		chain: chain,
+		done:  make(chan struct{}),
+	}
+	loading.loads[key] = l
+	loading.Unlock()
+
+	defer func() {
+		loading.Lock()
+		delete(loading.loads, key)
+		loading.Unlock()
+		close(l.done)
+	}()
+	l.ns, l.err = loadNamespace(ctx, to, name)
+	return l.ns, l.err
+}
+
+func waitForLoad(
+	ctx context.Context, chain *requireChain, l *moduleLoad,
+) (env.Namespace, error) {
+	defer func() {
+		loading.Lock()
+		chain.waiting = nil
+		loading.Unlock()
+	}()
+	select {
+	case <-l.done:
+		return l.ns, l.err
+	case <-ctx.Done():
+		return nil, runtime.ContextError(ctx)
+	}
+}
+
+// loadNamespace loads a namespace into a new namespace for its domain, which
+// only replaces an existing one if the load succeeds
+func loadNamespace(
+	ctx context.Context, to env.Namespace, name data.Local,
+) (env.Namespace, error) {
+	fsys, err := resolveFileSystem(to)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := resolveRequirePath(to)
+	if err != nil {
+		return nil, err
+	}
+	src, p, err := findModule(fsys, paths, name)
+	if err != nil {
+		return nil, err
+	}
+	return to.Environment().ReplaceQualified(name,
+		func(ns env.Namespace) error {
+			return loadModule(ctx, ns, fsys, paths, p, src)
+		},
+	)
+}
+
+func loadModule(
+	ctx context.Context, ns env.Namespace, fsys ale.Value, paths data.Vector,
+	p string, src data.String,
+) error {
+	if err := env.BindPrivate(ns, lang.FS, fsys); err != nil {
+		return err
+	}
+	if err := env.BindPrivate(ns, lang.RequirePath, paths); err != nil {
+		return err
+	}
+	seq, err := read.FromSource(ns, p, src)
+	if err != nil {
+		return err
+	}
+	_, err = eval.BlockContext(ctx, ns, seq)
+	return err
+}
+
+func withRequireChain(ctx context.Context) (context.Context, *requireChain) {
+	if c, ok := ctx.Value(requireChainKey{}).(*requireChain); ok {
+		return ctx, c
+	}
+	c := &requireChain{}
+	return context.WithValue(ctx, requireChainKey{}, c), c
+}
+
+// waitsOn returns whether this chain is, or is waiting on, the provided
+// chain. It must be called while holding the loading lock
+func (c *requireChain) waitsOn(other *requireChain) bool {
+	for ; c != nil; c = c.waiting.chainOf() {
+		if c == other {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *moduleLoad) chainOf() *requireChain {
+	if l == nil {
+		return nil
+	}
+	return l.chain
+}
+
+func resolveFileSystem(ns env.Namespace) (ale.Value, error) {
+	v, err := resolveBound(ns, lang.FS)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := v.(*compiler.Denied); ok {
+		return nil, d
+	}
+	if m, ok := v.(data.Mapped); ok {
+		if _, ok := m.Get(stream.OpenKey); ok {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrExpectedFileSystem, data.ToString(v))
+}
+
+func resolveRequirePath(ns env.Namespace) (data.Vector, error) {
+	v, err := resolveBound(ns, lang.RequirePath)
+	if err != nil {
+		return data.Vector{data.String(DefaultRequirePath)}, nil
+	}
+	s, ok := v.(data.Sequence)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrExpectedRequirePath, v)
+	}
+	var res data.Vector
+	for f, r, ok := s.Split(); ok; f, r, ok = r.Split() {
+		if _, ok := f.(data.String); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrExpectedRequirePath, f)
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
+
+func resolveBound(ns env.Namespace, n data.Local) (ale.Value, error) {
+	e, _, err := ns.Resolve(n)
+	if err != nil {
+		return nil, err
+	}
+	return e.Value()
+}
+
+func findModule(
+	fsys ale.Value, paths data.Vector, name data.Local,
+) (data.String, string, error) {
+	open, _ := fsys.(data.Mapped).Get(stream.OpenKey)
+	rel := strings.ReplaceAll(string(name), ".", "/") + ModuleExtension
+	for _, dir := range paths {
+		p := path.Join(string(dir.(data.String)), rel)
+		src, err := readModule(open.(data.Procedure), p)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		return src, p, err
+	}
+	return "", "", fmt.Errorf("%w: %s", ErrModuleNotFound, name)
+}
+
+func readModule(open data.Procedure, p string) (res data.String, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			if e, ok := rec.(error); ok {
+				err = e
+				return
+			}
+			panic(rec)
+		}
+	}()
+	b := open.Call(data.String(p), stream.ReadAll).(data.Bytes)
+	return data.String(b), nil
+}
@@ -0,0 +1,233 @@
+package special_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/core/bootstrap"
+	"github.com/kode4food/ale/core/special"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/eval"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func makeRequireNamespace(fsys fstest.MapFS) env.Namespace {
+	e := bootstrap.DevNullEnvironment()
+	ns := env.MustGetQualified(e, "user")
+	bootstrap.MustBindFileSystem(ns, fsys)
+	return ns
+}
+
+func evalRequire(ns env.Namespace, src string) (res ale.Value, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = rec.(error)
+		}
+	}()
+	return eval.String(ns, data.String(src))
+}
+
+func TestRequire(t *testing.T) {
+	as := assert.New(t)
+	fsys := fstest.MapFS{
+		"util/strings.ale": {Data: []byte(`
+			(require util.chars :refer [upper-char])
+			(define (shout s) (str (upper-char s) "!"))
+		`)},
+		"util/chars.ale": {Data: []byte(`
+			(define (upper-char s) (string/upper s))
+		`)},
+	}
+	ns := makeRequireNamespace(fsys)
+
+	res, err := evalRequire(ns, `
+		(require util.strings :as s :refer (shout [yell shout]))
+		(require util.strings)
+		[(shout "hi") (yell "yo") (s/shout "hey") (util.strings/shout "x")]
+	`)
+	if as.NoError(err) {
+		as.String(`["HI!" "YO!" "HEY!" "X!"]`, res)
+	}
+
+	res, err = evalRequire(ns, `
+		(require util/strings)
+		(require util/strings :as us)
+		[(us/shout "qualified") (util.strings/shout "dotted")]
+	`)
+	if as.NoError(err) {
+		as.String(`["QUALIFIED!" "DOTTED!"]`, res)
+	}
+
+	res, err = evalRequire(ns, `(require util.chars :refer :all) upper-char`)
+	if as.NoError(err) {
+		as.Identical(
+			env.MustResolveValue(ns, data.NewQualifiedSymbol(
+				"upper-char", "util.chars",
+			)),
+			res,
+		)
+	}
+}
+
+func TestRequireOnce(t *testing.T) {
+	as := assert.New(t)
+	fsys := fstest.MapFS{
+		"counter.ale": {Data: []byte(`(define created (gensym 'c))`)},
+	}
+	ns := makeRequireNamespace(fsys)
+
+	first, err := evalRequire(ns, `(require counter) counter/created`)
+	as.NoError(err)
+	second, err := evalRequire(ns, `(require counter) counter/created`)
+	as.NoError(err)
+	as.Equal(first, second)
+
+	referred, err := evalRequire(ns, `(require counter :refer [created]) created`)
+	as.NoError(err)
+	as.Equal(first, referred)
+
+	reloaded, err := evalRequire(ns, `
+		(require counter :refer [created] :reload)
+		counter/created
+	`)
+	as.NoError(err)
+	as.NotEqual(first, reloaded)
+}
+
+func TestRequireReloadFailure(t *testing.T) {
+	as := assert.New(t)
+	fsys := fstest.MapFS{
+		"changing.ale": {Data: []byte(`(define x 1)`)},
+	}
+	ns := makeRequireNamespace(fsys)
+
+	res, err := evalRequire(ns, `(require changing) changing/x`)
+	as.NoError(err)
+	as.Equal(I(1), res)
+
+	fsys["changing.ale"] = &fstest.MapFile{
+		Data: []byte(`(define x 2) (raise "boom")`),
+	}
+	_, err = evalRequire(ns, `(require changing :reload)`)
+	as.ErrorContains(err, "boom")
+	res, err = evalRequire(ns, `changing/x`)
+	as.NoError(err)
+	as.Equal(I(1), res)
+
+	fsys["changing.ale"] = &fstest.MapFile{Data: []byte(`(define x 3)`)}
+	res, err = evalRequire(ns, `(require changing :reload) changing/x`)
+	as.NoError(err)
+	as.Equal(I(3), res)
+}
+
+func TestRequireConcurrently(t *testing.T) {
+	as := assert.New(t)
+	fsys := fstest.MapFS{
+		"slow.ale": {Data: []byte(`
+			(define total (apply + (take 20000 (range))))
+			(define created (gensym 'c))
+		`)},
+	}
+	ns := makeRequireNamespace(fsys)
+
+	var wg sync.WaitGroup
+	res := make([]ale.Value, 8)
+	errs := make([]error, len(res))
+	for i := range res {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res[i], errs[i] = evalRequire(ns, `(require slow) slow/created`)
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		as.NoError(err)
+		as.Equal(res[0], res[i])
+	}
+}
+
+func TestRequirePath(t *testing.T) {
+	as := assert.New(t)
+	fsys := fstest.MapFS{
+		"lib/greet.ale": {Data: []byte(`(define hello "lib")`)},
+		"greet.ale":     {Data: []byte(`(define hello "root")`)},
+		"vendor/far.ale": {Data: []byte(`
+			(require greet)
+			(define h greet/hello)
+		`)},
+	}
+	ns := makeRequireNamespace(fsys)
+
+	res, err := evalRequire(ns, `
+		(define *require-path* ["lib" "vendor"])
+		(require far)
+		far/h
+	`)
+	if as.NoError(err) {
+		as.String("lib", res)
+	}
+}
+
+func TestRequireErrors(t *testing.T) {
+	as := assert.New(t)
+	fsys := fstest.MapFS{
+		"cycle/a.ale":  {Data: []byte(`(require cycle.b)`)},
+		"cycle/b.ale":  {Data: []byte(`(require cycle.a)`)},
+		"broken.ale":   {Data: []byte(`(define x 1) (raise "boom")`)},
+		"reaches.ale":  {Data: []byte(`(require elsewhere)`)},
+		"elsewhere.ae": {Data: []byte(``)},
+	}
+	ns := makeRequireNamespace(fsys)
+
+	_, err := evalRequire(ns, `(require cycle.a)`)
+	as.ErrorIs(err, special.ErrCyclicRequire)
+	as.ErrorContains(err, "cyclic require of namespace: cycle.a")
+
+	_, err = evalRequire(ns, `(require missing)`)
+	as.ErrorIs(err, special.ErrModuleNotFound)
+	as.ErrorContains(err, "namespace not found on require path: missing")
+
+	_, err = evalRequire(ns, `(require broken)`)
+	as.ErrorContains(err, "boom")
+	_, err = ns.Environment().GetQualified("broken")
+	as.EqualError(err, fmt.Sprintf(env.ErrNamespaceNotFound, "broken"))
+
+	_, err = evalRequire(ns, `(require reaches)`)
+	as.ErrorIs(err, special.ErrModuleNotFound)
+	_, err = ns.Environment().GetQualified("reaches")
+	as.NotNil(err)
+
+	_, err = evalRequire(ns, `(define *require-path* 99) (require other)`)
+	as.ErrorIs(err, special.ErrExpectedRequirePath)
+
+	as.ErrorWith(`(require)`, fmt.Errorf(data.ErrMinimumArity, 1, 0))
+	as.ErrorWith(`(require 99)`,
+		fmt.Errorf("%w: %s", special.ErrExpectedName, I(99)),
+	)
+	as.ErrorWith(`(require x :bogus)`,
+		fmt.Errorf("%w: %s", special.ErrUnexpectedRequire, K("bogus")),
+	)
+	as.ErrorWith(`(require x :as)`,
+		fmt.Errorf("%w: %s", special.ErrMissingRequireOption, K("as")),
+	)
+	as.ErrorWith(`(require x :as 99)`,
+		fmt.Errorf("%w: %s", special.ErrExpectedName, I(99)),
+	)
+	as.ErrorWith(`(require x :refer 99)`,
+		fmt.Errorf("%w: %s", special.ErrUnexpectedImport, I(99)),
+	)
+}
+
+func TestRequireExisting(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(require string :as s :refer [upper])
+		[(s/lower "QUIET") (upper "loud")]
+	`, V(S("quiet"), S("LOUD")))
+}
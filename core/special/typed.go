@@ -0,0 +1,124 @@
+package special
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/basics"
+	"github.com/kode4food/ale/internal/compiler"
+	"github.com/kode4food/ale/internal/compiler/diagnostic"
+	"github.com/kode4food/ale/internal/compiler/encoder"
+	"github.com/kode4food/ale/internal/compiler/generate"
+	"github.com/kode4food/ale/internal/lang/params"
+	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/types"
+)
+
+const (
+	// ErrParamType is raised when a procedure is called with an argument
+	// that doesn't satisfy the Type annotation of its parameter
+	ErrParamType = "parameter %s expects %s, got: %s"
+
+	// ErrResultType is raised when a procedure returns a value that doesn't
+	// satisfy the Type annotation of its result
+	ErrResultType = "result expects %s, got: %s"
+
+	// WarnResultType is reported when a procedure can never return a value
+	// that satisfies the Type annotation of its result
+	WarnResultType = "result expects %s, got %s"
+)
+
+type typeFailure func(ale.Value) string
+
+func makeSignatures(pc *params.ParamCases) []types.Signature {
+	if !slices.ContainsFunc(pc.Cases, (*params.ParamCase).IsTyped) {
+		return nil
+	}
+	return basics.Map(pc.Cases, (*params.ParamCase).TypeSignature)
+}
+
+// checkParamTypes emits the checks of any annotated parameters. The value of
+// a rest parameter is checked element by element
+func checkParamTypes(e encoder.Encoder, c *params.ParamCase) error {
+	for i, t := range c.Types {
+		if isAnyType(t) {
+			continue
+		}
+		name := c.Params[i]
+		fail := func(v ale.Value) string {
+			return fmt.Sprintf(
+				ErrParamType, name, t.Name(), data.ToQuotedString(v),
+			)
+		}
+		var check data.Procedure
+		if c.Rest && i == len(c.Params)-1 {
+			e.Emit(isa.ArgsRest, isa.Operand(i))
+			check = makeRestTypeCheck(t, fail)
+		} else {
+			e.Emit(isa.Arg, isa.Operand(i))
+			check = makeTypeCheck(t, fail)
+		}
+		if err := generate.Literal(e, check); err != nil {
+			return err
+		}
+		e.Emit(isa.Call, 1)
+		e.Emit(isa.Pop)
+	}
+	return nil
+}
+
+// checkResultType emits a check of the value that a procedure is about to
+// return, unless its inferred Type proves that the check would succeed. The
+// check is a ResultCheck so that self-calls can remain in tail position
+func checkResultType(e encoder.Encoder, want, got ale.Type) error {
+	if isAnyType(want) || want.Accepts(got) {
+		return nil
+	}
+	if !types.Intersects(want, got) {
+		e.Warn(diagnostic.Type, WarnResultType,
+			want.Name(), generate.TypeName(got),
+		)
+	}
+	check := &compiler.ResultCheck{
+		Procedure: makeTypeCheck(want, func(v ale.Value) string {
+			return fmt.Sprintf(
+				ErrResultType, want.Name(), data.ToQuotedString(v),
+			)
+		}),
+	}
+	if err := generate.Literal(e, check); err != nil {
+		return err
+	}
+	e.Emit(isa.Call, 1)
+	return nil
+}
+
+func makeTypeCheck(t ale.Type, fail typeFailure) data.Procedure {
+	pred := data.MakeTypePredicate(t)
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		v := args[0]
+		if !pred.Call(v).(data.Bool) {
+			panic(data.NewError(data.TypeErrorKind, fail(v), v))
+		}
+		return v
+	}, 1)
+}
+
+func makeRestTypeCheck(t ale.Type, fail typeFailure) data.Procedure {
+	pred := data.MakeTypePredicate(t)
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		for _, v := range args[0].(data.Vector) {
+			if !pred.Call(v).(data.Bool) {
+				panic(data.NewError(data.TypeErrorKind, fail(v), v))
+			}
+		}
+		return args[0]
+	}, 1)
+}
+
+func isAnyType(t ale.Type) bool {
+	_, ok := t.(*types.Any)
+	return ok
+}
@@ -0,0 +1,66 @@
+package special_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/ale/core/special"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestTypedLambda(t *testing.T) {
+	as := assert.New(t)
+
+	as.MustEvalTo(`
+		(define (add [x :number] [y :number]) :- :number (+ x y))
+		(add 20 30)
+	`, I(50))
+
+	as.MustEvalTo(`
+		(define (join [sep :string] . [parts :string])
+			(apply str sep parts))
+		(join "-" "a" "b")
+	`, S("-ab"))
+
+	as.MustEvalTo(`
+		(define (loop [n :number]) :- :number
+			(if (= n 0) 0 (loop (- n 1))))
+		(loop 1000000)
+	`, I(0))
+
+	as.MustEvalTo(`
+		(define (message [x :number]) :- :string
+			(if (> x 0) "positive" x))
+		(try (message -1) (catch [e :type-error] (:data e)))
+	`, I(-1))
+}
+
+func TestTypedLambdaErrors(t *testing.T) {
+	as := assert.New(t)
+
+	as.PanicWith(`
+		(define (add [x :number] [y :number]) (+ x y))
+		(add 1 "2")
+	`, fmt.Errorf(special.ErrParamType, "y", "number", `"2"`))
+
+	as.PanicWith(`
+		(define (join [sep :string] . [parts :string]) parts)
+		(join "-" "a" :b)
+	`, fmt.Errorf(special.ErrParamType, "parts", "string", ":b"))
+
+	as.PanicWith(`
+		(define (name [x :keyword]) :- :string (str x))
+		(define (bad [x :keyword]) :- :string x)
+		[(name :ok) (bad :fail)]
+	`, fmt.Errorf(special.ErrResultType, "string", ":fail"))
+
+	as.PanicWith(`
+		((lambda ([x [:string :null]]) x) 1)
+	`, fmt.Errorf(special.ErrParamType, "x", "union(null,string)", "1"))
+
+	as.PanicWith(`
+		(define (q [x :list]) x)
+		(q [1])
+	`, fmt.Errorf(special.ErrParamType, "x", "list", "[1]"))
+}
@@ -0,0 +1,29 @@
+package special
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/compiler/encoder"
+	"github.com/kode4food/ale/internal/compiler/generate"
+)
+
+// ErrExpectedWarningKind is raised when a warning isn't identified by a
+// keyword
+var ErrExpectedWarningKind = errors.New("warning kind must be a keyword")
+
+// Warn reports a compiler warning of the specified kind and then encodes its
+// form. It allows macros to describe problems with the code they expand
+func Warn(e encoder.Encoder, args ...ale.Value) error {
+	if err := data.CheckFixedArity(3, len(args)); err != nil {
+		return err
+	}
+	kind, ok := args[0].(data.Keyword)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExpectedWarningKind, args[0])
+	}
+	e.Warn(kind, "%s", data.ToString(args[1]))
+	return generate.Value(e, args[2])
+}
@@ -0,0 +1,23 @@
+package special_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/ale/core/special"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestWarn(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`(%warn :unreachable "never mind" (+ 1 2))`, I(3))
+
+	as.ErrorWith(`(%warn "unreachable" "message" 1)`,
+		fmt.Errorf("%w: %s", special.ErrExpectedWarningKind, S("unreachable")),
+	)
+	as.ErrorWith(`(%warn :unreachable "message")`,
+		fmt.Errorf(data.ErrFixedArity, 3, 2),
+	)
+}
@@ -58,7 +58,7 @@ func makeFixedChecker(fixed int) ArityChecker {
 // CheckFixedArity allows for a fixed number of arguments
 func CheckFixedArity(fixed, count int) error {
 	if count != fixed {
-		return fmt.Errorf(ErrFixedArity, fixed, count)
+		return arityError(ErrFixedArity, fixed, count)
 	}
 	return nil
 }
@@ -79,7 +79,7 @@ func makeMinimumChecker(min int) ArityChecker {
 // CheckMinimumArity allows for a minimum number of arguments
 func CheckMinimumArity(min, count int) error {
 	if count < min {
-		return fmt.Errorf(ErrMinimumArity, min, count)
+		return arityError(ErrMinimumArity, min, count)
 	}
 	return nil
 }
@@ -93,7 +93,11 @@ func makeRangedChecker(min, max int) ArityChecker {
 // CheckRangedArity allows for a ranged number of arguments
 func CheckRangedArity(min, max, count int) error {
 	if count < min || count > max {
-		return fmt.Errorf(ErrRangedArity, min, max, count)
+		return arityError(ErrRangedArity, min, max, count)
 	}
 	return nil
 }
+
+func arityError(format string, a ...any) error {
+	return NewError(ArityErrorKind, fmt.Sprintf(format, a...), Null)
+}
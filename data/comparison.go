@@ -1,5 +1,11 @@
 package data
 
+import (
+	"cmp"
+
+	"github.com/kode4food/ale"
+)
+
 // Comparison represents the result of an equality comparison
 type Comparison int
 
@@ -10,3 +16,88 @@ const (
 	GreaterThan
 	Incomparable
 )
+
+// ErrIncomparable is raised when two Values have no defined ordering
+const ErrIncomparable = "values can't be compared: %s and %s"
+
+// Compare returns the ordering of two Values. Numbers, strings, keywords,
+// symbols, and booleans are ordered relative to Values of the same kind.
+// Pairs and sequences are ordered lexicographically by their elements. Any
+// other combination of Values is Incomparable
+func Compare(l, r ale.Value) Comparison {
+	switch l := l.(type) {
+	case Number:
+		if r, ok := r.(Number); ok {
+			return l.Cmp(r)
+		}
+	case String:
+		if r, ok := r.(String); ok {
+			return Comparison(cmp.Compare(l, r))
+		}
+	case Keyword:
+		if r, ok := r.(Keyword); ok {
+			return Comparison(cmp.Compare(l, r))
+		}
+	case Symbol:
+		if r, ok := r.(Symbol); ok {
+			return Comparison(cmp.Compare(ToString(l), ToString(r)))
+		}
+	case Bool:
+		if r, ok := r.(Bool); ok {
+			return compareBools(l, r)
+		}
+	case *Cons:
+		if r, ok := r.(*Cons); ok {
+			if c := Compare(l.Car(), r.Car()); c != EqualTo {
+				return c
+			}
+			return Compare(l.Cdr(), r.Cdr())
+		}
+	case *Object, *Set:
+		return Incomparable
+	case Sequence:
+		if r, ok := r.(Sequence); ok && isOrderedSequence(r) {
+			return compareSequences(l, r)
+		}
+	}
+	return Incomparable
+}
+
+func compareBools(l, r Bool) Comparison {
+	switch {
+	case l == r:
+		return EqualTo
+	case l == False:
+		return LessThan
+	default:
+		return GreaterThan
+	}
+}
+
+func compareSequences(l, r Sequence) Comparison {
+	for {
+		lf, lr, lok := l.Split()
+		rf, rr, rok := r.Split()
+		switch {
+		case !lok && !rok:
+			return EqualTo
+		case !lok:
+			return LessThan
+		case !rok:
+			return GreaterThan
+		}
+		if c := Compare(lf, rf); c != EqualTo {
+			return c
+		}
+		l, r = lr, rr
+	}
+}
+
+func isOrderedSequence(s Sequence) bool {
+	switch s.(type) {
+	case String, *Object, *Set:
+		return false
+	default:
+		return true
+	}
+}
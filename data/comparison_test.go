@@ -0,0 +1,46 @@
+package data_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestCompare(t *testing.T) {
+	as := assert.New(t)
+
+	as.Equal(data.LessThan, data.Compare(I(1), F(1.5)))
+	as.Equal(data.GreaterThan, data.Compare(R(1, 2), I(0)))
+	as.Equal(data.EqualTo, data.Compare(I(2), F(2)))
+	as.Equal(data.Incomparable, data.Compare(F(1), data.Float(math.NaN())))
+
+	as.Equal(data.LessThan, data.Compare(S("apple"), S("banana")))
+	as.Equal(data.GreaterThan, data.Compare(K("b"), K("a")))
+	as.Equal(data.LessThan, data.Compare(LS("a"), LS("b")))
+	as.Equal(data.LessThan,
+		data.Compare(data.NewQualifiedSymbol("x", "a"), LS("b")),
+	)
+	as.Equal(data.LessThan, data.Compare(data.False, data.True))
+	as.Equal(data.EqualTo, data.Compare(data.True, data.True))
+
+	as.Equal(data.Incomparable, data.Compare(I(1), S("1")))
+	as.Equal(data.Incomparable, data.Compare(S("a"), K("a")))
+	as.Equal(data.Incomparable, data.Compare(O(), O()))
+}
+
+func TestCompareSequences(t *testing.T) {
+	as := assert.New(t)
+
+	as.Equal(data.EqualTo, data.Compare(V(I(1), I(2)), L(I(1), I(2))))
+	as.Equal(data.LessThan, data.Compare(V(I(1)), V(I(1), I(2))))
+	as.Equal(data.GreaterThan, data.Compare(V(I(2)), V(I(1), I(2))))
+	as.Equal(data.LessThan, data.Compare(data.Null, V(I(1))))
+	as.Equal(data.Incomparable, data.Compare(V(I(1)), V(S("1"))))
+	as.Equal(data.Incomparable, data.Compare(V(I(1)), S("1")))
+
+	as.Equal(data.LessThan, data.Compare(C(K("a"), I(2)), C(K("b"), I(1))))
+	as.Equal(data.GreaterThan, data.Compare(C(K("a"), I(2)), C(K("a"), I(1))))
+}
@@ -0,0 +1,196 @@
+package data
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/internal/types"
+)
+
+// Error is a first-class error Value. It is identified by a Keyword kind, and
+// carries a message, an arbitrary data Value, and an optional cause. Errors
+// of the same kind are considered a match by errors.Is
+type Error struct {
+	kind    Keyword
+	message string
+	data    ale.Value
+	cause   error
+	stack   Vector
+}
+
+// Error Kinds
+const (
+	// ErrorKind identifies a general error, including one that wraps a raised
+	// Value that isn't an error
+	ErrorKind = Keyword("error")
+
+	// ArityErrorKind identifies an error raised when a procedure is called
+	// with an unexpected number of arguments
+	ArityErrorKind = Keyword("arity-error")
+
+	// TypeErrorKind identifies an error raised when a value is not of the
+	// type that was expected
+	TypeErrorKind = Keyword("type-error")
+
+	// ArithmeticErrorKind identifies an error raised when an arithmetic
+	// operation can't produce a result, such as a division by zero
+	ArithmeticErrorKind = Keyword("arithmetic-error")
+
+	// UnboundErrorKind identifies an error raised when a symbol can't be
+	// resolved to a bound value
+	UnboundErrorKind = Keyword("unbound-error")
+
+	// CancelledErrorKind identifies an error raised when the context of an
+	// evaluation is cancelled or its deadline is exceeded
+	CancelledErrorKind = Keyword("cancelled-error")
+
+	// InstructionLimitErrorKind identifies an error raised when an evaluation
+	// executes more instructions than its limits allow
+	InstructionLimitErrorKind = Keyword("instruction-limit-error")
+
+	// DepthLimitErrorKind identifies an error raised when calls are nested
+	// more deeply than the limits of an evaluation allow
+	DepthLimitErrorKind = Keyword("depth-limit-error")
+
+	// DeadlineErrorKind identifies an error raised when an evaluation runs
+	// for longer than its limits allow
+	DeadlineErrorKind = Keyword("deadline-error")
+)
+
+// Error Keys
+const (
+	KindKey    = Keyword("kind")
+	MessageKey = Keyword("message")
+	DataKey    = Keyword("data")
+	CauseKey   = Keyword("cause")
+	StackKey   = Keyword("stack")
+)
+
+var (
+	errorSalt = rand.Uint64()
+
+	// compile-time checks for interface implementation
+	_ interface {
+		error
+		Hashed
+		Mapped
+		ale.Typed
+		fmt.Stringer
+	} = (*Error)(nil)
+)
+
+// NewError constructs a new Error of the specified kind
+func NewError(kind Keyword, message string, data ale.Value) *Error {
+	return &Error{
+		kind:    kind,
+		message: message,
+		data:    data,
+	}
+}
+
+// WrapError returns the provided error as an Error. If it isn't already an
+// Error, a general Error is constructed that uses it as a cause
+func WrapError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return NewError(ErrorKind, err.Error(), Null).WithCause(err)
+}
+
+// WithCause returns a copy of the Error that has the provided cause
+func (e *Error) WithCause(cause error) *Error {
+	res := *e
+	res.cause = cause
+	return &res
+}
+
+// WithStack returns a copy of the Error that carries the provided stack
+func (e *Error) WithStack(stack Vector) *Error {
+	res := *e
+	res.stack = stack
+	return &res
+}
+
+// Kind returns the Keyword that identifies the kind of Error
+func (e *Error) Kind() Keyword {
+	return e.kind
+}
+
+// Message returns the Error's message
+func (e *Error) Message() string {
+	return e.message
+}
+
+// Data returns the data Value that the Error carries
+func (e *Error) Data() ale.Value {
+	return e.data
+}
+
+// Cause returns the error that caused this Error, if there is one
+func (e *Error) Cause() error {
+	return e.cause
+}
+
+// Stack returns the call stack that the Error propagated through, if it has
+// been captured
+func (e *Error) Stack() Vector {
+	return e.stack
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether the target is an Error of the same kind
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.kind == e.kind
+}
+
+func (e *Error) Get(key ale.Value) (ale.Value, bool) {
+	switch key {
+	case KindKey:
+		return e.kind, true
+	case MessageKey:
+		return String(e.message), true
+	case DataKey:
+		return e.data, true
+	case CauseKey:
+		if e.cause != nil {
+			return WrapError(e.cause), true
+		}
+	case StackKey:
+		if e.stack != nil {
+			return e.stack, true
+		}
+	}
+	return Null, false
+}
+
+func (e *Error) Equal(other ale.Value) bool {
+	if other, ok := other.(*Error); ok {
+		return e == other ||
+			e.kind == other.kind &&
+				e.message == other.message &&
+				e.data.Equal(other.data)
+	}
+	return false
+}
+
+func (e *Error) String() string {
+	return e.message
+}
+
+func (e *Error) Type() ale.Type {
+	return types.MakeLiteral(types.BasicError, e)
+}
+
+func (e *Error) HashCode() uint64 {
+	return errorSalt ^ e.kind.HashCode() ^ HashString(e.message) ^
+		HashCode(e.data)
+}
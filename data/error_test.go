@@ -0,0 +1,73 @@
+package data_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+func TestError(t *testing.T) {
+	as := assert.New(t)
+
+	e := data.NewError(K("bad-thing"), "it broke", I(42))
+	as.Equal(K("bad-thing"), e.Kind())
+	as.Equal("it broke", e.Message())
+	as.Equal(I(42), e.Data())
+	as.Nil(e.Cause())
+	as.EqualError(e, "it broke")
+	as.String("it broke", e)
+
+	as.Equal(K("bad-thing"), as.MustGet(e, data.KindKey))
+	as.Equal(S("it broke"), as.MustGet(e, data.MessageKey))
+	as.Equal(I(42), as.MustGet(e, data.DataKey))
+	_, ok := e.Get(data.CauseKey)
+	as.False(ok)
+	_, ok = e.Get(data.StackKey)
+	as.False(ok)
+
+	as.True(e.Equal(data.NewError(K("bad-thing"), "it broke", I(42))))
+	as.False(e.Equal(data.NewError(K("bad-thing"), "it broke", I(43))))
+	as.False(e.Equal(S("it broke")))
+	as.Equal(
+		e.HashCode(),
+		data.NewError(K("bad-thing"), "it broke", I(42)).HashCode(),
+	)
+}
+
+func TestErrorCause(t *testing.T) {
+	as := assert.New(t)
+
+	goErr := errors.New("go error")
+	inner := data.WrapError(goErr)
+	as.Equal(data.ErrorKind, inner.Kind())
+	as.Equal("go error", inner.Message())
+	as.Identical(inner, data.WrapError(inner))
+
+	outer := data.NewError(K("outer"), "outer", data.Null).WithCause(inner)
+	as.Identical(inner, outer.Cause())
+	as.Identical(inner, as.MustGet(outer, data.CauseKey))
+
+	as.True(errors.Is(outer, goErr))
+	as.True(errors.Is(outer, data.NewError(data.ErrorKind, "", data.Null)))
+	as.True(errors.Is(outer, data.NewError(K("outer"), "", data.Null)))
+	as.False(errors.Is(outer, data.NewError(K("missing"), "", data.Null)))
+
+	var target *data.Error
+	as.True(errors.As(outer, &target))
+	as.Equal(K("outer"), target.Kind())
+
+	stack := data.NewVector(S("frame"))
+	traced := outer.WithStack(stack)
+	as.Nil(outer.Stack())
+	as.Equal(stack, traced.Stack())
+	as.Equal(stack, as.MustGet(traced, data.StackKey))
+}
+
+func TestArityErrorKind(t *testing.T) {
+	as := assert.New(t)
+	err := data.CheckFixedArity(1, 2)
+	as.True(errors.Is(err, data.NewError(data.ArityErrorKind, "", data.Null)))
+}
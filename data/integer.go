@@ -2,7 +2,6 @@ package data
 
 import (
 	"cmp"
-	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -122,7 +121,7 @@ func (l Integer) Div(r Number) Number {
 		return pl.Div(pr)
 	}
 	if ri == 0 {
-		panic(errors.New(ErrDivideByZero))
+		panic(NewDivideByZeroError())
 	}
 	res := big.NewRat(int64(l), int64(ri))
 	return maybeWhole(res)
@@ -135,7 +134,7 @@ func (l Integer) Mod(r Number) Number {
 		return pl.Mod(pr)
 	}
 	if ri == 0 {
-		panic(errors.New(ErrDivideByZero))
+		panic(NewDivideByZeroError())
 	}
 	res := l % ri
 	if (res < 0 && ri > 0) || (res > 0 && ri < 0) {
@@ -247,7 +246,7 @@ func (l *BigInt) Div(r Number) Number {
 		lb := (*big.Int)(l)
 		rb := (*big.Int)(ri)
 		if rb.IsInt64() && rb.Int64() == 0 {
-			panic(errors.New(ErrDivideByZero))
+			panic(NewDivideByZeroError())
 		}
 		res := new(big.Int).Quo(lb, rb)
 		return maybeInteger(res)
@@ -261,7 +260,7 @@ func (l *BigInt) Mod(r Number) Number {
 		lb := (*big.Int)(l)
 		rb := (*big.Int)(ri)
 		if rb.IsInt64() && rb.Int64() == 0 {
-			panic(errors.New(ErrDivideByZero))
+			panic(NewDivideByZeroError())
 		}
 		res := new(big.Int).Rem(lb, rb)
 		return maybeInteger(res)
@@ -323,3 +322,9 @@ func maybeInteger(bi *big.Int) Number {
 	}
 	return (*BigInt)(bi)
 }
+
+// NewDivideByZeroError constructs the Error that is raised when an attempt is
+// made to divide by zero
+func NewDivideByZeroError() *Error {
+	return NewError(ArithmeticErrorKind, ErrDivideByZero, Null)
+}
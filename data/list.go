@@ -15,6 +15,7 @@ import (
 type List struct {
 	first ale.Value
 	rest  *List
+	loc   *Location
 	count int
 	hash  atomic.Uint64
 }
@@ -87,6 +88,28 @@ func (l *List) Prepend(v ale.Value) Sequence {
 	}
 }
 
+// WithLocation returns a copy of this List's head that is associated with the
+// provided source Location. The empty List can't carry a Location
+func (l *List) WithLocation(loc *Location) *List {
+	if l == nil {
+		return l
+	}
+	return &List{
+		first: l.first,
+		rest:  l.rest,
+		loc:   loc,
+		count: l.count,
+	}
+}
+
+// Location returns the source Location associated with this List, if any
+func (l *List) Location() (*Location, bool) {
+	if l == nil || l.loc == nil {
+		return nil, false
+	}
+	return l.loc, true
+}
+
 func (l *List) Reverse() Sequence {
 	if l == nil || l.count <= 1 {
 		return l
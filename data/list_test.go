@@ -151,3 +151,27 @@ func testSequenceCallInterface(as *assert.Wrapper, s data.Procedure) {
 	as.NoError(s.CheckArity(2))
 	as.NotNil(s.CheckArity(3))
 }
+
+func TestListLocation(t *testing.T) {
+	as := assert.New(t)
+
+	l1 := data.NewList(I(1), I(2), I(3))
+	_, ok := l1.Location()
+	as.False(ok)
+
+	loc := data.NewLocation("test.ale", 3, 7)
+	l2 := l1.WithLocation(loc)
+	as.Equal(l1, l2)
+	as.NotIdentical(l1, l2)
+	as.Identical(l1.Cdr(), l2.Cdr())
+
+	res, ok := l2.Location()
+	as.True(ok)
+	as.Identical(loc, res)
+	as.String("test.ale:3:7", res.String())
+	as.String("3:7", data.NewLocation("", 3, 7).String())
+
+	_, ok = l2.Prepend(I(0)).(*data.List).Location()
+	as.False(ok)
+	as.Nil(data.Null.WithLocation(loc))
+}
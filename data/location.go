@@ -0,0 +1,27 @@
+package data
+
+import "fmt"
+
+// Location identifies a position within a named source. Lines and columns
+// are one-based, and an empty Source means the name is unknown
+type Location struct {
+	Source string
+	Line   int
+	Column int
+}
+
+// NewLocation constructs a new source Location
+func NewLocation(source string, line, column int) *Location {
+	return &Location{
+		Source: source,
+		Line:   line,
+		Column: column,
+	}
+}
+
+func (l *Location) String() string {
+	if l.Source == "" {
+		return fmt.Sprintf("%d:%d", l.Line, l.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", l.Source, l.Line, l.Column)
+}
@@ -42,6 +42,12 @@ func (t *TypePredicate) Call(args ...ale.Value) ale.Value {
 	return Bool(t.typ.Accepts(other))
 }
 
+// ResultType returns the Type of the results of calling the TypePredicate,
+// which are always Booleans
+func (t *TypePredicate) ResultType(int) ale.Type {
+	return types.BasicBoolean
+}
+
 func (t *TypePredicate) CheckArity(argc int) error {
 	return CheckFixedArity(1, argc)
 }
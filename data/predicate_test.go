@@ -6,6 +6,7 @@ import (
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/assert"
 	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/types"
 )
 
 func TestTypePredicateCall(t *testing.T) {
@@ -19,6 +20,7 @@ func TestTypePredicateCall(t *testing.T) {
 		as.True(pred.Call(L(I(1), I(2), I(3))))
 		as.False(pred.Call(l2))
 		as.False(pred.Call(v1))
+		as.Equal(types.BasicBoolean, pred.ResultType(1))
 	}
 }
 
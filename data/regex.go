@@ -0,0 +1,77 @@
+package data
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/internal/lang"
+	"github.com/kode4food/ale/internal/types"
+)
+
+// Regex is a Value that wraps a compiled regular expression. The expression
+// uses the RE2 syntax that is supported by Go's regexp package
+type Regex struct {
+	re *regexp.Regexp
+}
+
+var (
+	regexSalt = rand.Uint64()
+
+	// compile-time checks for interface implementation
+	_ interface {
+		Hashed
+		ale.Typed
+		fmt.Stringer
+	} = (*Regex)(nil)
+)
+
+// CompileRegex compiles the provided expression into a Regex
+func CompileRegex(expr string) (*Regex, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &Regex{re: re}, nil
+}
+
+// MustCompileRegex compiles the provided expression or explodes
+func MustCompileRegex(expr string) *Regex {
+	res, err := CompileRegex(expr)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// Regexp returns the Go regular expression that this Regex wraps
+func (r *Regex) Regexp() *regexp.Regexp {
+	return r.re
+}
+
+// Source returns the expression that this Regex was compiled from
+func (r *Regex) Source() string {
+	return r.re.String()
+}
+
+func (r *Regex) Equal(other ale.Value) bool {
+	if other, ok := other.(*Regex); ok {
+		return r == other || r.Source() == other.Source()
+	}
+	return false
+}
+
+func (r *Regex) String() string {
+	src := strings.ReplaceAll(r.Source(), lang.StringQuote, `\"`)
+	return lang.RegexStart + src + lang.StringQuote
+}
+
+func (r *Regex) Type() ale.Type {
+	return types.MakeLiteral(types.BasicRegex, r)
+}
+
+func (r *Regex) HashCode() uint64 {
+	return regexSalt ^ HashString(r.Source())
+}
@@ -0,0 +1,32 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/types"
+)
+
+func TestRegex(t *testing.T) {
+	as := assert.New(t)
+
+	r1 := data.MustCompileRegex(`\d+`)
+	r2 := data.MustCompileRegex(`\d+`)
+	r3 := data.MustCompileRegex(`say "hi"`)
+	as.Equal(r1, r2)
+	as.NotEqual(r1, r3)
+	as.False(r1.Equal(S(`\d+`)))
+	as.Equal(r1.HashCode(), r2.HashCode())
+
+	as.String(`\d+`, r1.Source())
+	as.String(`#"\d+"`, r1)
+	as.String(`#"say \"hi\""`, r3)
+	as.True(r1.Regexp().MatchString("abc123"))
+	as.True(types.BasicRegex.Accepts(r1.Type()))
+
+	_, err := data.CompileRegex(`(`)
+	as.ExpectError("error parsing regexp", err)
+	as.Panics(func() { data.MustCompileRegex(`(`) })
+}
@@ -57,7 +57,8 @@ const (
 	upper           = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 	SymbolGenDigits = decimal + lower + upper + "-+"
 
-	genSymTemplate = "x-%s-gensym-%s-%s"
+	genSymMarker   = "-gensym-"
+	genSymTemplate = "x-%s" + genSymMarker + "%s-%s"
 	genSymOverflow = uint8(len(SymbolGenDigits))
 )
 
@@ -182,6 +183,22 @@ func (l Local) String() string {
 	return string(l)
 }
 
+// IsGenerated returns whether the Local was produced by a SymbolGenerator
+func (l Local) IsGenerated() bool {
+	return strings.Contains(string(l), genSymMarker)
+}
+
+// GeneratedFrom returns the name that a generated Local was produced from. A
+// Local that wasn't produced by a SymbolGenerator is returned as is
+func (l Local) GeneratedFrom() Local {
+	s := string(l)
+	i := strings.LastIndex(s, genSymMarker)
+	if i < 0 {
+		return l
+	}
+	return Local(strings.TrimPrefix(s[:i], "x-"))
+}
+
 func (l Local) HashCode() uint64 {
 	return lclSalt ^ HashString(string(l))
 }
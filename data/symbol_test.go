@@ -105,6 +105,10 @@ func TestSymbolGeneration(t *testing.T) {
 	}
 
 	as.String(fmt.Sprintf(prefix, gen.Prefix(), "10"), gen.Local("hello"))
+	as.True(gen.Local("hello").IsGenerated())
+	as.False(data.Local("hello").IsGenerated())
+	as.Equal(data.Local("hello"), gen.Local("hello").GeneratedFrom())
+	as.Equal(data.Local("x-hi"), data.Local("x-hi").GeneratedFrom())
 }
 
 func TestSymbolHashing(t *testing.T) {
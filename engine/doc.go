@@ -0,0 +1,5 @@
+// Package engine provides a high-level API for embedding Ale in Go
+// applications. An Engine bootstraps an Environment, registers Go functions
+// and values, loads Ale source, and calls Ale procedures with Go arguments.
+// Errors raised while doing so are returned rather than panicking.
+package engine
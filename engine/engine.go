@@ -0,0 +1,257 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"reflect"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/core/bootstrap"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/eval"
+	"github.com/kode4food/ale/ffi"
+	"github.com/kode4food/ale/internal/runtime"
+	"github.com/kode4food/ale/read"
+)
+
+type (
+	// Engine evaluates Ale code on behalf of a Go application. Its source is
+	// loaded into, and its registered values are bound in, a single Namespace
+	Engine struct {
+		env *env.Environment
+		ns  env.Namespace
+	}
+
+	// Option configures an Engine when it is created
+	Option func(*config)
+
+	config struct {
+		env    *env.Environment
+		domain data.Local
+	}
+)
+
+// Error messages
+const (
+	// ErrExpectedProcedure is raised when Call resolves a name that isn't
+	// bound to a procedure
+	ErrExpectedProcedure = "expected procedure, got: %s"
+
+	// ErrExpectedPointer is raised when Decode is given a target that isn't a
+	// non-nil pointer
+	ErrExpectedPointer = "decode target must be a non-nil pointer, got: %T"
+)
+
+// DefaultDomain is the domain of the Namespace used by an Engine, unless the
+// WithDomain Option is provided
+const DefaultDomain = data.Local("user")
+
+var anyType = reflect.TypeFor[any]()
+
+// WithEnvironment is an Option that has the Engine use an Environment that
+// has already been bootstrapped, such as one created by bootstrap.Sandboxed
+func WithEnvironment(e *env.Environment) Option {
+	return func(c *config) {
+		c.env = e
+	}
+}
+
+// WithDomain is an Option that sets the domain of the Namespace that the
+// Engine loads source into and binds registered values in
+func WithDomain(domain data.Local) Option {
+	return func(c *config) {
+		c.domain = domain
+	}
+}
+
+// New creates an Engine. Unless the WithEnvironment Option is provided, the
+// Engine's Environment is isolated from the standard I/O of the process
+func New(opts ...Option) (*Engine, error) {
+	c := &config{domain: DefaultDomain}
+	for _, o := range opts {
+		o(c)
+	}
+	if c.env == nil {
+		c.env = bootstrap.DevNullEnvironment()
+	}
+	ns, err := c.env.GetQualified(c.domain)
+	if err != nil {
+		if ns, err = c.env.NewQualified(c.domain); err != nil {
+			return nil, err
+		}
+	}
+	return &Engine{
+		env: c.env,
+		ns:  ns,
+	}, nil
+}
+
+// Environment returns the Environment that the Engine evaluates code in
+func (e *Engine) Environment() *env.Environment {
+	return e.env
+}
+
+// Namespace returns the Namespace that the Engine loads source into
+func (e *Engine) Namespace() env.Namespace {
+	return e.ns
+}
+
+// Register binds a Go function or value to a public name in the Engine's
+// Namespace. Values that aren't already Ale values are wrapped using ffi
+func (e *Engine) Register(name data.Local, v any) error {
+	w, err := wrap(v)
+	if err != nil {
+		return err
+	}
+	return env.BindPublic(e.ns, name, w)
+}
+
+// Load evaluates Ale source in the Engine's Namespace, returning the value of
+// its last form
+func (e *Engine) Load(src string) (ale.Value, error) {
+	return e.LoadContext(context.Background(), src)
+}
+
+// LoadContext evaluates Ale source in the Engine's Namespace with the
+// provided context. The context can be shared by concurrent calls
+func (e *Engine) LoadContext(
+	ctx context.Context, src string,
+) (res ale.Value, err error) {
+	defer recoverError(&err)
+	ctx = runtime.ForkContext(ctx)
+	seq, err := read.FromString(e.ns, data.String(src))
+	if err != nil {
+		return nil, err
+	}
+	return eval.BlockContext(ctx, e.ns, seq)
+}
+
+// LoadFS reads Ale source from a file system and evaluates it in the
+// Engine's Namespace, returning the value of its last form
+func (e *Engine) LoadFS(fsys fs.FS, path string) (ale.Value, error) {
+	return e.LoadFSContext(context.Background(), fsys, path)
+}
+
+// LoadFSContext reads Ale source from a file system and evaluates it in the
+// Engine's Namespace with the provided context. The context can be shared by
+// concurrent calls
+func (e *Engine) LoadFSContext(
+	ctx context.Context, fsys fs.FS, path string,
+) (res ale.Value, err error) {
+	defer recoverError(&err)
+	ctx = runtime.ForkContext(ctx)
+	src, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	seq, err := read.FromSource(e.ns, path, data.String(src))
+	if err != nil {
+		return nil, err
+	}
+	return eval.BlockContext(ctx, e.ns, seq)
+}
+
+// Call resolves a procedure by name and calls it with Go arguments, which are
+// wrapped using ffi. An unqualified name is resolved in the Engine's
+// Namespace, while a qualified name, such as string/join, is resolved in the
+// Namespace of its domain
+func (e *Engine) Call(name string, args ...any) (ale.Value, error) {
+	return e.CallContext(context.Background(), name, args...)
+}
+
+// CallContext performs a Call with the provided context. The context can be
+// shared by concurrent calls
+func (e *Engine) CallContext(
+	ctx context.Context, name string, args ...any,
+) (res ale.Value, err error) {
+	defer recoverError(&err)
+	ctx = runtime.ForkContext(ctx)
+	fn, err := e.resolveProcedure(name)
+	if err != nil {
+		return nil, err
+	}
+	in := make(data.Vector, len(args))
+	for i, a := range args {
+		if in[i], err = wrap(a); err != nil {
+			return nil, err
+		}
+	}
+	if err := fn.CheckArity(len(in)); err != nil {
+		return nil, err
+	}
+	return runtime.Call(ctx, fn, in...), nil
+}
+
+func (e *Engine) resolveProcedure(name string) (data.Procedure, error) {
+	s, err := data.ParseSymbol(data.String(name))
+	if err != nil {
+		return nil, err
+	}
+	v, err := env.ResolveValue(e.ns, s)
+	if err != nil {
+		return nil, err
+	}
+	if fn, ok := v.(data.Procedure); ok {
+		return fn, nil
+	}
+	return nil, fmt.Errorf(ErrExpectedProcedure, data.ToString(v))
+}
+
+// Decode unwraps an Ale value into the Go value that the provided pointer
+// refers to, using ffi. Decoding into an empty interface stores the Ale value
+// as-is
+func Decode(v ale.Value, out any) (err error) {
+	defer recoverError(&err)
+	ptr := reflect.ValueOf(out)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() {
+		return fmt.Errorf(ErrExpectedPointer, out)
+	}
+	target := ptr.Elem()
+	if target.Type() == anyType {
+		target.Set(reflect.ValueOf(&v).Elem())
+		return nil
+	}
+	w, err := ffi.WrapType(target.Type())
+	if err != nil {
+		return err
+	}
+	res, err := w.Unwrap(v)
+	if err != nil {
+		return err
+	}
+	target.Set(res)
+	return nil
+}
+
+// CallAs performs a Call on the provided Engine and decodes its result into a
+// Go value of the requested type
+func CallAs[T any](e *Engine, name string, args ...any) (T, error) {
+	var res T
+	v, err := e.Call(name, args...)
+	if err != nil {
+		return res, err
+	}
+	return res, Decode(v, &res)
+}
+
+func wrap(v any) (ale.Value, error) {
+	if v, ok := v.(ale.Value); ok {
+		return v, nil
+	}
+	if v == nil {
+		return data.Null, nil
+	}
+	return ffi.Wrap(v)
+}
+
+func recoverError(err *error) {
+	if rec := recover(); rec != nil {
+		if e, ok := rec.(error); ok {
+			*err = e
+			return
+		}
+		*err = fmt.Errorf("%v", rec)
+	}
+}
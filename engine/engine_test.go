@@ -0,0 +1,217 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/core/bootstrap"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/engine"
+	"github.com/kode4food/ale/eval"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+)
+
+type point struct {
+	X int
+	Y int
+}
+
+func TestEngineLoadAndCall(t *testing.T) {
+	as := assert.New(t)
+
+	e, err := engine.New()
+	as.NoError(err)
+
+	res, err := e.Load(`
+		(define (add-all . nums) (apply + nums))
+		(add-all 1 2 3)
+	`)
+	if as.NoError(err) {
+		as.Number(6, res)
+	}
+
+	res, err = e.Call("add-all", 10, 20.5, int8(3))
+	if as.NoError(err) {
+		as.Number(33.5, res)
+	}
+
+	res, err = e.Call("user/add-all")
+	if as.NoError(err) {
+		as.Number(0, res)
+	}
+
+	s, err := engine.CallAs[string](e, "string/join", "-", []string{"a", "b"})
+	if as.NoError(err) {
+		as.Equal("a-b", s)
+	}
+
+	n, err := engine.CallAs[int](e, "add-all", 1, 2)
+	if as.NoError(err) {
+		as.Equal(3, n)
+	}
+}
+
+func TestEngineRegister(t *testing.T) {
+	as := assert.New(t)
+
+	e, err := engine.New(engine.WithDomain("registered"))
+	as.NoError(err)
+	as.Equal(data.Local("registered"), e.Namespace().Domain())
+
+	as.NoError(e.Register("greet", func(name string) string {
+		return "hello, " + name
+	}))
+	as.NoError(e.Register("origin", point{X: 1, Y: 2}))
+	as.NoError(e.Register("answer", I(42)))
+
+	res, err := e.Load(`[(greet "ale") (:X origin) answer]`)
+	if as.NoError(err) {
+		as.Equal(V(S("hello, ale"), I(1), I(42)), res)
+	}
+
+	as.NoError(e.Register("shift", func(p point, by int) point {
+		return point{X: p.X + by, Y: p.Y + by}
+	}))
+	_, err = e.Load(`(define (move p) (shift p 10))`)
+	as.NoError(err)
+	p, err := engine.CallAs[point](e, "move", point{X: 1, Y: 2})
+	if as.NoError(err) {
+		as.Equal(point{X: 11, Y: 12}, p)
+	}
+
+	err = e.Register("greet", "again")
+	as.EqualError(err, "name is already bound in namespace: greet")
+}
+
+func TestEngineLoadFS(t *testing.T) {
+	as := assert.New(t)
+
+	e, err := engine.New()
+	as.NoError(err)
+
+	fsys := fstest.MapFS{
+		"lib.ale": {Data: []byte(`(define (double x) (* x 2))`)},
+		"bad.ale": {Data: []byte(`(define (broken) (raise "boom") 1)` +
+			"\n(broken)")},
+	}
+	_, err = e.LoadFS(fsys, "lib.ale")
+	as.NoError(err)
+	res, err := e.Call("double", 21)
+	if as.NoError(err) {
+		as.Number(42, res)
+	}
+
+	_, err = e.LoadFS(fsys, "bad.ale")
+	as.ErrorContains(err, "boom")
+	as.ErrorContains(err, "at user/broken (bad.ale:1:18)")
+
+	_, err = e.LoadFS(fsys, "missing.ale")
+	as.NotNil(err)
+}
+
+func TestEngineErrors(t *testing.T) {
+	as := assert.New(t)
+
+	e, err := engine.New()
+	as.NoError(err)
+
+	_, err = e.Load(`(+ 1`)
+	as.NotNil(err)
+
+	_, err = e.Load(`(raise "boom")`)
+	as.ErrorContains(err, "boom")
+
+	_, err = e.Call("missing")
+	as.EqualError(err, "name not declared in namespace: missing")
+
+	_, err = e.Load(`(define not-a-proc 1.5)`)
+	as.NoError(err)
+	_, err = e.Call("not-a-proc")
+	as.EqualError(err, "expected procedure, got: 1.5")
+
+	_, err = e.Load(`(define (one x) x)`)
+	as.NoError(err)
+	_, err = e.Call("one", 1, 2)
+	as.ErrorContains(err, "got 2 arguments, expected 1")
+
+	_, err = engine.CallAs[int](e, "one", "not a number")
+	as.NotNil(err)
+
+	var out int
+	as.EqualError(engine.Decode(I(1), out),
+		"decode target must be a non-nil pointer, got: int",
+	)
+
+	var v any
+	as.NoError(engine.Decode(S("as-is"), &v))
+	as.Equal(S("as-is"), v.(ale.Value))
+}
+
+func TestEngineContext(t *testing.T) {
+	as := assert.New(t)
+
+	e, err := engine.New(engine.WithEnvironment(bootstrap.Sandboxed()))
+	as.NoError(err)
+
+	_, err = e.Load(`(go 1)`)
+	as.True(strings.HasPrefix(err.Error(), "capability not granted"))
+
+	_, err = e.Load(`(define (spin) (spin))`)
+	as.NoError(err)
+
+	ctx, cancel := eval.WithLimits(context.Background(), eval.Limits{
+		Timeout: 20 * time.Millisecond,
+	})
+	defer cancel()
+	_, err = e.CallContext(ctx, "spin")
+	var de *data.Error
+	as.True(errors.As(err, &de))
+	as.Equal(data.DeadlineErrorKind, de.Kind())
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = e.LoadContext(ctx, `(spin)`)
+	as.True(errors.Is(err, context.Canceled))
+
+	fsys := fstest.MapFS{"spin.ale": {Data: []byte(`(spin)`)}}
+	_, err = e.LoadFSContext(ctx, fsys, "spin.ale")
+	as.True(errors.Is(err, context.Canceled))
+}
+
+func TestEngineSharedProfiler(t *testing.T) {
+	as := assert.New(t)
+
+	e, err := engine.New()
+	as.NoError(err)
+	_, err = e.Load(`
+		(define (left n) (if (= n 0) :left (left (- n 1))))
+		(define (right n) (if (= n 0) :right (right (- n 1))))
+	`)
+	as.NoError(err)
+
+	ctx, stop := eval.WithProfiler(context.Background(), time.Millisecond)
+	var wg sync.WaitGroup
+	for _, name := range []string{"left", "right"} {
+		wg.Go(func() {
+			res, err := e.CallContext(ctx, name, 500000)
+			as.NoError(err)
+			as.Equal(K(name), res)
+		})
+	}
+	wg.Wait()
+
+	for _, s := range stop().Samples {
+		names := map[data.Local]bool{}
+		for _, f := range s.Stack {
+			names[f.Name] = true
+		}
+		as.False(names["left"] && names["right"])
+	}
+}
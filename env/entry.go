@@ -42,7 +42,7 @@ func (e *Entry) Value() (ale.Value, error) {
 	if e.bound.Load() {
 		return e.value, nil
 	}
-	return nil, fmt.Errorf(ErrNameNotBound, e.name)
+	return nil, unboundError(ErrNameNotBound, e.name)
 }
 
 func (e *Entry) Bind(v ale.Value) error {
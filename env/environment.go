@@ -3,6 +3,7 @@ package env
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
@@ -12,14 +13,17 @@ import (
 
 // Environment maintains a mapping of domain names to namespaces
 type Environment struct {
-	root Namespace
-	data map[data.Local]Namespace
+	root        Namespace
+	data        map[data.Local]Namespace
+	generation  atomic.Uint64
+	lateBinding atomic.Bool
 	sync.RWMutex
 }
 
 const (
 	ErrNamespaceNotFound = "namespace not found: %s"
 	ErrNamespaceExists   = "namespace already exists: %s"
+	ErrCannotRemoveRoot  = "root namespace can't be removed: %s"
 )
 
 // RootSymbol returns a symbol qualified by the root domain
@@ -42,12 +46,39 @@ func (e *Environment) Domains() data.Locals {
 	return append(basics.MapKeys(e.data), lang.RootDomain)
 }
 
+// LateBinding returns whether code compiled in the Environment resolves the
+// globals of its namespaces when they're referenced, rather than embedding
+// the values that they're bound to at compile time
+func (e *Environment) LateBinding() bool {
+	return e.lateBinding.Load()
+}
+
+// SetLateBinding determines whether code compiled in the Environment resolves
+// the globals of its namespaces when they're referenced. When enabled, the
+// bound entries of those namespaces can also be redefined. The built-ins of
+// the root namespace are always embedded
+func (e *Environment) SetLateBinding(late bool) {
+	e.lateBinding.Store(late)
+}
+
+// Generation returns a number that changes whenever a name is declared or
+// removed, or a namespace is created or removed, in the Environment. It's
+// used to invalidate the entries that late-bound code has resolved
+func (e *Environment) Generation() uint64 {
+	return e.generation.Load()
+}
+
+func (e *Environment) changed() {
+	e.generation.Add(1)
+}
+
 func (e *Environment) Snapshot() *Environment {
 	e.RLock()
 	defer e.RUnlock()
 	res := &Environment{
 		data: make(map[data.Local]Namespace, len(e.data)),
 	}
+	res.lateBinding.Store(e.LateBinding())
 	res.root = e.root.Snapshot(res)
 	for k, v := range e.data {
 		res.data[k] = v.Snapshot(res)
@@ -55,6 +86,27 @@ func (e *Environment) Snapshot() *Environment {
 	return res
 }
 
+// Restrict creates a snapshot of the Environment whose namespaces only
+// retain the entries that the provided function accepts. The function is
+// called with the domain of the namespace that holds each entry
+func (e *Environment) Restrict(keep func(data.Local, *Entry) bool) *Environment {
+	e.RLock()
+	defer e.RUnlock()
+	res := &Environment{
+		data: make(map[data.Local]Namespace, len(e.data)),
+	}
+	res.lateBinding.Store(e.LateBinding())
+	res.root = restrict(e.root, res, keep)
+	for k, v := range e.data {
+		if c, ok := v.(*chainedNamespace); ok {
+			res.data[k] = chain(res.root, restrict(c.Namespace, res, keep))
+			continue
+		}
+		res.data[k] = restrict(v, res, keep)
+	}
+	return res
+}
+
 // GetRoot returns the root namespace, where built-ins go
 func (e *Environment) GetRoot() Namespace {
 	return e.root
@@ -78,6 +130,47 @@ func (e *Environment) NewQualified(n data.Local) (Namespace, error) {
 	}
 	ns := chain(e.root, e.newNamespace(n))
 	e.data[n] = ns
+	e.changed()
+	return ns, nil
+}
+
+// RemoveQualified removes the namespace for the specified domain. Namespaces
+// that have imported its entries or aliased its domain are unaffected, but a
+// namespace that is later created for the domain will be resolved through
+// those aliases
+func (e *Environment) RemoveQualified(n data.Local) error {
+	if n == lang.RootDomain {
+		return fmt.Errorf(ErrCannotRemoveRoot, lang.RootDomain)
+	}
+	e.Lock()
+	defer e.Unlock()
+	if _, ok := e.data[n]; !ok {
+		return fmt.Errorf(ErrNamespaceNotFound, n)
+	}
+	delete(e.data, n)
+	e.changed()
+	return nil
+}
+
+// ReplaceQualified creates a namespace for the specified domain, and passes
+// it to the provided function to be populated. Only if the function succeeds
+// does the namespace replace any that exists for the domain, so a failure
+// leaves the Environment's namespaces as they were. The namespace resolves
+// symbols qualified by its own domain while it's being populated
+func (e *Environment) ReplaceQualified(
+	n data.Local, populate func(Namespace) error,
+) (Namespace, error) {
+	if n == lang.RootDomain {
+		return nil, fmt.Errorf(ErrCannotRemoveRoot, lang.RootDomain)
+	}
+	ns := chain(e.root, e.newNamespace(n))
+	if err := populate(ns); err != nil {
+		return nil, err
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.data[n] = ns
+	e.changed()
 	return ns, nil
 }
 
@@ -103,12 +196,19 @@ func (e *Environment) newNamespace(n data.Local) Namespace {
 }
 
 // ResolveSymbol attempts to resolve a symbol. If it's a qualified symbol, it
-// will be retrieved directly from the identified namespace. Otherwise, it will
-// be searched in the current namespace
+// will be retrieved directly from the identified namespace, which may be
+// identified by one of the current namespace's aliases. Otherwise, it will be
+// searched in the current namespace
 func ResolveSymbol(ns Namespace, s data.Symbol) (*Entry, Namespace, error) {
 	if q, ok := s.(data.Qualified); ok {
-		e := ns.Environment()
-		qns, err := e.GetQualified(q.Domain())
+		domain := q.Domain()
+		if d, ok := ns.ResolveAlias(domain); ok {
+			domain = d
+		}
+		if domain == ns.Domain() {
+			return resolvePublic(ns, ns, q.Local())
+		}
+		qns, err := ns.Environment().GetQualified(domain)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -94,3 +94,115 @@ func TestDomains(t *testing.T) {
 	slices.Sort(r)
 	as.Equal(l, r)
 }
+
+func TestRestrict(t *testing.T) {
+	as := assert.New(t)
+
+	e := env.NewEnvironment()
+	root := e.GetRoot()
+	as.NoError(env.BindPublic(root, "kept", data.True))
+	as.NoError(env.BindPublic(root, "dropped", data.True))
+	q := env.MustGetQualified(e, "some-domain")
+	as.NoError(env.BindPublic(q, "qualified", data.True))
+
+	r := e.Restrict(func(_ data.Local, e *env.Entry) bool {
+		return e.Name() != "dropped"
+	})
+	as.True(as.IsBound(r.GetRoot(), "kept"))
+	as.IsNotDeclared(r.GetRoot(), "dropped")
+	as.IsBound(e.GetRoot(), "dropped")
+
+	rq := env.MustGetQualified(r, "some-domain")
+	as.True(as.IsBound(rq, "qualified"))
+
+	_, _, err := rq.Resolve("kept")
+	as.NoError(err)
+	_, _, err = rq.Resolve("dropped")
+	as.NotNil(err)
+
+	r = e.Restrict(func(d data.Local, _ *env.Entry) bool {
+		return d != "some-domain"
+	})
+	as.IsBound(r.GetRoot(), "dropped")
+	as.IsNotDeclared(env.MustGetQualified(r, "some-domain"), "qualified")
+}
+
+func TestResolveAliased(t *testing.T) {
+	as := assert.New(t)
+
+	e := env.NewEnvironment()
+	long := env.MustGetQualified(e, "very-long-domain")
+	as.NoError(env.BindPublic(long, "fn", data.True))
+	as.NoError(env.BindPrivate(long, "hidden", data.True))
+
+	ns := env.MustGetQualified(e, "user")
+	as.NoError(ns.Alias("v", "very-long-domain"))
+	as.NoError(ns.Alias("v", "very-long-domain"))
+	as.EqualError(ns.Alias("v", "user"),
+		fmt.Sprintf(env.ErrAliasAlreadyDeclared, "v"),
+	)
+
+	d, ok := ns.ResolveAlias("v")
+	as.True(ok)
+	as.Equal(LS("very-long-domain"), d)
+	as.True(env.MustResolveValue(ns, data.NewQualifiedSymbol("fn", "v")))
+
+	_, err := env.ResolveValue(ns, data.NewQualifiedSymbol("hidden", "v"))
+	as.EqualError(err, fmt.Sprintf(env.ErrNameNotDeclared, "hidden"))
+
+	other := env.MustGetQualified(e, "other")
+	_, err = env.ResolveValue(other, data.NewQualifiedSymbol("fn", "v"))
+	as.EqualError(err, fmt.Sprintf(env.ErrNamespaceNotFound, "v"))
+
+	snap := env.MustGetQualified(e.Snapshot(), "user")
+	as.True(env.MustResolveValue(snap, data.NewQualifiedSymbol("fn", "v")))
+}
+
+func TestRemoveQualified(t *testing.T) {
+	as := assert.New(t)
+
+	e := env.NewEnvironment()
+	ns := env.MustGetQualified(e, "removed")
+	as.NoError(env.BindPublic(ns, "first", data.True))
+
+	as.NoError(e.RemoveQualified("removed"))
+	_, err := e.GetQualified("removed")
+	as.EqualError(err, fmt.Sprintf(env.ErrNamespaceNotFound, "removed"))
+	as.EqualError(e.RemoveQualified("removed"),
+		fmt.Sprintf(env.ErrNamespaceNotFound, "removed"),
+	)
+	as.EqualError(e.RemoveQualified("ale"),
+		fmt.Sprintf(env.ErrCannotRemoveRoot, "ale"),
+	)
+
+	ns = env.MustGetQualified(e, "removed")
+	as.IsNotDeclared(ns, "first")
+}
+
+func TestLateBinding(t *testing.T) {
+	as := assert.New(t)
+
+	e := env.NewEnvironment()
+	as.False(e.LateBinding())
+	e.SetLateBinding(true)
+	as.True(e.LateBinding())
+	as.True(e.Snapshot().LateBinding())
+	as.True(e.Restrict(func(data.Local, *env.Entry) bool { return true }).
+		LateBinding())
+
+	gen := e.Generation()
+	ns := env.MustGetQualified(e, "changes")
+	as.NotEqual(gen, e.Generation())
+
+	gen = e.Generation()
+	as.NoError(env.BindPublic(ns, "name", data.True))
+	as.NotEqual(gen, e.Generation())
+
+	gen = e.Generation()
+	as.NoError(ns.Unbind("name"))
+	as.NotEqual(gen, e.Generation())
+
+	gen = e.Generation()
+	as.NoError(e.RemoveQualified("changes"))
+	as.NotEqual(gen, e.Generation())
+}
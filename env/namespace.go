@@ -2,6 +2,7 @@ package env
 
 import (
 	"fmt"
+	"maps"
 	"sync"
 
 	"github.com/kode4food/ale"
@@ -21,6 +22,10 @@ type (
 		// Declared returns all declared symbols in this namespace
 		Declared() data.Locals
 
+		// Entries returns all entries in this namespace, including private
+		// entries
+		Entries() Entries
+
 		// Public declares a public symbol in this namespace
 		Public(data.Local) (*Entry, error)
 
@@ -30,22 +35,45 @@ type (
 		// Resolve attempts to resolve a symbol in this namespace or its parents
 		Resolve(data.Local) (*Entry, Namespace, error)
 
+		// Unbind replaces a declared symbol in this namespace with an unbound
+		// entry of the same privacy, so that it can be bound again. Namespaces
+		// that imported the entry retain its value
+		Unbind(data.Local) error
+
+		// Rebind replaces a declared symbol in this namespace with an entry of
+		// the same privacy that is bound to a new value, in a single step.
+		// Namespaces that imported the entry retain its value
+		Rebind(data.Local, ale.Value) error
+
+		// Undeclare removes a declared symbol from this namespace
+		Undeclare(data.Local) error
+
 		// Snapshot creates a snapshot of this namespace for another environment
 		Snapshot(*Environment) Namespace
 
 		// Import atomically adds entries from another namespace to this one
 		Import(Entries) error
+
+		// Alias allows the namespace of a domain to be referred to by another
+		// name when resolving qualified symbols in this namespace
+		Alias(alias data.Local, domain data.Local) error
+
+		// ResolveAlias returns the domain that an alias refers to
+		ResolveAlias(data.Local) (data.Local, bool)
 	}
 
 	Binder func(ns Namespace, n data.Local, v ale.Value) error
 
 	namespace struct {
 		entries     Entries
+		aliases     aliases
 		environment *Environment
 		domain      data.Local
 		sync.RWMutex
 	}
 
+	aliases map[data.Local]data.Local
+
 	Entries map[data.Local]*Entry
 )
 
@@ -57,6 +85,10 @@ const (
 	// ErrNameNotDeclared is raised when an attempt to forcefully resolve an
 	// undeclared name in the Namespace fails
 	ErrNameNotDeclared = "name not declared in namespace: %s"
+
+	// ErrAliasAlreadyDeclared is raised when an attempt is made to alias a
+	// name that already refers to a different domain
+	ErrAliasAlreadyDeclared = "alias already declared in namespace: %s"
 )
 
 func (ns *namespace) Environment() *Environment {
@@ -80,6 +112,12 @@ func (ns *namespace) Declared() data.Locals {
 	return res
 }
 
+func (ns *namespace) Entries() Entries {
+	ns.RLock()
+	defer ns.RUnlock()
+	return maps.Clone(ns.entries)
+}
+
 func (ns *namespace) Public(n data.Local) (*Entry, error) {
 	return ns.declare(n, false)
 }
@@ -103,6 +141,7 @@ func (ns *namespace) declare(n data.Local, asPrivate bool) (*Entry, error) {
 		binding: new(binding),
 	}
 	ns.entries[n] = e
+	ns.environment.changed()
 	return e, nil
 }
 
@@ -110,7 +149,7 @@ func (ns *namespace) Resolve(n data.Local) (*Entry, Namespace, error) {
 	if e, ok := ns.resolve(n); ok {
 		return e, ns, nil
 	}
-	return nil, nil, fmt.Errorf(ErrNameNotDeclared, n)
+	return nil, nil, unboundError(ErrNameNotDeclared, n)
 }
 
 func (ns *namespace) resolve(n data.Local) (*Entry, bool) {
@@ -120,16 +159,72 @@ func (ns *namespace) resolve(n data.Local) (*Entry, bool) {
 	return e, ok
 }
 
+func (ns *namespace) Unbind(n data.Local) error {
+	return ns.replace(n, new(binding))
+}
+
+func (ns *namespace) Rebind(n data.Local, v ale.Value) error {
+	b := &binding{value: v}
+	b.bound.Store(true)
+	return ns.replace(n, b)
+}
+
+func (ns *namespace) replace(n data.Local, b *binding) error {
+	ns.Lock()
+	defer ns.Unlock()
+	e, ok := ns.entries[n]
+	if !ok {
+		return unboundError(ErrNameNotDeclared, n)
+	}
+	ns.entries[n] = &Entry{
+		name:    n,
+		private: e.private,
+		binding: b,
+	}
+	ns.environment.changed()
+	return nil
+}
+
+func (ns *namespace) Undeclare(n data.Local) error {
+	ns.Lock()
+	defer ns.Unlock()
+	if _, ok := ns.entries[n]; !ok {
+		return unboundError(ErrNameNotDeclared, n)
+	}
+	delete(ns.entries, n)
+	ns.environment.changed()
+	return nil
+}
+
 func (ns *namespace) Snapshot(e *Environment) Namespace {
+	return ns.restrict(e, func(*Entry) bool { return true })
+}
+
+func restrict(
+	ns Namespace, e *Environment, keep func(data.Local, *Entry) bool,
+) Namespace {
+	n, ok := ns.(*namespace)
+	if !ok {
+		return ns.Snapshot(e)
+	}
+	return n.restrict(e, func(en *Entry) bool {
+		return keep(n.domain, en)
+	})
+}
+
+func (ns *namespace) restrict(e *Environment, keep func(*Entry) bool) Namespace {
 	ns.RLock()
 	defer ns.RUnlock()
 	res := &namespace{
 		environment: e,
 		domain:      ns.domain,
 		entries:     make(Entries, len(ns.entries)),
+		aliases:     maps.Clone(ns.aliases),
 	}
 	for k, v := range ns.entries {
-		res.entries[k] = v.snapshot()
+		if keep(v) {
+			res.entries[k] = v.snapshot()
+		}
 	}
 	return res
 }
@@ -149,9 +244,31 @@ func (ns *namespace) Import(imports Entries) error {
 		}
 		ns.entries[as] = res
 	}
+	ns.environment.changed()
 	return nil
 }
 
+func (ns *namespace) Alias(alias data.Local, domain data.Local) error {
+	ns.Lock()
+	defer ns.Unlock()
+	if d, ok := ns.aliases[alias]; ok && d != domain {
+		return fmt.Errorf(ErrAliasAlreadyDeclared, alias)
+	}
+	if ns.aliases == nil {
+		ns.aliases = aliases{}
+	}
+	ns.aliases[alias] = domain
+	ns.environment.changed()
+	return nil
+}
+
+func (ns *namespace) ResolveAlias(alias data.Local) (data.Local, bool) {
+	ns.RLock()
+	defer ns.RUnlock()
+	d, ok := ns.aliases[alias]
+	return d, ok
+}
+
 func (ns *namespace) checkDuplicates(names data.Locals) error {
 	duped := data.Locals{}
 	for _, n := range names {
@@ -173,7 +290,7 @@ func resolvePublic(
 			return e, ns, nil
 		}
 	}
-	return nil, nil, fmt.Errorf(ErrNameNotDeclared, n)
+	return nil, nil, unboundError(ErrNameNotDeclared, n)
 }
 
 func BindPublic(ns Namespace, n data.Local, v ale.Value) error {
@@ -191,3 +308,7 @@ func BindPrivate(ns Namespace, n data.Local, v ale.Value) error {
 	}
 	return e.Bind(v)
 }
+
+func unboundError(format string, n data.Local) error {
+	return data.NewError(data.UnboundErrorKind, fmt.Sprintf(format, n), n)
+}
@@ -30,6 +30,10 @@ func TestDeclarations(t *testing.T) {
 	as.Equal(LS("public1"), n[0])
 	as.Equal(LS("public2"), n[1])
 
+	entries := root.Entries()
+	as.Equal(3, len(entries))
+	as.True(entries["private"].IsPrivate())
+
 	e2, in, err := root.Resolve(n[0])
 	if as.NoError(err) && as.NotNil(e2) && as.NotNil(in) {
 		as.Equal(n[0], e2.Name())
@@ -188,3 +192,50 @@ func TestImportDuplicatesAndResolvePublic(t *testing.T) {
 		}
 	}
 }
+
+func TestUnbindAndUndeclare(t *testing.T) {
+	as := assert.New(t)
+	e := env.NewEnvironment()
+
+	src := env.MustGetQualified(e, "src")
+	as.NoError(env.BindPrivate(src, "name", I(1)))
+	pub, _, err := src.Resolve("name")
+	as.NoError(err)
+	dst := env.MustGetQualified(e, "dst")
+	as.NoError(dst.Import(env.Entries{"imported": pub}))
+
+	as.NoError(src.Unbind("name"))
+	as.IsNotBound(src, "name")
+	as.Equal(I(1), as.IsBound(dst, "imported"))
+
+	as.NoError(env.BindPrivate(src, "name", I(2)))
+	as.Equal(I(2), as.IsBound(src, "name"))
+	e1, _, err := src.Resolve("name")
+	if as.NoError(err) {
+		as.True(e1.IsPrivate())
+	}
+
+	as.NoError(src.Rebind("name", I(3)))
+	as.Equal(I(3), as.IsBound(src, "name"))
+	as.Equal(I(1), as.IsBound(dst, "imported"))
+	e2, _, err := src.Resolve("name")
+	if as.NoError(err) {
+		as.True(e2.IsPrivate())
+	}
+
+	as.NoError(src.Undeclare("name"))
+	as.IsNotDeclared(src, "name")
+	as.EqualError(src.Undeclare("name"),
+		fmt.Sprintf(env.ErrNameNotDeclared, "name"),
+	)
+	as.EqualError(src.Unbind("name"),
+		fmt.Sprintf(env.ErrNameNotDeclared, "name"),
+	)
+	as.EqualError(src.Rebind("name", I(4)),
+		fmt.Sprintf(env.ErrNameNotDeclared, "name"),
+	)
+
+	as.NoError(env.BindPublic(e.GetRoot(), "in-root", data.True))
+	as.NotNil(src.Undeclare("in-root"))
+	as.True(as.IsBound(src, "in-root"))
+}
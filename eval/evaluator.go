@@ -1,29 +1,156 @@
 package eval
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/internal/compiler/asm"
+	"github.com/kode4food/ale/internal/compiler/bytecode"
+	"github.com/kode4food/ale/internal/compiler/diagnostic"
 	"github.com/kode4food/ale/internal/compiler/encoder"
 	"github.com/kode4food/ale/internal/compiler/generate"
 	"github.com/kode4food/ale/internal/compiler/procedure"
 	"github.com/kode4food/ale/internal/runtime"
 	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/runtime/vm"
 	"github.com/kode4food/ale/read"
 )
 
+type (
+	// entries records the entries of every namespace in an Environment, and
+	// whether they were bound, so that Compile can tell which definitions
+	// compiling a form made
+	entries map[data.Local]*namespaceEntries
+
+	namespaceEntries struct {
+		ns    env.Namespace
+		bound map[*env.Entry]bool
+		names env.Entries
+	}
+
+	reporterKey struct{}
+	nativesKey  struct{}
+	formsKey    struct{}
+)
+
+// Frame describes an Ale call frame that a runtime error propagated through
+type Frame = runtime.Frame
+
+// StackTrace returns the Ale call Frames that a runtime error raised by Value
+// or Block propagated through, from innermost to outermost
+func StackTrace(err error) []*Frame {
+	return runtime.Frames(err)
+}
+
+// Limits constrain the resources that an evaluation can consume. A limit with
+// a zero value isn't enforced
+type Limits = runtime.Limits
+
+// WithLimits returns a context that applies the provided Limits to any
+// evaluation performed with it, including the goroutines that the evaluation
+// starts. Exceeding a limit raises an Error of the kind :instruction-limit-error,
+// :depth-limit-error, or :deadline-error, and exceeding the instruction or
+// time limit also cancels the rest of the evaluation
+func WithLimits(
+	ctx context.Context, limits Limits,
+) (context.Context, context.CancelFunc) {
+	return runtime.WithLimits(ctx, limits)
+}
+
+// Profile describes where an evaluation spent its time and allocated memory.
+// It can be written in the format read by the pprof tool with WritePprof, or
+// as a flat text report with WriteReport
+type Profile = runtime.Profile
+
+// ProfileSample describes how often a stack of Ale calls was found to be
+// running while an evaluation was profiled
+type ProfileSample = runtime.ProfileSample
+
+// DefaultProfilePeriod is the time between samples when a profile is taken
+// without one
+const DefaultProfilePeriod = runtime.DefaultProfilePeriod
+
+// WithProfiler returns a context that samples the Ale calls made by any
+// evaluation performed with it, including the goroutines that the evaluation
+// starts, every period. If the context is already being profiled, its Profile
+// continues to include those calls. The returned function stops the sampling
+// and returns the Profile that was gathered. Evaluations that run
+// concurrently must each be given their own context by ForkContext
+func WithProfiler(
+	ctx context.Context, period time.Duration,
+) (context.Context, func() *Profile) {
+	return runtime.WithProfiler(ctx, period)
+}
+
+// ForkContext returns a context for an evaluation that runs concurrently with
+// others that share the provided context. It shares their Limits and Profile,
+// but tracks its own call depth and profiled calls
+func ForkContext(ctx context.Context) context.Context {
+	return runtime.ForkContext(ctx)
+}
+
+// Warning describes a problem that the compiler found in a form that doesn't
+// prevent it from being evaluated
+type Warning = diagnostic.Warning
+
+// WithWarnings returns a context that passes the Warnings found when
+// compiling the forms of any evaluation performed with it to report
+func WithWarnings(ctx context.Context, report func(*Warning)) context.Context {
+	return context.WithValue(ctx, reporterKey{}, report)
+}
+
+// Natives are values implemented in Go that the forms of a Compile can bind.
+// They're written by name, so the same Natives must be provided to Load
+type Natives = bytecode.Natives
+
+// WithNatives returns a context that provides Natives to any Compile or Load
+// performed with it
+func WithNatives(ctx context.Context, n Natives) context.Context {
+	return context.WithValue(ctx, nativesKey{}, n)
+}
+
+// WithLoadedForms returns a context that passes each form that a Load
+// performed with it has to compile, because it wasn't precompiled, to report
+func WithLoadedForms(
+	ctx context.Context, report func(ale.Value),
+) context.Context {
+	return context.WithValue(ctx, formsKey{}, report)
+}
+
 // String evaluates the specified raw source
 func String(ns env.Namespace, src data.String) (ale.Value, error) {
+	return StringContext(context.Background(), ns, src)
+}
+
+// StringContext evaluates the specified raw source with the provided context
+func StringContext(
+	ctx context.Context, ns env.Namespace, src data.String,
+) (ale.Value, error) {
 	r := read.MustFromString(ns, src)
-	return Block(ns, r)
+	return BlockContext(ctx, ns, r)
 }
 
 // Block evaluates a Sequence that a call to eval.String might produce
 func Block(ns env.Namespace, s data.Sequence) (ale.Value, error) {
+	return BlockContext(context.Background(), ns, s)
+}
+
+// BlockContext evaluates a Sequence that a call to eval.String might produce
+// with the provided context
+func BlockContext(
+	ctx context.Context, ns env.Namespace, s data.Sequence,
+) (ale.Value, error) {
 	var res ale.Value
 	var err error
 	for f, r, ok := s.Split(); ok; f, r, ok = r.Split() {
-		res, err = Value(ns, f)
+		res, err = ValueContext(ctx, ns, f)
 		if err != nil {
 			return nil, err
 		}
@@ -33,21 +160,249 @@ func Block(ns env.Namespace, s data.Sequence) (ale.Value, error) {
 
 // Value evaluates the provided Value
 func Value(ns env.Namespace, v ale.Value) (ale.Value, error) {
+	return ValueContext(context.Background(), ns, v)
+}
+
+// ValueContext evaluates the provided Value with the provided context. The
+// context is observed by the evaluation and any goroutines or channels that it
+// creates. Once the context is done, a cancellation error is raised
+func ValueContext(
+	ctx context.Context, ns env.Namespace, v ale.Value,
+) (ale.Value, error) {
 	defer runtime.NormalizeGoRuntimeErrors()
-	e := encoder.NewEncoder(ns)
-	if err := generate.Value(e, v); err != nil {
+	fn, err := compile(ctx, ns, v)
+	if err != nil {
 		return nil, err
 	}
-	e.Emit(isa.Return)
-	return encodeAndRun(e)
+	return run(ctx, fn), nil
+}
+
+// Compile evaluates a Sequence that a call to eval.String might produce,
+// writing each of its compiled forms to w as precompiled bytecode that can be
+// evaluated by Load. The forms are evaluated as they're compiled because the
+// definitions and macros of one form affect how the next is compiled. Any
+// entries that compiling a form defines are written ahead of it, with their
+// values. A form that can't be serialized once compiled is written as is, and
+// compiled again when it's loaded
+func Compile(ns env.Namespace, s data.Sequence, w io.Writer) error {
+	return CompileContext(context.Background(), ns, s, w)
+}
+
+// CompileContext performs a Compile with the provided context
+func CompileContext(
+	ctx context.Context, ns env.Namespace, s data.Sequence, w io.Writer,
+) error {
+	defer runtime.NormalizeGoRuntimeErrors()
+	bw, err := bytecode.NewWriter(w, natives(ctx))
+	if err != nil {
+		return err
+	}
+	existing := ns.Environment().Domains()
+	for f, r, ok := s.Split(); ok; f, r, ok = r.Split() {
+		if err := compileForm(ctx, bw, ns, existing, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compileForm(
+	ctx context.Context, w *bytecode.Writer, ns env.Namespace,
+	existing data.Locals, form ale.Value,
+) error {
+	before := snapshot(ns)
+	fn, err := compile(ctx, ns, form)
+	if err != nil {
+		return err
+	}
+	defs, ok := before.definitions(ns, existing)
+	if !ok || w.Write(fn, defs...) != nil {
+		if err := w.WriteForm(form); err != nil {
+			return err
+		}
+	}
+	run(ctx, fn)
+	return nil
 }
 
-func encodeAndRun(e encoder.Encoder) (ale.Value, error) {
-	encoded := e.Encode()
-	fn, err := procedure.FromEncoded(encoded)
+func natives(ctx context.Context) Natives {
+	n, _ := ctx.Value(nativesKey{}).(Natives)
+	return n
+}
+
+func snapshot(ns env.Namespace) entries {
+	res := entries{}
+	add := func(ns env.Namespace) {
+		names := ns.Entries()
+		bound := make(map[*env.Entry]bool, len(names))
+		for _, e := range names {
+			bound[e] = e.IsBound()
+		}
+		res[ns.Domain()] = &namespaceEntries{
+			ns:    ns,
+			bound: bound,
+			names: names,
+		}
+	}
+	add(ns)
+	e := ns.Environment()
+	for _, d := range e.Domains() {
+		if qns, err := e.GetQualified(d); err == nil && d != ns.Domain() {
+			add(qns)
+		}
+	}
+	return res
+}
+
+// definitions returns the entries that have been declared or bound since the
+// snapshot was taken. If an entry or namespace has since been added or
+// removed, the changes can't be described as definitions. Neither can changes
+// to the existing namespaces other than the one being compiled, because a
+// Reader won't define entries in them
+func (s entries) definitions(
+	ns env.Namespace, existing data.Locals,
+) ([]*bytecode.Definition, bool) {
+	now := snapshot(ns)
+	var res []*bytecode.Definition
+	for _, d := range slices.Sorted(maps.Keys(now)) {
+		after := now[d]
+		before, ok := s[d]
+		if !ok {
+			return nil, false
+		}
+		for n := range before.names {
+			if _, ok := after.names[n]; !ok {
+				return nil, false
+			}
+		}
+		for _, n := range slices.Sorted(maps.Keys(after.names)) {
+			e := after.names[n]
+			bound, ok := before.bound[e]
+			if ok && bound == after.bound[e] {
+				continue
+			}
+			if d != ns.Domain() && slices.Contains(existing, d) {
+				return nil, false
+			}
+			v, _ := e.Value()
+			res = append(res, &bytecode.Definition{
+				Value:     v,
+				Namespace: after.ns,
+				Name:      n,
+				Private:   e.IsPrivate(),
+				Bound:     after.bound[e],
+			})
+		}
+	}
+	return res, len(s) == len(now)
+}
+
+// Disassemble evaluates a Sequence that a call to eval.String might produce,
+// writing the instructions of each of its compiled forms to w in the syntax
+// of the asm special form. Like Compile, the forms are evaluated as they're
+// compiled
+func Disassemble(ns env.Namespace, s data.Sequence, w io.Writer) error {
+	return DisassembleContext(context.Background(), ns, s, w)
+}
+
+// DisassembleContext performs a Disassemble with the provided context
+func DisassembleContext(
+	ctx context.Context, ns env.Namespace, s data.Sequence, w io.Writer,
+) error {
+	defer runtime.NormalizeGoRuntimeErrors()
+	sep := ""
+	for f, r, ok := s.Split(); ok; f, r, ok = r.Split() {
+		fn, err := compile(ctx, ns, f)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s; %s\n", sep, data.ToQuotedString(f))
+		if err != nil {
+			return err
+		}
+		sep = "\n"
+		if err := asm.Disassemble(w, fn); err != nil {
+			return err
+		}
+		run(ctx, fn)
+	}
+	return nil
+}
+
+// Load evaluates precompiled bytecode that was written by Compile, returning
+// the value of its last form. Each form is verified before it's evaluated
+func Load(ns env.Namespace, r io.Reader) (ale.Value, error) {
+	return LoadContext(context.Background(), ns, r)
+}
+
+// LoadContext performs a Load with the provided context
+func LoadContext(
+	ctx context.Context, ns env.Namespace, r io.Reader,
+) (ale.Value, error) {
+	defer runtime.NormalizeGoRuntimeErrors()
+	br, err := bytecode.NewReader(ns, r, natives(ctx))
 	if err != nil {
 		return nil, err
 	}
-	closure := fn.Call().(data.Procedure)
-	return closure.Call(), nil
+	var res ale.Value
+	for {
+		v, err := br.Read()
+		if err == io.EOF {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if d, ok := v.(*bytecode.Definition); ok {
+			if err := define(d); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		fn, ok := v.(*vm.Procedure)
+		if !ok {
+			if report, ok := ctx.Value(formsKey{}).(func(ale.Value)); ok {
+				report(v)
+			}
+			if fn, err = compile(ctx, ns, v); err != nil {
+				return nil, err
+			}
+		}
+		res = run(ctx, fn)
+	}
+}
+
+func define(d *bytecode.Definition) error {
+	declare := d.Namespace.Public
+	if d.Private {
+		declare = d.Namespace.Private
+	}
+	e, err := declare(d.Name)
+	if err != nil || !d.Bound {
+		return err
+	}
+	return e.Bind(d.Value)
+}
+
+func compile(
+	ctx context.Context, ns env.Namespace, v ale.Value,
+) (*vm.Procedure, error) {
+	e := encoder.NewEncoder(ns)
+	if err := generate.Value(e, v); err != nil {
+		return nil, err
+	}
+	generate.CheckTypes(e, 0, nil)
+	e.Emit(isa.Return)
+	enc := e.Encode()
+	if report, ok := ctx.Value(reporterKey{}).(func(*Warning)); ok {
+		for _, w := range enc.Warnings {
+			report(w)
+		}
+	}
+	return procedure.FromEncoded(enc)
+}
+
+func run(ctx context.Context, fn *vm.Procedure) ale.Value {
+	closure := fn.Call().(*vm.Closure)
+	return closure.CallContext(ctx)
 }
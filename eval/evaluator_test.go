@@ -1,7 +1,15 @@
 package eval_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/core/bootstrap"
@@ -10,6 +18,8 @@ import (
 	"github.com/kode4food/ale/eval"
 	"github.com/kode4food/ale/internal/assert"
 	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/lang/params"
+	"github.com/kode4food/ale/internal/sync"
 	"github.com/kode4food/ale/read"
 )
 
@@ -65,3 +75,661 @@ func TestBuiltIns(t *testing.T) {
 		as.String("there", res)
 	}
 }
+
+func TestLateBinding(t *testing.T) {
+	as := assert.New(t)
+
+	e := bootstrap.DevNullEnvironment()
+	e.SetLateBinding(true)
+	ns := env.MustGetQualified(e, "user")
+
+	_, err := eval.String(ns, `
+		(define (helper) :old)
+		(define (caller) (helper))
+		(define value 1)
+		(define (get-value) value)
+		(define-namespace other (define x :old))
+		(alias o other)
+		(define (get-other) o/x)
+	`)
+	as.NoError(err)
+
+	res, err := eval.String(ns, `[(caller) (get-value) (get-other)]`)
+	if as.NoError(err) {
+		as.Equal(V(K("old"), I(1), K("old")), res)
+	}
+
+	res, err = eval.String(ns, `
+		(define (helper) :new)
+		(define value 2)
+		(remove-namespace other)
+		(define-namespace other (define x :new))
+		[(caller) (get-value) (get-other)]
+	`)
+	if as.NoError(err) {
+		as.Equal(V(K("new"), I(2), K("new")), res)
+	}
+
+	as.Panics(func() {
+		_, _ = eval.String(ns, `(undeclare value) (get-value)`)
+	})
+}
+
+func TestEarlyBinding(t *testing.T) {
+	as := assert.New(t)
+
+	e := bootstrap.DevNullEnvironment()
+	ns := env.MustGetQualified(e, "user")
+
+	_, err := eval.String(ns, `
+		(define (helper) :old)
+		(define (caller) (helper))
+	`)
+	as.NoError(err)
+	as.Panics(func() {
+		_, _ = eval.String(ns, `(define (helper) :new)`)
+	})
+
+	res, err := eval.String(ns, `
+		(unbind helper)
+		(define (helper) :new)
+		[(caller) (helper)]
+	`)
+	if as.NoError(err) {
+		as.Equal(V(K("old"), K("new")), res)
+	}
+}
+
+func TestDisassemble(t *testing.T) {
+	as := assert.New(t)
+
+	e := bootstrap.DevNullEnvironment()
+	ns := env.MustGetQualified(e, "user")
+
+	var buf strings.Builder
+	as.NoError(eval.Disassemble(ns, read.MustFromString(ns, `
+		(define (double x) (* x 2))
+		(double 21)
+	`), &buf))
+	res := buf.String()
+	as.True(strings.HasPrefix(res, "; (define (double x) (* x 2))\n"))
+	as.Contains("; double, arity: 1", S(res))
+	as.Contains("\n\n; (double 21)\n", S(res))
+	as.Contains("resolve double", S(res))
+	as.Number(42, as.IsBound(ns, "double").(data.Procedure).Call(I(21)))
+
+	as.EqualError(
+		eval.Disassemble(ns, read.MustFromString(ns, `(unknown)`), &buf),
+		fmt.Sprintf(env.ErrNameNotDeclared, "unknown"),
+	)
+}
+
+func TestWarnings(t *testing.T) {
+	as := assert.New(t)
+
+	e := bootstrap.DevNullEnvironment()
+	ns := env.MustGetQualified(e, "user")
+
+	var res []string
+	ctx := eval.WithWarnings(context.Background(), func(w *eval.Warning) {
+		res = append(res, w.String())
+	})
+	src := read.MustFromSource(ns, "test.ale", `
+(define (unused x y) x)
+(let [str 1]
+  (let [str 2] str))
+(cond [false 1] [:else 2] [true 3])
+(define (ignored _x) 1)
+(define (outer x)
+  (lambda (x) x))
+(let ([a 1]
+      [b 2])
+  3)
+(define (collect . rest) rest)
+	`)
+	_, err := eval.BlockContext(ctx, ns, src)
+	as.NoError(err)
+	as.Equal([]string{
+		"test.ale:2:17: warning: parameter is never used: y",
+		"test.ale:3:6: warning: name shadows a global: str",
+		"test.ale:4:8: warning: name shadows an outer local: str",
+		"test.ale:3:6: warning: local is never used: str",
+		"test.ale:5:1: warning: cond clauses after :else are unreachable",
+		"test.ale:8:11: warning: name shadows an outer local: x",
+		"test.ale:7:16: warning: parameter is never used: x",
+		"test.ale:10:7: warning: local is never used: b",
+		"test.ale:9:6: warning: local is never used: a",
+	}, res)
+
+	_, err = eval.String(ns, `(later)`)
+	as.EqualError(err, fmt.Sprintf(env.ErrNameNotDeclared, "later"))
+
+	res = nil
+	e.SetLateBinding(true)
+	src = read.MustFromSource(ns, "late.ale", `
+(define (caller) (later 1 2))
+(define (later x) x)
+(define (bad) (later))
+(caller)
+	`)
+	as.Panics(func() { _, _ = eval.BlockContext(ctx, ns, src) })
+	as.Equal([]string{
+		"late.ale:2:18: warning: name not declared: later",
+		"late.ale:4:15: warning: call to later will fail: " +
+			fmt.Sprintf(params.ErrUnmatchedCase, 0, "1"),
+	}, res)
+}
+
+func TestTypeWarnings(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	var res []string
+	ctx := eval.WithWarnings(context.Background(), func(w *eval.Warning) {
+		res = append(res, w.String())
+	})
+	src := read.MustFromSource(ns, "types.ale", `
+(define (add [x :number] [y :number]) :- :number (+ x y))
+(define (twice [s :string]) :- :string (str s s))
+(define (bad [x :number]) :- :string x)
+(define (caller) (add 1 (twice "a")))
+(add (+ 1 2) (number? 3))
+	`)
+	as.Panics(func() { _, _ = eval.BlockContext(ctx, ns, src) })
+	as.Equal([]string{
+		"types.ale:4:1: warning: result expects string, got number",
+		"types.ale:5:18: warning: argument 2 expects number, got string",
+		"types.ale:6:1: warning: argument 2 expects number, got boolean",
+	}, res)
+}
+
+func TestRuntimeErrorLocation(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	src := data.String(`
+		(define (explode x)
+		  (raise "boom: " x))
+
+		(define (caller y)
+		  (explode y)
+		  y)
+
+		(caller 42)`,
+	)
+
+	defer func() {
+		err, ok := recover().(error)
+		as.True(ok)
+		if as.NotNil(err) {
+			as.ExpectError("boom: 42\n", err)
+			as.ErrorContains(err, "\n\tat *anon*/caller (test.ale:6:5)")
+
+			var e *data.Error
+			as.True(errors.As(err, &e))
+			as.Equal(data.ErrorKind, e.Kind())
+		}
+	}()
+
+	seq := read.MustFromSource(ns, "test.ale", src)
+	_, _ = eval.Block(ns, seq)
+}
+
+func TestStackTrace(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	src := data.String(`
+		(define (explode x)
+		  (if (= x 0)
+		      (begin (raise "boom") x)
+		      (explode (- x 1))))
+
+		(explode 3)`,
+	)
+
+	defer func() {
+		err, ok := recover().(error)
+		as.True(ok)
+		frames := eval.StackTrace(err)
+		if as.Len(frames, 2) {
+			f := frames[1]
+			as.Equal(LS("explode"), f.Name)
+			as.Equal(LS("*anon*"), f.Domain)
+			as.Equal(1, f.ArgCount)
+			if as.NotNil(f.Location) {
+				as.String("test.ale:4:16", f.Location.String())
+			}
+		}
+	}()
+
+	seq := read.MustFromSource(ns, "test.ale", src)
+	_, _ = eval.Block(ns, seq)
+}
+
+func TestDeepStackTrace(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	src := data.String(`
+		(define (deep x)
+		  (if (= x 0)
+		      (raise "boom")
+		      (+ 1 (deep (- x 1)))))
+
+		(deep 1000)`,
+	)
+
+	defer func() {
+		err, ok := recover().(error)
+		as.True(ok)
+		frames := eval.StackTrace(err)
+		as.Equal(1001, len(frames))
+		for _, f := range frames[1:] {
+			as.Equal(LS("deep"), f.Name)
+		}
+	}()
+
+	seq := read.MustFromSource(ns, "test.ale", src)
+	_, _ = eval.Block(ns, seq)
+}
+
+func TestCancelledEval(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := evalContextError(ctx, ns, "(+ 1 2)")
+	as.True(errors.Is(err, context.Canceled))
+
+	var e *data.Error
+	as.True(errors.As(err, &e))
+	as.Equal(data.CancelledErrorKind, e.Kind())
+	as.String("context canceled", e.Message())
+
+	res, err := eval.String(ns, "(+ 1 2)")
+	if as.NoError(err) {
+		as.Number(3, res)
+	}
+}
+
+func TestDeadlineEval(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	_, err := eval.String(ns, "(define (spin) (spin))")
+	as.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = evalContextError(ctx, ns, "(spin)")
+	as.True(errors.Is(err, context.DeadlineExceeded))
+
+	_, err = eval.String(ns, "(define caught (atom null))")
+	as.NoError(err)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = evalContextError(ctx, ns, `
+		(try (spin)
+		  (catch [e :cancelled-error]
+		    (reset! caught [(error-kind e) (cancelled?)])))
+		:unreachable
+	`)
+	as.True(errors.Is(err, context.DeadlineExceeded))
+
+	res, err := eval.String(ns, "(deref caught)")
+	if as.NoError(err) {
+		as.Equal(V(K("cancelled-error"), data.False), res)
+	}
+}
+
+func TestCancelledBlocking(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	_, err := eval.String(ns, "(define mailbox (chan))")
+	as.NoError(err)
+
+	for _, src := range []data.String{
+		"(first (:seq (chan)))",
+		"((:emit (chan)) 42)",
+		"(select [(:recv v (:seq mailbox)) v])",
+	} {
+		ctx, cancel := context.WithTimeout(
+			context.Background(), 20*time.Millisecond,
+		)
+		err := evalContextError(ctx, ns, src)
+		cancel()
+		as.True(errors.Is(err, context.DeadlineExceeded))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	res, err := eval.StringContext(ctx, ns, `
+		(let* ([done (first (done-chan))]
+		       [cancelled (cancelled?)])
+		  [done cancelled])
+	`)
+	if as.NoError(err) {
+		as.Equal(V(data.Null, data.True), res)
+	}
+}
+
+func TestCancelledCallbacks(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	_, err := eval.String(ns, "(define (spin) (spin))")
+	as.NoError(err)
+
+	for _, src := range []data.String{
+		"(first (lazy-seq (spin)))",
+		"(sort (lambda (l r) (spin)) [2 1])",
+		"(swap! (atom 1) (lambda (x) (spin)))",
+		"(reset! (add-watch (atom 1) :w (lambda (k a o n) (spin))) 2)",
+		`(regex/replace #"a" "abc" (lambda (m) (spin)))`,
+		"(deref (delay (spin)))",
+	} {
+		ctx, cancel := context.WithTimeout(
+			context.Background(), 20*time.Millisecond,
+		)
+		err := evalContextError(ctx, ns, src)
+		cancel()
+		as.True(errors.Is(err, context.DeadlineExceeded))
+	}
+}
+
+func TestCancelledGoroutine(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	res, err := eval.StringContext(ctx, ns, `
+		(define state (atom :running))
+		(define ready (chan))
+		(go (try
+		      ((:emit ready) true)
+		      (first (:seq (chan)))
+		      (catch [e :cancelled-error] (reset! state :cancelled))))
+		(first (:seq ready))
+		state
+	`)
+	as.NoError(err)
+	cancel()
+
+	state := res.(*sync.Atom)
+	as.Eventually(func() bool {
+		return state.Deref().Equal(K("cancelled"))
+	}, time.Second, time.Millisecond)
+}
+
+func TestClosureOutlivesContext(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	ctx, cancel := eval.WithLimits(context.Background(), eval.Limits{
+		MaxInstructions: 1000,
+	})
+	res, err := eval.StringContext(ctx, ns, `
+		(let [base 1]
+		  (lambda (n) (fold-left + base (range n))))
+	`)
+	cancel()
+	if as.NoError(err) {
+		as.Number(4951, res.(data.Procedure).Call(I(100)))
+		as.Number(49995001, res.(data.Procedure).Call(I(10000)))
+	}
+}
+
+func evalContextError(
+	ctx context.Context, ns env.Namespace, src data.String,
+) (err error) {
+	defer func() {
+		err, _ = recover().(error)
+	}()
+	_, err = eval.StringContext(ctx, ns, src)
+	return
+}
+
+func TestInstructionLimit(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	ctx, cancel := eval.WithLimits(context.Background(), eval.Limits{
+		MaxInstructions: 10000,
+	})
+	defer cancel()
+
+	res, err := eval.StringContext(ctx, ns, "(+ 1 2)")
+	if as.NoError(err) {
+		as.Number(3, res)
+	}
+
+	err = evalContextError(ctx, ns, "((lambda-rec spin () (spin)))")
+	var e *data.Error
+	as.True(errors.As(err, &e))
+	as.Equal(data.InstructionLimitErrorKind, e.Kind())
+	as.String("instruction limit exceeded: 10000", e.Message())
+
+	err = evalContextError(ctx, ns, "(+ 1 2)")
+	as.True(errors.As(err, &e))
+	as.Equal(data.InstructionLimitErrorKind, e.Kind())
+}
+
+func TestDepthLimit(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	_, err := eval.String(ns, `
+		(define (deep n)
+		  (if (= n 0) 0 (+ 1 (deep (- n 1)))))
+	`)
+	as.NoError(err)
+
+	ctx, cancel := eval.WithLimits(context.Background(), eval.Limits{
+		MaxDepth: 100,
+	})
+	defer cancel()
+
+	res, err := eval.StringContext(ctx, ns, "(deep 50)")
+	if as.NoError(err) {
+		as.Number(50, res)
+	}
+
+	err = evalContextError(ctx, ns, "(deep 1000000)")
+	var e *data.Error
+	as.True(errors.As(err, &e))
+	as.Equal(data.DepthLimitErrorKind, e.Kind())
+	as.String("call depth limit exceeded: 100", e.Message())
+
+	res, err = eval.StringContext(ctx, ns, `
+		(try (deep 1000)
+		  (catch [e :depth-limit-error] :too-deep))
+	`)
+	if as.NoError(err) {
+		as.Equal(K("too-deep"), res)
+	}
+
+	res, err = eval.StringContext(ctx, ns, "(deep 50)")
+	if as.NoError(err) {
+		as.Number(50, res)
+	}
+}
+
+func TestTimeoutLimit(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	ctx, cancel := eval.WithLimits(context.Background(), eval.Limits{
+		Timeout: 20 * time.Millisecond,
+	})
+	defer cancel()
+
+	err := evalContextError(ctx, ns, `
+		(go ((lambda-rec spin () (spin))))
+		(first (:seq (chan)))
+	`)
+	var e *data.Error
+	as.True(errors.As(err, &e))
+	as.Equal(data.DeadlineErrorKind, e.Kind())
+	as.String("evaluation deadline exceeded: 20ms", e.Message())
+	as.True(errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestCallbackLimits(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	for _, src := range []data.String{
+		"(first (lazy-seq ((lambda-rec f () (f)))))",
+		"(sort (lambda (a b) ((lambda-rec f () (f)))) [2 1])",
+		"(swap! (atom 1) (lambda (x) ((lambda-rec f () (f)))))",
+	} {
+		ctx, cancel := eval.WithLimits(context.Background(), eval.Limits{
+			MaxInstructions: 100000,
+		})
+		err := evalContextError(ctx, ns, src)
+		cancel()
+		var e *data.Error
+		as.True(errors.As(err, &e))
+		as.Equal(data.InstructionLimitErrorKind, e.Kind())
+	}
+
+	_, err := eval.String(ns, `
+		(define unsorted (seq->vector (range 2000 0 -1)))
+	`)
+	as.NoError(err)
+
+	ctx, cancel := eval.WithLimits(context.Background(), eval.Limits{
+		MaxInstructions: 1000,
+	})
+	err = evalContextError(ctx, ns, "(sort (lambda (l r) (- l r)) unsorted)")
+	cancel()
+	var e *data.Error
+	as.True(errors.As(err, &e))
+	as.Equal(data.InstructionLimitErrorKind, e.Kind())
+
+	ctx, cancel = eval.WithLimits(context.Background(), eval.Limits{
+		MaxInstructions: 100000,
+	})
+	err = evalContextError(ctx, ns, `
+		(try ((lambda-rec f () (f)))
+		  (catch [e :instruction-limit-error] :caught))
+		:unreachable
+	`)
+	cancel()
+	as.True(errors.As(err, &e))
+	as.Equal(data.InstructionLimitErrorKind, e.Kind())
+
+	_, err = eval.String(ns, `
+		(define (deep n)
+		  (if (= n 0) 0 (+ 1 (deep (- n 1)))))
+	`)
+	as.NoError(err)
+
+	ctx, cancel = eval.WithLimits(context.Background(), eval.Limits{
+		MaxDepth: 100,
+	})
+	defer cancel()
+	err = evalContextError(ctx, ns, "(swap! (atom 1000) deep)")
+	as.True(errors.As(err, &e))
+	as.Equal(data.DepthLimitErrorKind, e.Kind())
+}
+
+func TestProfiler(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	_, err := eval.String(ns, `
+		(define (busy n)
+		  (if (= n 0) :done (busy (- n 1))))
+	`)
+	as.NoError(err)
+
+	ctx, stop := eval.WithProfiler(context.Background(), time.Millisecond)
+	res, err := eval.StringContext(ctx, ns, "(busy 500000)")
+	if as.NoError(err) {
+		as.Equal(K("done"), res)
+	}
+
+	p := stop()
+	as.Equal(p, stop())
+	as.Equal(time.Millisecond, p.Period)
+	if as.NotEmpty(p.Samples) {
+		var names []string
+		for _, s := range p.Samples {
+			as.True(s.Count > 0)
+			for _, f := range s.Stack {
+				names = append(names, string(f.Name))
+			}
+		}
+		as.Contains("busy", S(strings.Join(names, " ")))
+	}
+
+	var report strings.Builder
+	as.NoError(p.WriteReport(&report))
+	as.Contains("self%", S(report.String()))
+	as.Contains("/busy (", S(report.String()))
+
+	var buf bytes.Buffer
+	as.NoError(p.WritePprof(&buf))
+	r, err := gzip.NewReader(&buf)
+	if as.NoError(err) {
+		b, err := io.ReadAll(r)
+		as.NoError(err)
+		as.Contains("alloc_space", S(string(b)))
+		as.Contains("busy", S(string(b)))
+	}
+}
+
+func TestProfilerCallbacks(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	_, err := eval.String(ns, `
+		(define (busy n)
+		  (if (= n 0) :done (busy (- n 1))))
+	`)
+	as.NoError(err)
+
+	ctx, stop := eval.WithProfiler(context.Background(), time.Millisecond)
+	res, err := eval.StringContext(ctx, ns, `
+		(swap! (atom 0) (lambda (x) (busy 500000)))
+	`)
+	if as.NoError(err) {
+		as.Equal(K("done"), res)
+	}
+
+	var report strings.Builder
+	as.NoError(stop().WriteReport(&report))
+	as.Contains("/busy (", S(report.String()))
+}
+
+func TestNestedProfilers(t *testing.T) {
+	as := assert.New(t)
+
+	ns := bootstrap.DevNullEnvironment().GetAnonymous()
+	_, err := eval.String(ns, `
+		(define (busy n)
+		  (if (= n 0) :done (busy (- n 1))))
+	`)
+	as.NoError(err)
+
+	ctx, stop := eval.WithProfiler(context.Background(), time.Millisecond)
+	res, err := eval.StringContext(ctx, ns, `
+		(let [inner (%profile (lambda () (busy 500000)))]
+		  [(:result inner) (:report inner)])
+	`)
+	if as.NoError(err) {
+		as.Equal(K("done"), res.(data.Vector)[0])
+		as.Contains("/busy (", res.(data.Vector)[1])
+	}
+
+	var report strings.Builder
+	as.NoError(stop().WriteReport(&report))
+	as.Contains("/busy (", S(report.String()))
+}
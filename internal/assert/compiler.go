@@ -1,6 +1,8 @@
 package assert
 
 import (
+	"slices"
+
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
@@ -10,14 +12,18 @@ import (
 	"github.com/kode4food/ale/read"
 )
 
-// MustEncodedAs tests that a string generates the expected set of Instructions
+// MustEncodedAs tests that a string generates the expected set of
+// Instructions. Source positions are not considered
 func (w *Wrapper) MustEncodedAs(expected isa.Instructions, src data.String) {
 	e := GetTestEncoder()
 	v := read.MustFromString(e.Globals(), src)
 	if err := generate.Block(e, v); err != nil {
 		panic(err)
 	}
-	w.Instructions(expected, e.Encode().Code)
+	code := slices.DeleteFunc(e.Encode().Code, func(i isa.Instruction) bool {
+		return i.Opcode() == isa.Pos
+	})
+	w.Instructions(expected, code)
 }
 
 // Instructions test that two sets of Instructions are identical
@@ -0,0 +1,287 @@
+package asm
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	lang "github.com/kode4food/ale/internal/lang/env"
+	"github.com/kode4food/ale/internal/lang/params"
+	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/runtime/vm"
+	str "github.com/kode4food/ale/internal/strings"
+)
+
+type disassembler struct {
+	lines []string
+}
+
+// ErrExpectedProcedure is raised when an attempt is made to disassemble a
+// value that isn't a procedure compiled for the abstract machine
+const ErrExpectedProcedure = "expected compiled procedure, got: %s"
+
+const indentation = "  "
+
+var callOperands = map[isa.Opcode]isa.Operand{
+	isa.Call0: 0,
+	isa.Call1: 1,
+	isa.Call2: 2,
+	isa.Call3: 3,
+}
+
+// Disassemble writes the instructions of a compiled procedure in the syntax
+// of the asm special form. The instructions are wrapped in a lambda that
+// accepts the procedure's arguments, and any procedures that it refers to
+// are disassembled in place. If the procedure captures values, the lambda is
+// wrapped in another that accepts them as its closure slots. Every path
+// through a compiled procedure exits it, so each block ends with an
+// unreachable null that leaves the stack as its enclosing lambda expects
+func Disassemble(w io.Writer, v ale.Value) error {
+	d := new(disassembler)
+	switch v := v.(type) {
+	case *vm.Closure:
+		d.closure(v)
+	case *vm.Procedure:
+		d.procedure(v, "", 0)
+	default:
+		return fmt.Errorf(ErrExpectedProcedure, data.ToString(v))
+	}
+	_, err := io.WriteString(w, strings.Join(d.lines, "\n")+"\n")
+	return err
+}
+
+func (d *disassembler) closure(c *vm.Closure) {
+	for i, v := range c.Captured() {
+		d.line(0, "; %s: %s", closureName(i), data.ToQuotedString(v))
+	}
+	d.procedure(c.Procedure, "", 0)
+}
+
+func (d *disassembler) procedure(p *vm.Procedure, prefix string, depth int) {
+	closing := "))"
+	if n := capturedCount(p.Code); n > 0 {
+		names := make([]string, n)
+		for i := range n {
+			names[i] = closureName(i)
+		}
+		d.line(depth, "%s(lambda (%s)", prefix, strings.Join(names, " "))
+		prefix = ""
+		closing += ")"
+		depth++
+	}
+	d.line(depth, "%s(lambda %s", prefix, formatParams(p.Arity))
+	d.line(depth+1, "; %s", describe(p))
+	d.line(depth+1, "(asm")
+	body := depth + 2
+	for i := range p.LocalCount {
+		d.line(body, "local %s :val", localName(i))
+	}
+	labels := makeLabels(p.Code)
+	for pc, inst := range p.Code {
+		if l, ok := labels[pc]; ok {
+			d.line(body-1, " %s", l)
+		}
+		d.instruction(p, inst, labels, body)
+	}
+	if l, ok := labels[len(p.Code)]; ok {
+		d.line(body-1, " %s", l)
+	}
+	d.line(body, "null%s", closing)
+}
+
+func (d *disassembler) instruction(
+	p *vm.Procedure, inst isa.Instruction, labels map[int]string, depth int,
+) {
+	oc, op := inst.Split()
+	if argc, ok := callOperands[oc]; ok {
+		d.line(depth, "call %d", argc)
+		return
+	}
+	switch oc {
+	case isa.Label, isa.NoOp, isa.Pos:
+		return
+	case isa.Const:
+		d.constant(p, p.Constants[op], depth)
+		return
+	case isa.Closure:
+		d.line(depth, "resolve %s", closureName(int(op)))
+		return
+	}
+	name := str.CamelToSnake(oc.String())
+	switch isa.Effects[oc].Operand {
+	case isa.Nothing:
+		d.line(depth, "%s", name)
+	case isa.Labels:
+		d.line(depth, "%s %s", name, labels[int(op)])
+	case isa.Locals:
+		d.line(depth, "%s %s", name, localName(op))
+	default:
+		d.line(depth, "%s %d", name, op)
+	}
+}
+
+func (d *disassembler) constant(p *vm.Procedure, v ale.Value, depth int) {
+	if n, ok := globalName(p.Globals, v); ok {
+		d.line(depth, "resolve %s", n)
+		return
+	}
+	switch v := v.(type) {
+	case *vm.Closure:
+		if len(v.Captured()) == 0 {
+			d.procedure(v.Procedure, "eval ", depth)
+			return
+		}
+	case *vm.Procedure:
+		d.procedure(v, "eval ", depth)
+		return
+	case *data.Error:
+		d.line(depth, "eval %s", formatError(v))
+		return
+	}
+	d.line(depth, "const %s", data.ToQuotedString(v))
+}
+
+func (d *disassembler) line(depth int, format string, args ...any) {
+	indent := strings.Repeat(indentation, depth)
+	d.lines = append(d.lines, indent+fmt.Sprintf(format, args...))
+}
+
+func describe(p *vm.Procedure) string {
+	res := fmt.Sprintf(
+		"stack size: %d, locals: %d", p.StackSize, p.LocalCount,
+	)
+	if p.Arity != nil {
+		res = fmt.Sprintf("arity: %s, %s", formatArity(p.Arity), res)
+	}
+	if p.Name != "" {
+		res = fmt.Sprintf("%s, %s", p.Name, res)
+	}
+	return res
+}
+
+func formatError(e *data.Error) string {
+	res := fmt.Sprintf("(%s %s %s",
+		env.RootSymbol(lang.Error), e.Kind(),
+		data.ToQuotedString(data.String(e.Message())),
+	)
+	if d := e.Data(); !data.Null.Equal(d) {
+		res += fmt.Sprintf(" (quote %s)", data.ToQuotedString(d))
+	}
+	return res + ")"
+}
+
+func globalName(ns env.Namespace, v ale.Value) (data.Symbol, bool) {
+	if ns == nil {
+		return nil, false
+	}
+	if c, ok := v.(*vm.Closure); ok && c.Name != "" {
+		if e, _, err := ns.Resolve(c.Name); err == nil {
+			if b, err := e.Value(); err == nil && v.Equal(b) {
+				return c.Name, true
+			}
+		}
+	}
+	if _, ok := v.(data.Procedure); !ok {
+		return nil, false
+	}
+	root := ns.Environment().GetRoot()
+	for _, in := range []env.Namespace{ns, root} {
+		entries := in.Entries()
+		// A value can be bound to more than one name, so the names are
+		// searched in order to keep the disassembly stable
+		for _, n := range slices.Sorted(maps.Keys(entries)) {
+			if b, err := entries[n].Value(); err == nil && v.Equal(b) {
+				if in == root {
+					return env.RootSymbol(n), true
+				}
+				return n, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func makeLabels(code isa.Instructions) map[int]string {
+	res := map[int]string{}
+	for _, inst := range code {
+		oc, op := inst.Split()
+		if isa.Effects[oc].Operand != isa.Labels {
+			continue
+		}
+		if _, ok := res[int(op)]; !ok {
+			res[int(op)] = fmt.Sprintf(":label-%d", len(res))
+		}
+	}
+	return res
+}
+
+func capturedCount(code isa.Instructions) int {
+	res := 0
+	for _, inst := range code {
+		if oc, op := inst.Split(); oc == isa.Closure {
+			res = max(res, int(op)+1)
+		}
+	}
+	return res
+}
+
+func formatParams(a *params.Arity) string {
+	if a == nil {
+		return "args"
+	}
+	fixed := fixedCounts(a)
+	switch {
+	case !a.HasRest && len(fixed) == 1:
+		return "(" + strings.Join(argNames(fixed[0]), " ") + ")"
+	case a.HasRest && len(fixed) == 0 && a.LowRest == 0:
+		return "rest"
+	case a.HasRest && len(fixed) == 0:
+		return "(" + strings.Join(argNames(a.LowRest), " ") + " . rest)"
+	default:
+		return "args"
+	}
+}
+
+func formatArity(a *params.Arity) string {
+	var res []string
+	for _, n := range fixedCounts(a) {
+		res = append(res, fmt.Sprint(n))
+	}
+	if a.HasRest {
+		res = append(res, fmt.Sprintf("%d or more", a.LowRest))
+	}
+	return strings.Join(res, ", ")
+}
+
+func fixedCounts(a *params.Arity) []int {
+	var res []int
+	for i, bits := range a.Fixed {
+		for b := range 8 {
+			if bits&(1<<b) != 0 {
+				res = append(res, i*8+b)
+			}
+		}
+	}
+	return res
+}
+
+func argNames(n int) []string {
+	res := make([]string, n)
+	for i := range n {
+		res[i] = fmt.Sprintf("arg-%d", i)
+	}
+	return res
+}
+
+func localName(i isa.Operand) string {
+	return fmt.Sprintf("local-%d", i)
+}
+
+func closureName(i int) string {
+	return fmt.Sprintf("closure-%d", i)
+}
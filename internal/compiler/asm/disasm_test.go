@@ -0,0 +1,122 @@
+package asm_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/compiler/asm"
+)
+
+func TestDisassemble(t *testing.T) {
+	as := assert.New(t)
+
+	var buf strings.Builder
+	fn := as.MustEval(`(lambda (x) (if (> x 1) :big "small"))`)
+	as.NoError(asm.Disassemble(&buf, fn))
+	as.String(strings.Join([]string{
+		`(lambda (arg-0)`,
+		`  ; arity: 1, stack size: 3, locals: 0`,
+		`  (asm`,
+		`    args-len`,
+		`    pos-int 1`,
+		`    num-eq`,
+		`    cond-jump :label-0`,
+		`    eval (ale/error :arity-error "no matching parameter pattern")`,
+		`    panic`,
+		`    jump :label-1`,
+		`   :label-0`,
+		`    pos-int 1`,
+		`    arg 0`,
+		`    resolve >`,
+		`    call 2`,
+		`    cond-jump :label-2`,
+		`    const "small"`,
+		`    return`,
+		`    jump :label-1`,
+		`   :label-2`,
+		`    const :big`,
+		`    return`,
+		`   :label-1`,
+		`    null))`,
+		``,
+	}, "\n"), buf.String())
+}
+
+func TestDisassembleClosure(t *testing.T) {
+	as := assert.New(t)
+
+	var buf strings.Builder
+	fn := as.MustEval(`((lambda (x) (lambda (y) (+ x y))) 42)`)
+	as.NoError(asm.Disassemble(&buf, fn))
+	res := buf.String()
+	as.True(strings.HasPrefix(res, "; closure-0: 42\n(lambda (closure-0)\n"))
+	as.Contains("resolve closure-0", S(res))
+}
+
+func TestDisassembleRoundTrip(t *testing.T) {
+	as := assert.New(t)
+	as.MustEvalTo(`
+		(define (fact n)
+			(if (<= n 1) 1 (* n (fact (- n 1)))))
+		(define (sum n acc)
+			(if (= n 0) acc (sum (- n 1) (+ acc n))))
+		(define (adder x) (lambda (y) (+ x y)))
+		(define (scale x . r)
+			(let [m (* x 2)] (map (lambda (y) (* y m)) r)))
+		(define multi
+			(lambda [(x) x] [(x y) (+ x y)] [(x y . z) (apply + x y z)]))
+		(define (constants) [1 :a "s" 'sym '(1 2)])
+
+		(define (reassemble f) (eval (read (disasm f))))
+		[((reassemble fact) 10)
+		 ((reassemble sum) 10000 0)
+		 (((reassemble (adder 3)) 3) 4)
+		 (seq->vector ((reassemble scale) 3 1 2))
+		 ((reassemble multi) 1)
+		 ((reassemble multi) 1 2)
+		 ((reassemble multi) 1 2 3 4)
+		 ((reassemble constants))]
+	`, V(
+		I(3628800), I(50005000), I(7), V(I(6), I(12)),
+		I(1), I(3), I(10),
+		V(I(1), K("a"), S("s"), LS("sym"), L(I(1), I(2))),
+	))
+}
+
+func TestDisassembleErrors(t *testing.T) {
+	as := assert.New(t)
+
+	var buf strings.Builder
+	as.EqualError(
+		asm.Disassemble(&buf, I(10)),
+		fmt.Sprintf(asm.ErrExpectedProcedure, "10"),
+	)
+	as.EqualError(
+		asm.Disassemble(&buf, K("first")),
+		fmt.Sprintf(asm.ErrExpectedProcedure, ":first"),
+	)
+}
+
+func TestDisassembleAliases(t *testing.T) {
+	as := assert.New(t)
+
+	fn := as.MustEval(`
+		(lambda (x)
+			[(first x) (rest x) (reduce + x) (modulo 7 2)])
+	`)
+	var first strings.Builder
+	as.NoError(asm.Disassemble(&first, fn))
+	res := S(first.String())
+	as.Contains("resolve ale/car", res)
+	as.Contains("resolve ale/cdr", res)
+	as.Contains("resolve fold-left", res)
+	as.Contains("resolve mod", res)
+	for range 20 {
+		var buf strings.Builder
+		as.NoError(asm.Disassemble(&buf, fn))
+		as.String(first.String(), buf.String())
+	}
+}
@@ -12,8 +12,7 @@ import (
 )
 
 var asmEffects = excludeEffects([]isa.Opcode{
-	isa.Call0, isa.Call1, isa.Call2, isa.Call3, isa.CallSelf, isa.Const,
-	isa.Label, isa.TailCall, isa.TailClos, isa.TailSelf,
+	isa.Call0, isa.Call1, isa.Call2, isa.Call3, isa.Const, isa.Label, isa.Pos,
 })
 
 func getInstructionCalls() namedAsmParsers {
@@ -1,9 +1,8 @@
 package asm
 
 import (
-	"errors"
-
 	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/compiler"
 	"github.com/kode4food/ale/internal/compiler/encoder"
 	"github.com/kode4food/ale/internal/lang/params"
@@ -17,39 +16,62 @@ const (
 	ErrUnexpectedParameter = "unexpected parameter name: %s"
 )
 
-func MakeSpecial(pc *params.ParamCases) EmitBuilder {
+// NewSpecial assembles a special form from the provided param cases
+func NewSpecial(cases data.Vector) (*compiler.Special, error) {
+	return makeSpecial(makeAsmParser(getCalls()), cases)
+}
+
+// MakeSpecial returns an EmitBuilder that assembles a special form from the
+// provided param cases, and emits it as a constant
+func MakeSpecial(cases data.Vector) EmitBuilder {
 	return func(p *Parser) (Emit, error) {
-		cases := pc.Cases
-		ap := make([]*Parser, len(cases))
-		emitters := make([]Emit, len(cases))
-		for i, c := range cases {
-			ap[i] = p.withParams(c.Params)
-			e, err := ap[i].sequence(c.Body)
-			if err != nil {
-				return nil, err
-			}
-			emitters[i] = e
+		s, err := makeSpecial(p, cases)
+		if err != nil {
+			return nil, err
 		}
+		return func(e *Encoder) error {
+			e.Emit(isa.Const, e.AddConstant(s))
+			return nil
+		}, nil
+	}
+}
 
-		ac := pc.MakeArityChecker()
-		fetchers := pc.MakeArgFetchers()
+func makeSpecial(p *Parser, cases data.Vector) (*compiler.Special, error) {
+	pc, err := params.ParseCases(cases)
+	if err != nil {
+		return nil, err
+	}
+	ap := make([]*Parser, len(pc.Cases))
+	emitters := make([]Emit, len(pc.Cases))
+	for i, c := range pc.Cases {
+		ap[i] = p.withParams(c.Params)
+		e, err := ap[i].sequence(c.Body)
+		if err != nil {
+			return nil, err
+		}
+		emitters[i] = e
+	}
 
-		fn := func(e encoder.Encoder, args ...ale.Value) error {
-			if err := ac(len(args)); err != nil {
-				return err
-			}
-			for i, f := range fetchers {
-				if a, ok := f(args); ok {
-					ae := ap[i].wrapEncoder(e, a...)
-					return emitters[i](ae)
-				}
+	ac := pc.MakeArityChecker()
+	fetchers := pc.MakeArgFetchers()
+
+	fn := func(e encoder.Encoder, args ...ale.Value) error {
+		if err := ac(len(args)); err != nil {
+			return err
+		}
+		for i, f := range fetchers {
+			if a, ok := f(args); ok {
+				ae := ap[i].wrapEncoder(e, a...)
+				return emitters[i](ae)
 			}
-			return errors.New(params.ErrNoMatchingParamPattern)
 		}
-
-		return func(e *Encoder) error {
-			e.Emit(isa.Const, e.AddConstant(compiler.Call(fn)))
-			return nil
-		}, nil
+		return data.NewError(
+			data.ArityErrorKind, params.ErrNoMatchingParamPattern,
+			data.Null,
+		)
 	}
+	return &compiler.Special{
+		Call:  fn,
+		Cases: cases,
+	}, nil
 }
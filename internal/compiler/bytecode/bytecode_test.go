@@ -0,0 +1,423 @@
+package bytecode_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/core/bootstrap"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/eval"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/compiler/bytecode"
+	"github.com/kode4food/ale/internal/compiler/ir/analysis"
+	"github.com/kode4food/ale/internal/lang/params"
+	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/runtime/vm"
+	"github.com/kode4food/ale/read"
+)
+
+const script = `
+	(define big 123456789012345678901234567890)
+	(define (scale x) (* x 1/2 0.5))
+	(define shapes {:square [4 "four"] :tri '(3 three) :set #{1 2}})
+	(define (adder n) (lambda (x) (+ x n)))
+	(define add10 (adder 10))
+	(define-lambda arity
+	  [() 0]
+	  [(x) 1]
+	  [(x y z) 3]
+	  [(x y z . r) :more])
+	(define (fail) (raise "boom"))
+	[(add10 5) (scale 8) (arity) (arity 1 2 3 4) (:tri shapes) big]
+`
+
+func compileScript(t *testing.T, src string) []byte {
+	t.Helper()
+	as := assert.New(t)
+	ns := makeNamespace()
+	var buf bytes.Buffer
+	seq := read.MustFromSource(ns, "script.ale", data.String(src))
+	as.NoError(eval.Compile(ns, seq, &buf))
+	return buf.Bytes()
+}
+
+func makeNamespace() env.Namespace {
+	e := bootstrap.DevNullEnvironment()
+	return env.MustGetQualified(e, "user")
+}
+
+func TestRoundTrip(t *testing.T) {
+	as := assert.New(t)
+	code := compileScript(t, script)
+	as.True(bytecode.IsPrecompiled(code))
+
+	ns := makeNamespace()
+	res, err := eval.Load(ns, bytes.NewReader(code))
+	if as.NoError(err) {
+		as.String(
+			`[15 2.0 0 :more (3 three) 123456789012345678901234567890]`,
+			res,
+		)
+	}
+
+	arity := env.MustResolveValue(ns, LS("arity")).(data.Procedure)
+	as.NoError(arity.CheckArity(1))
+	as.ErrorContains(arity.CheckArity(2), "got 2 arguments, expected 0-1")
+
+	fail := env.MustResolveValue(ns, LS("fail")).(data.Procedure)
+	as.Panics(func() { fail.Call() })
+}
+
+func TestGlobalReferences(t *testing.T) {
+	as := assert.New(t)
+	code := compileScript(t, `
+		(define (helper) :first)
+		(define (caller) helper)
+	`)
+
+	ns := makeNamespace()
+	_, err := eval.Load(ns, bytes.NewReader(code))
+	as.NoError(err)
+	helper := env.MustResolveValue(ns, LS("helper"))
+	caller := env.MustResolveValue(ns, LS("caller")).(data.Procedure)
+	as.Identical(helper, caller.Call())
+}
+
+func TestReproducible(t *testing.T) {
+	as := assert.New(t)
+	src := "(define-macro (twice x) `(let [v# ,x] (+ v# v#)))\n" +
+		"(define shapes {:square 4 :tri 3 :set #{1 2 3} :quad 4})\n" +
+		"[(twice 4) (twice 5) (:set shapes)]"
+	code := compileScript(t, src)
+	as.Equal(code, compileScript(t, src))
+
+	res, err := eval.Load(makeNamespace(), bytes.NewReader(code))
+	if as.NoError(err) {
+		as.String(`[8 10 #{1 2 3}]`, res)
+	}
+}
+
+func TestCoreProcedures(t *testing.T) {
+	as := assert.New(t)
+	ns := makeNamespace()
+	root := ns.Environment().GetRoot()
+	for n, e := range root.Entries() {
+		v, _ := e.Value()
+		c, ok := v.(*vm.Closure)
+		if !ok {
+			continue
+		}
+		var buf bytes.Buffer
+		w, err := bytecode.NewWriter(&buf, nil)
+		as.NoError(err)
+		if !as.NoError(w.Write(c.Procedure), string(n)) {
+			continue
+		}
+		r, err := bytecode.NewReader(root, &buf, nil)
+		as.NoError(err)
+		u, err := r.Read()
+		if as.NoError(err, string(n)) {
+			p := u.(*vm.Procedure)
+			as.Equal(c.Code, p.Code)
+			as.Equal(c.StackSize, p.StackSize)
+			as.Equal(c.LocalCount, p.LocalCount)
+		}
+		_, err = r.Read()
+		as.Equal(io.EOF, err)
+	}
+}
+
+func TestUnsupportedConstant(t *testing.T) {
+	as := assert.New(t)
+	ns := makeNamespace()
+	unbound := data.MakeProcedure(func(...ale.Value) ale.Value {
+		return data.Null
+	})
+	fn := &vm.Procedure{
+		Runnable: isa.Runnable{
+			Globals:   ns,
+			Code:      isa.Instructions{isa.Const.New(0), isa.Return.New()},
+			Constants: data.Vector{unbound},
+			StackSize: 1,
+		},
+	}
+	w, err := bytecode.NewWriter(io.Discard, nil)
+	as.NoError(err)
+	as.EqualError(w.Write(fn),
+		fmt.Sprintf(bytecode.ErrUnsupportedConstant, data.ToString(unbound)),
+	)
+}
+
+func TestBadHeader(t *testing.T) {
+	as := assert.New(t)
+	ns := makeNamespace()
+
+	_, err := eval.Load(ns, bytes.NewReader([]byte("(+ 1 2)")))
+	as.EqualError(err, bytecode.ErrBadMagic)
+
+	code := compileScript(t, `1`)
+	code[len(bytecode.Magic)] = bytecode.Version + 1
+	_, err = eval.Load(ns, bytes.NewReader(code))
+	as.EqualError(err, fmt.Sprintf(
+		bytecode.ErrUnsupportedVersion, bytecode.Version+1, bytecode.Version,
+	))
+}
+
+func TestTruncated(t *testing.T) {
+	as := assert.New(t)
+	code := compileScript(t, `(define (inc-all x) (map inc x))`)
+	for i := len(bytecode.Magic) + 2; i < len(code); i++ {
+		_, err := eval.Load(makeNamespace(), bytes.NewReader(code[:i]))
+		as.ErrorIs(err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestVerifyFailure(t *testing.T) {
+	as := assert.New(t)
+	ns := makeNamespace()
+	var buf bytes.Buffer
+	w, err := bytecode.NewWriter(&buf, nil)
+	as.NoError(err)
+	as.NoError(w.Write(&vm.Procedure{
+		Runnable: isa.Runnable{
+			Globals: ns,
+			Code: isa.Instructions{
+				isa.True.New(),
+				isa.Jump.New(3),
+				isa.Return.New(),
+			},
+			StackSize: 1,
+		},
+	}))
+	_, err = eval.Load(ns, &buf)
+	as.EqualError(err, fmt.Sprintf(analysis.ErrJumpOutOfRange, 3))
+
+	buf.Reset()
+	w, _ = bytecode.NewWriter(&buf, nil)
+	as.NoError(w.Write(&vm.Procedure{
+		Runnable: isa.Runnable{
+			Globals: ns,
+			Code: isa.Instructions{
+				isa.True.New(),
+				isa.True.New(),
+				isa.Return.New(),
+			},
+			StackSize: 1,
+		},
+	}))
+	_, err = eval.Load(ns, &buf)
+	as.EqualError(err, fmt.Sprintf(analysis.ErrStackOverflow, 1))
+
+	buf.Reset()
+	w, _ = bytecode.NewWriter(&buf, nil)
+	as.NoError(w.Write(&vm.Procedure{
+		Runnable: isa.Runnable{
+			Globals: ns,
+			Code:    isa.Instructions{isa.Load.New(0), isa.Return.New()},
+		},
+	}))
+	_, err = eval.Load(ns, &buf)
+	as.EqualError(err, fmt.Sprintf(bytecode.ErrLocalOutOfRange, 0))
+}
+
+func TestDeferredForms(t *testing.T) {
+	as := assert.New(t)
+	ns := makeNamespace()
+	_, err := eval.Load(ns, bytes.NewReader(compileScript(t, `
+		(declare later)
+	`)))
+	as.NoError(err)
+	as.IsNotBound(ns, "later")
+
+	res, err := eval.Load(makeNamespace(), bytes.NewReader(compileScript(t, `
+		(declare later)
+		(define (caller) (later))
+		(define (later) :later)
+		(caller)
+	`)))
+	if as.NoError(err) {
+		as.Equal(K("later"), res)
+	}
+}
+
+func TestLoadedForms(t *testing.T) {
+	as := assert.New(t)
+	var buf bytes.Buffer
+	w, err := bytecode.NewWriter(&buf, nil)
+	as.NoError(err)
+	form := data.NewList(LS("+"), I(1), I(2))
+	as.NoError(w.WriteForm(form))
+
+	var forms []ale.Value
+	ctx := eval.WithLoadedForms(context.Background(), func(v ale.Value) {
+		forms = append(forms, v)
+	})
+	res, err := eval.LoadContext(ctx, makeNamespace(), &buf)
+	if as.NoError(err) {
+		as.Equal(I(3), res)
+	}
+	as.Equal(1, len(forms))
+	as.String("(+ 1 2)", forms[0])
+}
+
+func TestPrivateGlobals(t *testing.T) {
+	as := assert.New(t)
+	ns := makeNamespace()
+	root := ns.Environment().GetRoot()
+	secret := data.NewObject(C(K("secret"), data.True))
+	as.NoError(env.BindPrivate(root, "secret", secret))
+
+	res, err := eval.Load(root, bytes.NewReader(constantCode(t, root, secret)))
+	if as.NoError(err) {
+		as.Identical(secret, res)
+	}
+	_, err = eval.Load(ns, bytes.NewReader(constantCode(t, root, secret)))
+	as.EqualError(err, fmt.Sprintf(bytecode.ErrForeignGlobals, "ale"))
+}
+
+func TestForeignGlobals(t *testing.T) {
+	as := assert.New(t)
+	e := bootstrap.DevNullEnvironment()
+	victim := env.MustGetQualified(e, "victim")
+	as.NoError(env.BindPrivate(victim, "secret", S("TOP-SECRET")))
+
+	var buf bytes.Buffer
+	seq := read.MustFromString(victim, "secret")
+	as.NoError(eval.Compile(victim, seq, &buf))
+	code := buf.Bytes()
+
+	user := env.MustGetQualified(e, "user")
+	_, err := eval.Load(user, bytes.NewReader(code))
+	as.EqualError(err, fmt.Sprintf(bytecode.ErrForeignGlobals, "victim"))
+
+	res, err := eval.Load(victim, bytes.NewReader(code))
+	if as.NoError(err) {
+		as.String("TOP-SECRET", res)
+	}
+}
+
+func constantCode(t *testing.T, globals env.Namespace, v ale.Value) []byte {
+	t.Helper()
+	as := assert.New(t)
+	var buf bytes.Buffer
+	w, err := bytecode.NewWriter(&buf, nil)
+	as.NoError(err)
+	as.NoError(w.Write(&vm.Procedure{
+		Runnable: isa.Runnable{
+			Globals:   globals,
+			Code:      isa.Instructions{isa.Const.New(0), isa.Return.New()},
+			Constants: data.Vector{v},
+			StackSize: 1,
+		},
+	}))
+	return buf.Bytes()
+}
+
+func TestSandboxedSpecial(t *testing.T) {
+	as := assert.New(t)
+	code := compileScript(t, `
+		(define twice (special [(x) eval x eval x pop]))
+	`)
+
+	e := bootstrap.Sandboxed()
+	_, err := eval.Load(env.MustGetQualified(e, "user"), bytes.NewReader(code))
+	as.EqualError(err, "capability not granted: special requires internals")
+
+	e = bootstrap.Sandboxed(bootstrap.Grant(bootstrap.Internals))
+	_, err = eval.Load(env.MustGetQualified(e, "user"), bytes.NewReader(code))
+	as.NoError(err)
+}
+
+func TestForeignDefinitions(t *testing.T) {
+	as := assert.New(t)
+	ns := makeNamespace()
+	root := ns.Environment().GetRoot()
+
+	var buf bytes.Buffer
+	w, err := bytecode.NewWriter(&buf, nil)
+	as.NoError(err)
+	as.NoError(w.Write(&vm.Procedure{
+		Runnable: isa.Runnable{
+			Globals:   ns,
+			Code:      isa.Instructions{isa.Null.New(), isa.Return.New()},
+			StackSize: 1,
+		},
+	}, &bytecode.Definition{
+		Namespace: root,
+		Name:      "if",
+		Value:     I(1),
+		Bound:     true,
+	}))
+	_, err = eval.Load(ns, &buf)
+	as.EqualError(err, fmt.Sprintf(bytecode.ErrForeignDefinition, "ale", "if"))
+
+	res, err := eval.Load(makeNamespace(), bytes.NewReader(compileScript(t, `
+		(define in-other (%mk-ns other))
+		(in-other (define x 1))
+		(in-other (define y 2))
+		(+ other/x other/y)
+	`)))
+	if as.NoError(err) {
+		as.Number(3, res)
+	}
+}
+
+func TestArgumentOutOfRange(t *testing.T) {
+	as := assert.New(t)
+	ns := makeNamespace()
+
+	read := func(arity *params.Arity, code ...isa.Instruction) error {
+		var buf bytes.Buffer
+		w, err := bytecode.NewWriter(&buf, nil)
+		as.NoError(err)
+		as.NoError(w.Write(&vm.Procedure{
+			Runnable: isa.Runnable{
+				Globals:   ns,
+				Code:      append(code, isa.Return.New()),
+				StackSize: 2,
+			},
+			Arity: arity,
+		}))
+		r, err := bytecode.NewReader(ns, &buf, nil)
+		as.NoError(err)
+		_, err = r.Read()
+		return err
+	}
+
+	one := &params.Arity{Fixed: []uint8{1 << 1}}
+	as.NoError(read(one, isa.Arg.New(0)))
+	as.EqualError(read(one, isa.Arg.New(1)),
+		fmt.Sprintf(bytecode.ErrArgumentOutOfRange, 1),
+	)
+	as.NoError(read(one, isa.ArgsRest.New(1)))
+	as.EqualError(read(one, isa.ArgsRest.New(2)),
+		fmt.Sprintf(bytecode.ErrArgumentOutOfRange, 2),
+	)
+
+	wide := &params.Arity{Fixed: []uint8{1 << 1, 1 << 1}}
+	as.NoError(read(wide, isa.Arg.New(8)))
+	as.EqualError(read(wide, isa.Arg.New(9)),
+		fmt.Sprintf(bytecode.ErrArgumentOutOfRange, 9),
+	)
+
+	rest := &params.Arity{Fixed: []uint8{1 << 1}, HasRest: true, LowRest: 2}
+	as.NoError(read(rest, isa.Arg.New(5)))
+	as.NoError(read(nil, isa.Arg.New(5)))
+
+	as.NoError(read(one,
+		isa.Arg.New(0), isa.Arg.New(0), isa.ArgsPush.New(2),
+		isa.Arg.New(1), isa.ArgsPop.New(),
+	))
+	as.EqualError(read(rest,
+		isa.Arg.New(0), isa.ArgsPush.New(1), isa.Arg.New(1), isa.ArgsPop.New(),
+	), fmt.Sprintf(bytecode.ErrArgumentOutOfRange, 1))
+	as.EqualError(read(one,
+		isa.Arg.New(0), isa.ArgsPush.New(1), isa.ArgsPop.New(), isa.Arg.New(1),
+	), fmt.Sprintf(bytecode.ErrArgumentOutOfRange, 1))
+}
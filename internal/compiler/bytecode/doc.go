@@ -0,0 +1,3 @@
+// Package bytecode serializes compiled procedures into a versioned binary
+// format, and loads them back into the abstract machine
+package bytecode
@@ -0,0 +1,146 @@
+package bytecode
+
+import (
+	"bytes"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+)
+
+type (
+	tag byte
+
+	// Definition is a top-level unit that declares a name in a Namespace,
+	// and that binds it to a Value if Bound is set. Definitions are written
+	// in place of the forms that made them, so that loading bytecode doesn't
+	// have to compile those forms again
+	Definition struct {
+		Value     ale.Value
+		Namespace env.Namespace
+		Name      data.Local
+		Private   bool
+		Bound     bool
+	}
+
+	// Natives are the values implemented in Go that bytecode can refer to by
+	// name. The same Natives must be provided to the Writer and the Reader
+	Natives map[data.Local]ale.Value
+)
+
+// Magic is the header that begins every stream of precompiled bytecode
+const Magic = "\x00ale"
+
+// Version identifies the revision of the binary format. A Reader will only
+// load bytecode that was written using the same Version
+const Version = 3
+
+// Error messages
+const (
+	// ErrBadMagic is raised when a Reader is provided a stream that doesn't
+	// begin with the bytecode header
+	ErrBadMagic = "stream is not precompiled bytecode"
+
+	// ErrUnsupportedVersion is raised when a Reader is provided bytecode that
+	// was written using a different Version of the format
+	ErrUnsupportedVersion = "unsupported bytecode version: %d, expected %d"
+
+	// ErrUnsupportedConstant is raised when a Writer encounters a constant
+	// that can't be serialized, and that isn't bound to a global name
+	ErrUnsupportedConstant = "constant can't be serialized: %s"
+
+	// ErrUnsupportedDefinition is raised when a Writer is asked to write a
+	// Definition whose Value is bound to another global name, and so can't be
+	// written without losing its identity
+	ErrUnsupportedDefinition = "definition can't be serialized: %s"
+
+	// ErrForeignDefinition is raised when a Reader encounters a Definition
+	// for a namespace that existed before the Reader was created, other than
+	// the one that it's loading into
+	ErrForeignDefinition = "definition outside of loading namespace: %s/%s"
+
+	// ErrForeignGlobals is raised when a Reader encounters a procedure whose
+	// globals are a namespace that existed before the Reader was created,
+	// other than the one that it's loading into
+	ErrForeignGlobals = "procedure globals outside of loading namespace: %s"
+
+	// ErrUnknownNative is raised when a Reader encounters a native value
+	// whose name wasn't provided to it
+	ErrUnknownNative = "unknown native value: %s"
+
+	// ErrUnknownTag is raised when a Reader encounters a constant whose tag
+	// isn't part of the format
+	ErrUnknownTag = "unknown constant tag: %d"
+
+	// ErrUnknownOpcode is raised when a Reader encounters an instruction
+	// whose opcode isn't part of the instruction set, or that should have
+	// been stripped when its procedure was finalized
+	ErrUnknownOpcode = "unknown opcode: %d"
+
+	// ErrOperandOutOfRange is raised when a Reader encounters an instruction
+	// whose operand doesn't fit within the operand bits
+	ErrOperandOutOfRange = "instruction operand out of range: %d"
+
+	// ErrConstantOutOfRange is raised when a Reader encounters an instruction
+	// that refers to a constant the procedure doesn't have
+	ErrConstantOutOfRange = "constant index out of range: %d"
+
+	// ErrClosureOutOfRange is raised when a Reader encounters an instruction
+	// that refers to a closure slot the procedure doesn't declare
+	ErrClosureOutOfRange = "closure slot out of range: %d"
+
+	// ErrClosureMismatch is raised when a Reader encounters a closure that
+	// captures fewer values than its procedure declares
+	ErrClosureMismatch = "closure captures %d values, procedure expects %d"
+
+	// ErrLocalOutOfRange is raised when a Reader encounters an instruction
+	// that refers to a local the procedure doesn't declare
+	ErrLocalOutOfRange = "local index out of range: %d"
+
+	// ErrArgumentOutOfRange is raised when a Reader encounters an instruction
+	// that refers to an argument the procedure can't be called with
+	ErrArgumentOutOfRange = "argument index out of range: %d"
+)
+
+// generatedTemplate is the form in which generated symbols are written. It's
+// recognized as a generated symbol, so that the Reader can generate it again
+const generatedTemplate = "x-%s-gensym-%d"
+
+const (
+	tagNull tag = iota
+	tagTrue
+	tagFalse
+	tagInteger
+	tagBigInt
+	tagFloat
+	tagRatio
+	tagString
+	tagKeyword
+	tagLocal
+	tagQualified
+	tagList
+	tagVector
+	tagObject
+	tagCons
+	tagSet
+	tagBytes
+	tagRegex
+	tagError
+	tagProcedure
+	tagClosure
+	tagGlobal
+	tagNative
+	tagSpecial
+	tagDefinition
+)
+
+// Equal compares this Definition to another value
+func (d *Definition) Equal(other ale.Value) bool {
+	return d == other
+}
+
+// IsPrecompiled returns whether the provided source begins with the bytecode
+// header, rather than being Ale source code
+func IsPrecompiled(src []byte) bool {
+	return bytes.HasPrefix(src, []byte(Magic))
+}
@@ -0,0 +1,662 @@
+package bytecode
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/internal/compiler"
+	"github.com/kode4food/ale/internal/compiler/asm"
+	"github.com/kode4food/ale/internal/compiler/ir/analysis"
+	lang "github.com/kode4food/ale/internal/lang/env"
+	"github.com/kode4food/ale/internal/lang/params"
+	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/runtime/vm"
+)
+
+type (
+	// Reader loads the compiled procedures that a Writer serialized, linking
+	// them to the Environment of a Namespace
+	Reader struct {
+		r         *bufio.Reader
+		ns        env.Namespace
+		natives   Natives
+		generated map[data.Local]data.Local
+		existing  data.Locals
+	}
+
+	// decoded is a procedure whose fields have been read, but that hasn't
+	// yet been verified
+	decoded struct {
+		*isa.Runnable
+		name        data.Local
+		arity       *params.Arity
+		closureSize isa.Operand
+	}
+)
+
+// NewReader creates a Reader, consuming the bytecode header from the provided
+// io.Reader. Procedures are loaded into the provided Namespace, the global
+// names they refer to are resolved as code compiled in it would resolve them,
+// and the native names they refer to are resolved using the provided Natives
+func NewReader(ns env.Namespace, r io.Reader, n Natives) (*Reader, error) {
+	res := &Reader{
+		r:         bufio.NewReader(r),
+		ns:        ns,
+		natives:   n,
+		generated: map[data.Local]data.Local{},
+		existing:  ns.Environment().Domains(),
+	}
+	magic := make([]byte, len(Magic))
+	if _, err := io.ReadFull(res.r, magic); err != nil || !IsPrecompiled(magic) {
+		return nil, errors.New(ErrBadMagic)
+	}
+	v, err := binary.ReadUvarint(res.r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if v != Version {
+		return nil, fmt.Errorf(ErrUnsupportedVersion, v, Version)
+	}
+	return res, nil
+}
+
+// Read loads the next top-level unit. It's either a *vm.Procedure, a
+// *Definition that must be declared and bound, or a form that was written by
+// WriteForm, and that must be compiled before it's called. Every procedure
+// that a unit contains is verified before being returned. Global names are
+// resolved when a unit is read, so each unit should be called or defined
+// before the next one is read. At the end of the stream, io.EOF is returned
+func (r *Reader) Read() (ale.Value, error) {
+	t, err := r.r.Peek(1)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	var res ale.Value
+	if err == nil && tag(t[0]) == tagDefinition {
+		_, _ = r.r.ReadByte()
+		res, err = r.definition()
+	} else {
+		res, err = r.value()
+	}
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return res, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (r *Reader) procedure() (*vm.Procedure, error) {
+	d, err := r.decode()
+	if err != nil {
+		return nil, err
+	}
+	return d.verify()
+}
+
+func (r *Reader) decode() (*decoded, error) {
+	name, err := r.local()
+	if err != nil {
+		return nil, err
+	}
+	globals, err := r.namespace()
+	if err != nil {
+		return nil, err
+	}
+	if !r.canDefine(globals) {
+		return nil, fmt.Errorf(ErrForeignGlobals, globals.Domain())
+	}
+	arity, err := r.arity()
+	if err != nil {
+		return nil, err
+	}
+	meta := make([]isa.Operand, 3)
+	for i := range meta {
+		if meta[i], err = r.operand(); err != nil {
+			return nil, err
+		}
+	}
+	code, err := r.code()
+	if err != nil {
+		return nil, err
+	}
+	constants, err := r.values()
+	if err != nil {
+		return nil, err
+	}
+	positions, err := r.positions()
+	if err != nil {
+		return nil, err
+	}
+	return &decoded{
+		Runnable: &isa.Runnable{
+			Globals:    globals,
+			Code:       code,
+			Constants:  constants,
+			Positions:  positions,
+			LocalCount: meta[0],
+			StackSize:  meta[1],
+		},
+		name:        name,
+		arity:       arity,
+		closureSize: meta[2],
+	}, nil
+}
+
+func (r *Reader) namespace() (env.Namespace, error) {
+	domain, err := r.string()
+	if err != nil {
+		return nil, err
+	}
+	if data.Local(domain) == r.ns.Domain() {
+		return r.ns, nil
+	}
+	return r.ns.Environment().GetQualified(data.Local(domain))
+}
+
+func (r *Reader) arity() (*params.Arity, error) {
+	if ok, err := r.bool(); err != nil || !ok {
+		return nil, err
+	}
+	fixed, err := r.bytes()
+	if err != nil {
+		return nil, err
+	}
+	hasRest, err := r.bool()
+	if err != nil {
+		return nil, err
+	}
+	lowRest, err := r.operand()
+	if err != nil {
+		return nil, err
+	}
+	return &params.Arity{
+		Fixed:   fixed,
+		HasRest: hasRest,
+		LowRest: int(lowRest),
+	}, nil
+}
+
+func (r *Reader) code() (isa.Instructions, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	var res isa.Instructions
+	for range n {
+		inst, err := r.instruction()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, inst)
+	}
+	return res, nil
+}
+
+func (r *Reader) instruction() (isa.Instruction, error) {
+	u, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return 0, err
+	}
+	oc := isa.Opcode(u)
+	effect, err := isa.GetEffect(oc)
+	if err != nil || u > uint64(isa.OpcodeMask) || effect.Ignore {
+		return 0, fmt.Errorf(ErrUnknownOpcode, u)
+	}
+	if effect.Operand == isa.Nothing {
+		return oc.New(), nil
+	}
+	op, err := r.operand()
+	if err != nil {
+		return 0, err
+	}
+	return oc.New(op), nil
+}
+
+func (r *Reader) operand() (isa.Operand, error) {
+	u, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return 0, err
+	}
+	if u > uint64(isa.OperandMask) {
+		return 0, fmt.Errorf(ErrOperandOutOfRange, u)
+	}
+	return isa.Operand(u), nil
+}
+
+func (r *Reader) positions() (isa.Positions, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	var res isa.Positions
+	for range n {
+		pc, err := r.operand()
+		if err != nil {
+			return nil, err
+		}
+		loc, err := r.location()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, isa.Position{
+			Location: loc,
+			PC:       int(pc),
+		})
+	}
+	return res, nil
+}
+
+func (r *Reader) location() (*data.Location, error) {
+	if ok, err := r.bool(); err != nil || !ok {
+		return nil, err
+	}
+	src, err := r.string()
+	if err != nil {
+		return nil, err
+	}
+	line, err := r.operand()
+	if err != nil {
+		return nil, err
+	}
+	col, err := r.operand()
+	if err != nil {
+		return nil, err
+	}
+	return data.NewLocation(src, int(line), int(col)), nil
+}
+
+func (r *Reader) values() (data.Vector, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	res := data.Vector{}
+	for range n {
+		v, err := r.value()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func (r *Reader) value() (ale.Value, error) {
+	t, err := r.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch tag(t) {
+	case tagNull:
+		return data.Null, nil
+	case tagTrue:
+		return data.True, nil
+	case tagFalse:
+		return data.False, nil
+	case tagInteger:
+		i, err := binary.ReadVarint(r.r)
+		return data.Integer(i), err
+	case tagBigInt:
+		return r.number(data.ParseInteger)
+	case tagFloat:
+		var b [8]byte
+		if _, err := io.ReadFull(r.r, b[:]); err != nil {
+			return nil, err
+		}
+		f := math.Float64frombits(binary.LittleEndian.Uint64(b[:]))
+		return data.Float(f), nil
+	case tagRatio:
+		return r.number(data.ParseRatio)
+	case tagString:
+		s, err := r.string()
+		return data.String(s), err
+	case tagKeyword:
+		s, err := r.string()
+		return data.Keyword(s), err
+	case tagLocal:
+		return r.local()
+	case tagQualified:
+		return r.qualified()
+	case tagBytes:
+		b, err := r.bytes()
+		return data.Bytes(b), err
+	case tagRegex:
+		s, err := r.string()
+		if err != nil {
+			return nil, err
+		}
+		return data.CompileRegex(s)
+	case tagList:
+		v, err := r.values()
+		return data.NewList(v...), err
+	case tagVector:
+		return r.values()
+	case tagSet:
+		v, err := r.values()
+		return data.NewSet(v...), err
+	case tagCons:
+		return r.cons()
+	case tagObject:
+		return r.object()
+	case tagError:
+		return r.error()
+	case tagProcedure:
+		return r.procedure()
+	case tagClosure:
+		return r.closure()
+	case tagGlobal:
+		return r.global()
+	case tagNative:
+		return r.native()
+	case tagSpecial:
+		return r.special()
+	default:
+		return nil, fmt.Errorf(ErrUnknownTag, t)
+	}
+}
+
+func (r *Reader) number(
+	parse func(string) (data.Number, error),
+) (ale.Value, error) {
+	s, err := r.string()
+	if err != nil {
+		return nil, err
+	}
+	return parse(s)
+}
+
+func (r *Reader) qualified() (data.Symbol, error) {
+	domain, err := r.string()
+	if err != nil {
+		return nil, err
+	}
+	name, err := r.local()
+	if err != nil {
+		return nil, err
+	}
+	return data.NewQualifiedSymbol(name, data.Local(domain)), nil
+}
+
+func (r *Reader) cons() (ale.Value, error) {
+	car, err := r.value()
+	if err != nil {
+		return nil, err
+	}
+	cdr, err := r.value()
+	if err != nil {
+		return nil, err
+	}
+	return data.NewCons(car, cdr), nil
+}
+
+func (r *Reader) object() (ale.Value, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	var pairs data.Pairs
+	for range n {
+		p, err := r.cons()
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, p.(data.Pair))
+	}
+	return data.NewObject(pairs...), nil
+}
+
+func (r *Reader) error() (ale.Value, error) {
+	kind, err := r.string()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := r.string()
+	if err != nil {
+		return nil, err
+	}
+	d, err := r.value()
+	if err != nil {
+		return nil, err
+	}
+	return data.NewError(data.Keyword(kind), msg, d), nil
+}
+
+func (r *Reader) closure() (ale.Value, error) {
+	d, err := r.decode()
+	if err != nil {
+		return nil, err
+	}
+	captured, err := r.values()
+	if err != nil {
+		return nil, err
+	}
+	if len(captured) < int(d.closureSize) {
+		return nil, fmt.Errorf(
+			ErrClosureMismatch, len(captured), d.closureSize,
+		)
+	}
+	p, err := d.verify()
+	if err != nil {
+		return nil, err
+	}
+	return p.Call(captured...), nil
+}
+
+func (r *Reader) global() (ale.Value, error) {
+	s, err := r.qualified()
+	if err != nil {
+		return nil, err
+	}
+	return r.resolve(s)
+}
+
+// resolve looks up a global name the way that code compiled in the Reader's
+// Namespace would, so that the private entries of other namespaces can't be
+// reached, and so that a denied entry raises its error
+func (r *Reader) resolve(s data.Symbol) (ale.Value, error) {
+	v, err := env.ResolveValue(r.ns, s)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := v.(*compiler.Denied); ok {
+		return nil, d
+	}
+	return v, nil
+}
+
+func (r *Reader) native() (ale.Value, error) {
+	n, err := r.string()
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := r.natives[data.Local(n)]; ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf(ErrUnknownNative, n)
+}
+
+func (r *Reader) special() (ale.Value, error) {
+	if _, err := r.resolve(env.RootSymbol(lang.Special)); err != nil {
+		return nil, err
+	}
+	cases, err := r.values()
+	if err != nil {
+		return nil, err
+	}
+	return asm.NewSpecial(cases)
+}
+
+func (r *Reader) definition() (*Definition, error) {
+	ns, err := r.namespace()
+	if err != nil {
+		return nil, err
+	}
+	name, err := r.local()
+	if err != nil {
+		return nil, err
+	}
+	if !r.canDefine(ns) {
+		return nil, fmt.Errorf(ErrForeignDefinition, ns.Domain(), name)
+	}
+	flags := make([]bool, 2)
+	for i := range flags {
+		if flags[i], err = r.bool(); err != nil {
+			return nil, err
+		}
+	}
+	res := &Definition{
+		Namespace: ns,
+		Name:      name,
+		Private:   flags[0],
+		Bound:     flags[1],
+	}
+	if res.Bound {
+		if res.Value, err = r.value(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (r *Reader) bool() (bool, error) {
+	b, err := r.r.ReadByte()
+	return b != 0, err
+}
+
+func (r *Reader) bytes() ([]byte, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	res, err := io.ReadAll(io.LimitReader(r.r, int64(min(n, math.MaxInt64))))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(res)) != n {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return res, nil
+}
+
+// local reads a Local, generating a generated symbol again the first time
+// that it's read, so that it can't collide with the symbols generated by this
+// process
+func (r *Reader) local() (data.Local, error) {
+	s, err := r.string()
+	if err != nil {
+		return "", err
+	}
+	l := data.Local(s)
+	if !l.IsGenerated() {
+		return l, nil
+	}
+	if res, ok := r.generated[l]; ok {
+		return res, nil
+	}
+	res := data.NewGeneratedSymbol(l.GeneratedFrom()).(data.Local)
+	r.generated[l] = res
+	return res, nil
+}
+
+func (r *Reader) string() (string, error) {
+	b, err := r.bytes()
+	return string(b), err
+}
+
+// verify checks the decoded operands against the procedure's metadata, and
+// then checks every path through its instructions before finalizing them
+// into a Procedure
+func (d *decoded) verify() (*vm.Procedure, error) {
+	if err := d.checkOperands(); err != nil {
+		return nil, err
+	}
+	if err := analysis.VerifyRunnable(d.Runnable); err != nil {
+		return nil, err
+	}
+	arity := data.ArityChecker(data.CheckAnyArity)
+	if d.arity != nil {
+		arity = d.arity.MakeArityChecker()
+	}
+	res := vm.MakeProcedure(d.Runnable, arity)
+	res.Arity = d.arity
+	res.Name = d.name
+	return res, nil
+}
+
+func (d *decoded) checkOperands() error {
+	argc := []int{d.maxArguments()}
+	for _, inst := range d.Code {
+		oc, op := inst.Split()
+		switch oc {
+		case isa.ArgsPush:
+			argc = append(argc, int(op))
+		case isa.ArgsPop:
+			if len(argc) > 1 {
+				argc = argc[:len(argc)-1]
+			}
+		case isa.Arg:
+			if n := argc[len(argc)-1]; n >= 0 && int(op) >= n {
+				return fmt.Errorf(ErrArgumentOutOfRange, op)
+			}
+		case isa.ArgsRest:
+			if n := argc[len(argc)-1]; n >= 0 && int(op) > n {
+				return fmt.Errorf(ErrArgumentOutOfRange, op)
+			}
+		}
+		switch isa.MustGetEffect(oc).Operand {
+		case isa.Constants:
+			if int(op) >= len(d.Constants) {
+				return fmt.Errorf(ErrConstantOutOfRange, op)
+			}
+		case isa.Captured:
+			if op >= d.closureSize {
+				return fmt.Errorf(ErrClosureOutOfRange, op)
+			}
+		case isa.Locals:
+			if op >= d.LocalCount {
+				return fmt.Errorf(ErrLocalOutOfRange, op)
+			}
+		}
+	}
+	return nil
+}
+
+// maxArguments returns the most arguments that the procedure can be called
+// with, which bounds its Arg operands. If it has no arity, or takes a rest
+// parameter, -1 is returned because its arguments aren't bounded
+func (d *decoded) maxArguments() int {
+	if d.arity == nil || d.arity.HasRest {
+		return -1
+	}
+	res := 0
+	for i, b := range d.arity.Fixed {
+		if b != 0 {
+			res = i*8 + bits.Len8(b) - 1
+		}
+	}
+	return res
+}
+
+// canDefine returns whether a Definition can be made in the provided
+// Namespace, or a procedure can run with it as its globals. That's only the
+// case for the Reader's Namespace, and for the namespaces that were created
+// after the Reader was, which are those that the bytecode itself has created
+func (r *Reader) canDefine(ns env.Namespace) bool {
+	d := ns.Domain()
+	return d == r.ns.Domain() || !slices.Contains(r.existing, d)
+}
@@ -0,0 +1,509 @@
+package bytecode
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"math/big"
+	"reflect"
+	"slices"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/internal/compiler"
+	"github.com/kode4food/ale/internal/lang/params"
+	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/runtime/vm"
+)
+
+type (
+	// Writer serializes compiled procedures to an io.Writer, following a
+	// header that identifies the format and its Version
+	Writer struct {
+		w         io.Writer
+		natives   *nativeIndex
+		generated generated
+	}
+
+	serializer struct {
+		globals   map[ale.Value]data.Symbol
+		natives   *nativeIndex
+		generated generated
+		buf       []byte
+	}
+
+	// generated numbers the generated symbols of a stream in the order that
+	// they're written, so that the bytecode doesn't depend on the prefix of
+	// the process's symbol generator. The Reader generates them again
+	generated map[data.Local]int
+
+	// nativeIndex finds the names of native values. Functions can't be
+	// compared, so they're found using their code pointer, unless more than
+	// one native shares it
+	nativeIndex struct {
+		values Natives
+		refs   map[ale.Value]data.Local
+		funcs  map[uintptr]data.Local
+	}
+)
+
+// NewWriter creates a Writer, writing the bytecode header to the provided
+// io.Writer. A constant that is one of the provided Natives is written as a
+// reference to its name
+func NewWriter(w io.Writer, n Natives) (*Writer, error) {
+	hdr := binary.AppendUvarint([]byte(Magic), Version)
+	if _, err := w.Write(hdr); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:         w,
+		natives:   indexNatives(n),
+		generated: generated{},
+	}, nil
+}
+
+// Write serializes a top-level Procedure, including the procedures nested
+// in its constants. It's preceded by any Definitions that were made when it
+// was compiled. A constant that is bound to a name in one of the Procedure's
+// Environment namespaces is written as a reference to it, and is resolved
+// again when the Procedure is read. For that reason, a Procedure must be
+// written before it's called
+func (w *Writer) Write(p *vm.Procedure, defs ...*Definition) error {
+	e := w.serializer(p.Globals, defs)
+	if err := e.definitions(defs); err != nil {
+		return err
+	}
+	e.tag(tagProcedure)
+	if err := e.procedure(p); err != nil {
+		return err
+	}
+	_, err := w.w.Write(e.buf)
+	return err
+}
+
+// WriteForm serializes a top-level form that can't be written as a compiled
+// Procedure, either because compiling it changes the Environment in a way
+// that Definitions can't describe, or because the Procedure or Definitions
+// have values that can't be serialized. The form is returned by the Reader so that it can be
+// compiled again when it's loaded
+func (w *Writer) WriteForm(v ale.Value) error {
+	e := w.serializer(nil, nil)
+	if err := e.value(v); err != nil {
+		return err
+	}
+	_, err := w.w.Write(e.buf)
+	return err
+}
+
+func (w *Writer) serializer(
+	ns env.Namespace, defs []*Definition,
+) *serializer {
+	res := &serializer{
+		natives:   w.natives,
+		generated: w.generated,
+	}
+	if ns != nil {
+		res.globals = indexGlobals(ns, defs)
+	}
+	return res
+}
+
+func indexNatives(n Natives) *nativeIndex {
+	res := &nativeIndex{
+		values: n,
+		refs:   map[ale.Value]data.Local{},
+		funcs:  map[uintptr]data.Local{},
+	}
+	shared := map[uintptr]bool{}
+	for _, name := range slices.Sorted(maps.Keys(n)) {
+		switch v := n[name]; {
+		case isReference(v):
+			if _, ok := res.refs[v]; !ok {
+				res.refs[v] = name
+			}
+		case isFunc(v):
+			p := reflect.ValueOf(v).Pointer()
+			if _, ok := res.funcs[p]; ok {
+				shared[p] = true
+			}
+			res.funcs[p] = name
+		}
+	}
+	for p := range shared {
+		delete(res.funcs, p)
+	}
+	return res
+}
+
+// lookup returns the name of a native value, preferring the provided name
+// when the value is the native that it names
+func (n *nativeIndex) lookup(v ale.Value, name data.Local) (data.Local, bool) {
+	if nv, ok := n.values[name]; ok && isSameNative(nv, v) {
+		return name, true
+	}
+	switch {
+	case isReference(v):
+		res, ok := n.refs[v]
+		return res, ok
+	case isFunc(v):
+		res, ok := n.funcs[reflect.ValueOf(v).Pointer()]
+		return res, ok && isSameNative(n.values[res], v)
+	default:
+		return "", false
+	}
+}
+
+func isSameNative(l, r ale.Value) bool {
+	switch {
+	case isReference(l):
+		return l == r
+	case isFunc(l):
+		return reflect.TypeOf(l) == reflect.TypeOf(r) &&
+			reflect.ValueOf(l).Pointer() == reflect.ValueOf(r).Pointer()
+	default:
+		return false
+	}
+}
+
+// indexGlobals finds the global names of the reference values bound in the
+// Environment of a Namespace. The entries of the Definitions being written are
+// skipped, so that their Values aren't written as references to themselves.
+// So are the private entries of other namespaces, which a Reader won't resolve
+func indexGlobals(
+	from env.Namespace, defs []*Definition,
+) map[ale.Value]data.Symbol {
+	res := map[ale.Value]data.Symbol{}
+	e := from.Environment()
+	domains := e.Domains()
+	slices.Sort(domains)
+	for _, dom := range domains {
+		ns, err := e.GetQualified(dom)
+		if err != nil {
+			continue
+		}
+		entries := ns.Entries()
+		names := slices.Sorted(maps.Keys(entries))
+		for _, n := range names {
+			if isDefined(defs, dom, n) ||
+				entries[n].IsPrivate() && dom != from.Domain() {
+				continue
+			}
+			v, err := entries[n].Value()
+			if err != nil || !isReference(v) {
+				continue
+			}
+			if _, ok := res[v]; !ok {
+				res[v] = data.NewQualifiedSymbol(n, dom)
+			}
+		}
+	}
+	return res
+}
+
+func isDefined(defs []*Definition, domain, name data.Local) bool {
+	return slices.ContainsFunc(defs, func(d *Definition) bool {
+		return d.Name == name && d.Namespace.Domain() == domain
+	})
+}
+
+func isReference(v ale.Value) bool {
+	return v != nil && reflect.TypeOf(v).Kind() == reflect.Pointer
+}
+
+func isFunc(v ale.Value) bool {
+	return v != nil && reflect.TypeOf(v).Kind() == reflect.Func
+}
+
+func (e *serializer) definitions(defs []*Definition) error {
+	for _, d := range defs {
+		e.tag(tagDefinition)
+		e.string(string(d.Namespace.Domain()))
+		e.local(d.Name)
+		e.bool(d.Private)
+		e.bool(d.Bound)
+		if !d.Bound {
+			continue
+		}
+		if err := e.definition(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *serializer) definition(d *Definition) error {
+	if n, ok := e.natives.lookup(d.Value, d.Name); ok {
+		e.native(n)
+		return nil
+	}
+	if _, ok := e.global(d.Value); ok {
+		return fmt.Errorf(ErrUnsupportedDefinition, d.Name)
+	}
+	return e.literal(d.Value)
+}
+
+func (e *serializer) procedure(p *vm.Procedure) error {
+	e.local(p.Name)
+	e.string(string(p.Globals.Domain()))
+	e.arity(p.Arity)
+	e.uvarint(uint64(p.LocalCount))
+	e.uvarint(uint64(p.StackSize))
+	e.uvarint(uint64(closureSize(p.Code)))
+	e.code(p.Code)
+	if err := e.values(p.Constants); err != nil {
+		return err
+	}
+	e.positions(p.Positions)
+	return nil
+}
+
+func closureSize(code isa.Instructions) isa.Operand {
+	var res isa.Operand
+	for _, inst := range code {
+		if oc, op := inst.Split(); oc == isa.Closure {
+			res = max(res, op+1)
+		}
+	}
+	return res
+}
+
+func (e *serializer) arity(a *params.Arity) {
+	if a == nil {
+		e.bool(false)
+		return
+	}
+	e.bool(true)
+	e.bytes(a.Fixed)
+	e.bool(a.HasRest)
+	e.uvarint(uint64(a.LowRest))
+}
+
+func (e *serializer) code(code isa.Instructions) {
+	e.uvarint(uint64(len(code)))
+	for _, inst := range code {
+		oc, op := inst.Split()
+		e.uvarint(uint64(oc))
+		if isa.MustGetEffect(oc).Operand != isa.Nothing {
+			e.uvarint(uint64(op))
+		}
+	}
+}
+
+func (e *serializer) positions(pos isa.Positions) {
+	e.uvarint(uint64(len(pos)))
+	for _, p := range pos {
+		e.uvarint(uint64(p.PC))
+		if p.Location == nil {
+			e.bool(false)
+			continue
+		}
+		e.bool(true)
+		e.string(p.Location.Source)
+		e.uvarint(uint64(p.Location.Line))
+		e.uvarint(uint64(p.Location.Column))
+	}
+}
+
+func (e *serializer) values(v data.Vector) error {
+	e.uvarint(uint64(len(v)))
+	for _, elem := range v {
+		if err := e.value(elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *serializer) value(v ale.Value) error {
+	if v == data.Null {
+		e.tag(tagNull)
+		return nil
+	}
+	if n, ok := e.natives.lookup(v, ""); ok {
+		e.native(n)
+		return nil
+	}
+	if s, ok := e.global(v); ok {
+		e.tag(tagGlobal)
+		e.string(string(s.(data.Qualified).Domain()))
+		e.local(s.Local())
+		return nil
+	}
+	return e.literal(v)
+}
+
+func (e *serializer) literal(v ale.Value) error {
+	switch v := v.(type) {
+	case data.Bool:
+		if v {
+			e.tag(tagTrue)
+		} else {
+			e.tag(tagFalse)
+		}
+	case data.Integer:
+		e.tag(tagInteger)
+		e.buf = binary.AppendVarint(e.buf, int64(v))
+	case *data.BigInt:
+		e.tag(tagBigInt)
+		e.string((*big.Int)(v).String())
+	case data.Float:
+		e.tag(tagFloat)
+		e.buf = binary.LittleEndian.AppendUint64(
+			e.buf, math.Float64bits(float64(v)),
+		)
+	case *data.Ratio:
+		e.tag(tagRatio)
+		e.string((*big.Rat)(v).String())
+	case data.String:
+		e.tag(tagString)
+		e.string(string(v))
+	case data.Keyword:
+		e.tag(tagKeyword)
+		e.string(string(v))
+	case data.Local:
+		e.tag(tagLocal)
+		e.local(v)
+	case data.Qualified:
+		e.tag(tagQualified)
+		e.string(string(v.Domain()))
+		e.local(v.Local())
+	case data.Bytes:
+		e.tag(tagBytes)
+		e.bytes(v)
+	case *data.Regex:
+		e.tag(tagRegex)
+		e.string(v.Source())
+	case *data.List:
+		e.tag(tagList)
+		return e.sequence(v)
+	case data.Vector:
+		e.tag(tagVector)
+		return e.values(v)
+	case *data.Set:
+		e.tag(tagSet)
+		return e.set(v)
+	case *data.Cons:
+		e.tag(tagCons)
+		if err := e.value(v.Car()); err != nil {
+			return err
+		}
+		return e.value(v.Cdr())
+	case *data.Object:
+		e.tag(tagObject)
+		return e.object(v)
+	case *data.Error:
+		e.tag(tagError)
+		e.string(string(v.Kind()))
+		e.string(v.Message())
+		return e.value(v.Data())
+	case *vm.Procedure:
+		e.tag(tagProcedure)
+		return e.procedure(v)
+	case *vm.Closure:
+		e.tag(tagClosure)
+		if err := e.procedure(v.Procedure); err != nil {
+			return err
+		}
+		return e.values(v.Captured())
+	case *compiler.Special:
+		e.tag(tagSpecial)
+		return e.values(v.Cases)
+	default:
+		return fmt.Errorf(ErrUnsupportedConstant, data.ToString(v))
+	}
+	return nil
+}
+
+func (e *serializer) native(n data.Local) {
+	e.tag(tagNative)
+	e.string(string(n))
+}
+
+func (e *serializer) global(v ale.Value) (data.Symbol, bool) {
+	if !isReference(v) {
+		return nil, false
+	}
+	s, ok := e.globals[v]
+	return s, ok
+}
+
+func (e *serializer) sequence(s data.Sequence) error {
+	var elems data.Vector
+	for f, r, ok := s.Split(); ok; f, r, ok = r.Split() {
+		elems = append(elems, f)
+	}
+	return e.values(elems)
+}
+
+// object writes the pairs of an Object ordered by their keys, so that the
+// bytecode doesn't depend on the order in which they're hashed
+func (e *serializer) object(o *data.Object) error {
+	pairs := o.Pairs()
+	slices.SortStableFunc(pairs, func(l, r data.Pair) int {
+		return compareLiterals(l.Car(), r.Car())
+	})
+	e.uvarint(uint64(len(pairs)))
+	for _, p := range pairs {
+		if err := e.value(p.Car()); err != nil {
+			return err
+		}
+		if err := e.value(p.Cdr()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// set writes the members of a Set in order, for the same reason as object
+func (e *serializer) set(s *data.Set) error {
+	members := s.Members()
+	slices.SortStableFunc(members, compareLiterals)
+	return e.values(members)
+}
+
+func compareLiterals(l, r ale.Value) int {
+	return cmp.Compare(data.ToQuotedString(l), data.ToQuotedString(r))
+}
+
+func (e *serializer) tag(t tag) {
+	e.buf = append(e.buf, byte(t))
+}
+
+func (e *serializer) bool(b bool) {
+	if b {
+		e.buf = append(e.buf, 1)
+		return
+	}
+	e.buf = append(e.buf, 0)
+}
+
+func (e *serializer) uvarint(u uint64) {
+	e.buf = binary.AppendUvarint(e.buf, u)
+}
+
+func (e *serializer) bytes(b []byte) {
+	e.uvarint(uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *serializer) local(l data.Local) {
+	if !l.IsGenerated() {
+		e.string(string(l))
+		return
+	}
+	idx, ok := e.generated[l]
+	if !ok {
+		idx = len(e.generated)
+		e.generated[l] = idx
+	}
+	e.string(fmt.Sprintf(generatedTemplate, l.GeneratedFrom(), idx))
+}
+
+func (e *serializer) string(s string) {
+	e.uvarint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
@@ -7,8 +7,18 @@ import (
 	"github.com/kode4food/ale/internal/types"
 )
 
-// Call represents a code-generating function for the compiler
-type Call func(encoder.Encoder, ...ale.Value) error
+type (
+	// Call represents a code-generating function for the compiler
+	Call func(encoder.Encoder, ...ale.Value) error
+
+	// Special is a Call that was assembled from the param cases of a special
+	// form. The cases are retained so that the Call can be assembled again
+	// when it's loaded from precompiled bytecode
+	Special struct {
+		Call
+		Cases data.Vector
+	}
+)
 
 var (
 	CallType = types.MakeBasic("special")
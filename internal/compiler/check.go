@@ -0,0 +1,10 @@
+package compiler
+
+import "github.com/kode4food/ale/data"
+
+// ResultCheck is a Procedure that checks the value that a procedure is about
+// to return. A procedure has already checked whatever a call to itself
+// returns, so the optimizer is free to drop the check that follows one
+type ResultCheck struct {
+	data.Procedure
+}
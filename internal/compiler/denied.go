@@ -0,0 +1,44 @@
+package compiler
+
+import (
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/internal/types"
+)
+
+// Denied is bound in place of a value that an environment hasn't been granted
+// access to. Compiling a call to it, or a reference to it, fails with the
+// error that it carries
+type Denied struct {
+	err error
+}
+
+var (
+	DeniedType = types.MakeBasic("denied")
+
+	// compile-time checks for interface implementation
+	_ interface {
+		error
+		ale.Typed
+	} = (*Denied)(nil)
+)
+
+// NewDenied constructs a Denied value that carries the provided error
+func NewDenied(err error) *Denied {
+	return &Denied{err: err}
+}
+
+func (d *Denied) Error() string {
+	return d.err.Error()
+}
+
+func (d *Denied) Unwrap() error {
+	return d.err
+}
+
+func (d *Denied) Type() ale.Type {
+	return types.MakeLiteral(DeniedType, d)
+}
+
+func (d *Denied) Equal(other ale.Value) bool {
+	return d == other
+}
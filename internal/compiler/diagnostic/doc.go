@@ -0,0 +1,2 @@
+// Package diagnostic provides compiler warnings
+package diagnostic
@@ -0,0 +1,65 @@
+package diagnostic
+
+import (
+	"fmt"
+
+	"github.com/kode4food/ale/data"
+)
+
+type (
+	// Warning describes a problem that the compiler found in source code
+	// that doesn't prevent it from being compiled
+	Warning struct {
+		Location *data.Location
+		Kind     data.Keyword
+		Message  string
+	}
+
+	// Warnings are gathered in the order that they're reported
+	Warnings []*Warning
+)
+
+// Warning Kinds
+const (
+	// Unused identifies a let binding or lambda parameter that is never
+	// referenced
+	Unused = data.Keyword("unused")
+
+	// Shadowed identifies a local name that hides an outer local or a
+	// declared global
+	Shadowed = data.Keyword("shadowed")
+
+	// Arity identifies a call to a known procedure with a number of
+	// arguments that it will reject
+	Arity = data.Keyword("arity")
+
+	// Unreachable identifies code that can never be evaluated
+	Unreachable = data.Keyword("unreachable")
+
+	// Undeclared identifies a reference to a global that hasn't been
+	// declared
+	Undeclared = data.Keyword("undeclared")
+
+	// Type identifies a value that can never satisfy the Type that a
+	// procedure annotation declares for it
+	Type = data.Keyword("type")
+)
+
+// New constructs a Warning of the specified Kind at a source Location, which
+// may be nil if the Location is unknown
+func New(
+	loc *data.Location, kind data.Keyword, format string, args ...any,
+) *Warning {
+	return &Warning{
+		Location: loc,
+		Kind:     kind,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func (w *Warning) String() string {
+	if w.Location == nil {
+		return fmt.Sprintf("warning: %s", w.Message)
+	}
+	return fmt.Sprintf("%s: warning: %s", w.Location, w.Message)
+}
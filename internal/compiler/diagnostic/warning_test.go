@@ -0,0 +1,22 @@
+package diagnostic_test
+
+import (
+	"testing"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	"github.com/kode4food/ale/internal/compiler/diagnostic"
+)
+
+func TestWarning(t *testing.T) {
+	as := assert.New(t)
+
+	loc := data.NewLocation("test.ale", 3, 5)
+	w := diagnostic.New(loc, diagnostic.Unused, "never used: %s", "x")
+	as.Equal(diagnostic.Unused, w.Kind)
+	as.Equal("never used: x", w.Message)
+	as.Equal("test.ale:3:5: warning: never used: x", w.String())
+
+	w = diagnostic.New(nil, diagnostic.Arity, "bad call")
+	as.Equal("warning: bad call", w.String())
+}
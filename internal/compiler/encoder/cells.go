@@ -11,8 +11,10 @@ type (
 
 	// Cell attaches a name to a type/disposition
 	Cell struct {
-		Name data.Local
-		Type CellType
+		location   *data.Location
+		Name       data.Local
+		Type       CellType
+		referenced bool
 	}
 
 	// IndexedCells encapsulates a group of IndexedCells
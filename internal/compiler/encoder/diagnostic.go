@@ -0,0 +1,99 @@
+package encoder
+
+import (
+	"strings"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/internal/compiler/diagnostic"
+)
+
+// Warning messages
+const (
+	// WarnUnusedLocal is reported when a local is never referenced
+	WarnUnusedLocal = "local is never used: %s"
+
+	// WarnUnusedParam is reported when a parameter is never referenced
+	WarnUnusedParam = "parameter is never used: %s"
+
+	// WarnShadowedLocal is reported when a local or parameter hides another
+	// that was declared in an enclosing scope
+	WarnShadowedLocal = "name shadows an outer local: %s"
+
+	// WarnShadowedGlobal is reported when a local or parameter hides a
+	// global that is visible from the current namespace
+	WarnShadowedGlobal = "name shadows a global: %s"
+)
+
+// ignoredPrefix marks names that are intentionally left unused
+const ignoredPrefix = "_"
+
+func (e *encoder) Warn(kind data.Keyword, format string, args ...any) {
+	e.warnAt(e.location, kind, format, args...)
+}
+
+func (e *encoder) warnAt(
+	loc *data.Location, kind data.Keyword, format string, args ...any,
+) {
+	w := diagnostic.New(loc, kind, format, args...)
+	*e.warnings = append(*e.warnings, w)
+}
+
+func (e *encoder) newLocatedCell(t CellType, n data.Local) *Cell {
+	res := newCell(t, n)
+	res.location = e.location
+	return res
+}
+
+func (e *encoder) checkUnused(cells IndexedCells, format string) {
+	for _, c := range cells {
+		if c.referenced || isIgnored(c.Name) {
+			continue
+		}
+		e.warnAt(c.location, diagnostic.Unused, format, c.Name)
+	}
+}
+
+// checkShadowed reports names that hide those of enclosing scopes or the
+// globals of the current namespace. Reference cells are exempt from the
+// latter check because they're how recursive procedures name themselves, and
+// rest cells because they're conventionally named rest, after what they
+// collect
+func (e *encoder) checkShadowed(n data.Local, t CellType) {
+	if n.IsGenerated() {
+		return
+	}
+	if e.isScoped(n) {
+		e.Warn(diagnostic.Shadowed, WarnShadowedLocal, n)
+		return
+	}
+	if t == ReferenceCell || t == RestCell {
+		return
+	}
+	if _, _, err := env.ResolveSymbol(e.Globals(), n); err == nil {
+		e.Warn(diagnostic.Shadowed, WarnShadowedGlobal, n)
+	}
+}
+
+// isScoped checks whether a name is declared by an enclosing scope without
+// marking it as referenced
+func (e *encoder) isScoped(n data.Local) bool {
+	for _, scope := range e.locals {
+		if _, ok := scope[n]; ok {
+			return true
+		}
+	}
+	for _, p := range e.params {
+		if _, ok := resolveParam(p, n); ok {
+			return true
+		}
+	}
+	if p, ok := e.parent.(*encoder); ok {
+		return p.isScoped(n)
+	}
+	return false
+}
+
+func isIgnored(n data.Local) bool {
+	return n.IsGenerated() || strings.HasPrefix(string(n), ignoredPrefix)
+}
@@ -0,0 +1,76 @@
+package encoder_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	"github.com/kode4food/ale/internal/compiler/diagnostic"
+	"github.com/kode4food/ale/internal/compiler/encoder"
+)
+
+func messages(w diagnostic.Warnings) []string {
+	res := make([]string, len(w))
+	for i, w := range w {
+		res[i] = w.Message
+	}
+	return res
+}
+
+func TestUnusedWarnings(t *testing.T) {
+	as := assert.New(t)
+
+	e := assert.GetTestEncoder()
+	e.PushParams(data.Locals{"used", "unused", "_ignored"}, false)
+	e.PushLocals()
+	_, err := e.AddLocal("two", encoder.ValueCell)
+	as.NoError(err)
+	_, err = e.AddLocal("one", encoder.ValueCell)
+	as.NoError(err)
+	_, err = e.AddLocal(data.NewGeneratedSymbol("gen").(data.Local),
+		encoder.ValueCell,
+	)
+	as.NoError(err)
+
+	_, ok := e.ResolveParam("used")
+	as.True(ok)
+	as.NoError(e.PopLocals())
+	e.PopParams()
+
+	as.Equal([]string{
+		fmt.Sprintf(encoder.WarnUnusedLocal, "two"),
+		fmt.Sprintf(encoder.WarnUnusedLocal, "one"),
+		fmt.Sprintf(encoder.WarnUnusedParam, "unused"),
+	}, messages(e.Encode().Warnings))
+}
+
+func TestShadowedWarnings(t *testing.T) {
+	as := assert.New(t)
+
+	e := assert.GetTestEncoder()
+	e.PushParams(data.Locals{"outer"}, false)
+	c := e.Child()
+	c.PushLocals()
+	_, err := c.AddLocal("outer", encoder.ValueCell)
+	as.NoError(err)
+	_, err = c.AddLocal("str", encoder.ValueCell)
+	as.NoError(err)
+	_, err = c.AddLocal("list", encoder.ReferenceCell)
+	as.NoError(err)
+
+	w := e.Encode().Warnings
+	as.Equal([]string{
+		fmt.Sprintf(encoder.WarnShadowedLocal, "outer"),
+		fmt.Sprintf(encoder.WarnShadowedGlobal, "str"),
+	}, messages(w))
+	as.Equal(diagnostic.Shadowed, w[0].Kind)
+
+	// checking for shadowing doesn't count as a reference
+	as.NoError(c.PopLocals())
+	e.PopParams()
+	w = e.Encode().Warnings
+	as.Equal(
+		fmt.Sprintf(encoder.WarnUnusedParam, "outer"), w[len(w)-1].Message,
+	)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/internal/compiler/diagnostic"
 	"github.com/kode4food/ale/internal/compiler/ir/analysis"
 	"github.com/kode4food/ale/internal/runtime/isa"
 )
@@ -13,12 +14,16 @@ import (
 type (
 	// Encoded is a snapshot of the current Encoder's state. It is used as an
 	// intermediate step in the compilation process, particularly as input to
-	// the optimizer.
+	// the optimizer. The operands of any Pos instructions in the Code index
+	// into its Positions. Warnings include those reported while encoding
+	// any nested procedures.
 	Encoded struct {
 		Code      isa.Instructions
 		Globals   env.Namespace
 		Constants data.Vector
+		Positions []*data.Location
 		Closure   data.Locals
+		Warnings  diagnostic.Warnings
 	}
 
 	finalizer struct {
@@ -28,6 +33,7 @@ type (
 		localMap  map[isa.Operand]isa.Operand
 		output    isa.Instructions
 		constants data.Vector
+		positions isa.Positions
 	}
 
 	label struct {
@@ -91,6 +97,8 @@ func (f *finalizer) handleInst(i isa.Instruction) error {
 		f.handleJump(i)
 	case isa.Label:
 		return f.handleLabel(i)
+	case isa.Pos:
+		f.handlePos(i)
 	default:
 		effect, err := isa.GetEffect(oc)
 		if err != nil {
@@ -153,6 +161,18 @@ func (f *finalizer) handleLabel(i isa.Instruction) error {
 	return nil
 }
 
+func (f *finalizer) handlePos(i isa.Instruction) {
+	pos := isa.Position{
+		Location: f.Positions[i.Operand()],
+		PC:       int(f.nextOutputOffset()),
+	}
+	if l := len(f.positions) - 1; l >= 0 && f.positions[l].PC == pos.PC {
+		f.positions[l] = pos
+		return
+	}
+	f.positions = append(f.positions, pos)
+}
+
 func (f *finalizer) getLabel(idx isa.Operand) *label {
 	if lbl, ok := f.labels[idx]; ok {
 		return lbl
@@ -176,6 +196,7 @@ func (f *finalizer) stripAdjacentJumps() {
 		oc, op := f.output[i].Split()
 		if oc == isa.Jump && op == isa.Operand(i+1) {
 			f.output = removeInstruction(f.output, i)
+			f.positions = removePosition(f.positions, i)
 			continue
 		}
 		i--
@@ -193,6 +214,21 @@ func removeInstruction(inst isa.Instructions, idx int) isa.Instructions {
 	return res
 }
 
+func removePosition(pos isa.Positions, idx int) isa.Positions {
+	res := make(isa.Positions, 0, len(pos))
+	for _, p := range pos {
+		if p.PC > idx {
+			p.PC--
+		}
+		if l := len(res) - 1; l >= 0 && res[l].PC == p.PC {
+			res[l] = p
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
 func (f *finalizer) makeRunnable() (*isa.Runnable, error) {
 	stackSize, err := analysis.CalculateStackSize(f.output)
 	if err != nil {
@@ -202,6 +238,7 @@ func (f *finalizer) makeRunnable() (*isa.Runnable, error) {
 		Code:       f.output,
 		Globals:    f.Globals,
 		Constants:  f.constants,
+		Positions:  f.positions,
 		LocalCount: isa.Operand(len(f.localMap)),
 		StackSize:  stackSize,
 	}, nil
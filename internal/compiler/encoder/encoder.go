@@ -7,6 +7,7 @@ import (
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
 	"github.com/kode4food/ale/internal/basics"
+	"github.com/kode4food/ale/internal/compiler/diagnostic"
 	"github.com/kode4food/ale/internal/runtime/isa"
 )
 
@@ -25,6 +26,18 @@ type (
 		// Globals returns the global namespace for this Encoder
 		Globals() env.Namespace
 
+		// SetLocation sets the source location of the instructions that are
+		// subsequently emitted, returning the location it replaces
+		SetLocation(*data.Location) *data.Location
+
+		// SetArguments sets the arguments of the form being encoded, as they
+		// appeared in its source, returning the arguments it replaces
+		SetArguments(data.Sequence) data.Sequence
+
+		// ArgLocation returns the source location of an argument of the form
+		// being encoded, if it's known
+		ArgLocation(int) (*data.Location, bool)
+
 		// NewLabel creates a new label for jump instructions
 		NewLabel() isa.Operand
 
@@ -57,6 +70,9 @@ type (
 
 		// ResolveLocal resolves a local variable
 		ResolveLocal(data.Local) (*IndexedCell, bool)
+
+		// Warn reports a diagnostic Warning at the current source location
+		Warn(data.Keyword, string, ...any)
 	}
 
 	WrappedEncoder interface {
@@ -74,6 +90,11 @@ type (
 		constants data.Vector
 		closure   IndexedCells
 		params    paramStack
+		positions []*data.Location
+		warnings  *diagnostic.Warnings
+		location  *data.Location
+		emitted   *data.Location
+		arguments data.Sequence
 		nextLabel isa.Operand
 		nextLocal isa.Operand
 	}
@@ -82,21 +103,25 @@ type (
 // NewEncoder instantiates a new Encoder
 func NewEncoder(globals env.Namespace) Encoder {
 	return &encoder{
-		globals: globals,
-		locals:  []Locals{{}},
+		globals:  globals,
+		locals:   []Locals{{}},
+		warnings: new(diagnostic.Warnings),
 	}
 }
 
 // Child creates a child Encoder
 func (e *encoder) Child() Encoder {
 	return &encoder{
-		parent: e,
-		locals: []Locals{{}},
+		parent:   e,
+		locals:   []Locals{{}},
+		warnings: e.warnings,
+		location: e.location,
 	}
 }
 
 // Emit adds instructions to the Encoder's eventual output
 func (e *encoder) Emit(oc isa.Opcode, args ...isa.Operand) {
+	e.emitPosition(oc)
 	e.code = append(e.code, oc.New(args...))
 }
 
@@ -106,6 +131,8 @@ func (e *encoder) Encode() *Encoded {
 		Code:      e.code,
 		Globals:   e.Globals(),
 		Constants: slices.Clone(e.constants),
+		Positions: slices.Clone(e.positions),
+		Warnings:  slices.Clone(*e.warnings),
 		Closure: basics.Map(e.closure, func(elem *IndexedCell) data.Local {
 			return elem.Name
 		}),
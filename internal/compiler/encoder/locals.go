@@ -3,6 +3,7 @@ package encoder
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/runtime/isa"
@@ -29,6 +30,7 @@ func (e *encoder) PopLocals() error {
 		return errors.New(ErrNoLocalScope)
 	}
 	scope := e.peekLocals()
+	e.checkUnused(scope.sorted(), WarnUnusedLocal)
 	e.nextLocal -= isa.Operand(len(scope))
 	scopes := e.locals
 	e.locals = scopes[0 : len(scopes)-1]
@@ -52,7 +54,8 @@ func (e *encoder) AddLocal(n data.Local, t CellType) (*IndexedCell, error) {
 	if _, ok := scope[n]; ok {
 		return nil, fmt.Errorf(ErrDuplicateName, n)
 	}
-	c := newCell(t, n)
+	e.checkShadowed(n, t)
+	c := e.newLocatedCell(t, n)
 	res := newIndexedCell(e.allocLocal(), c)
 	scope[n] = res
 	return res, nil
@@ -63,8 +66,20 @@ func (e *encoder) ResolveLocal(n data.Local) (*IndexedCell, bool) {
 	for i := len(scopes) - 1; i >= 0; i-- {
 		scope := scopes[i]
 		if l, ok := scope[n]; ok {
+			l.referenced = true
 			return l, true
 		}
 	}
 	return nil, false
 }
+
+func (l Locals) sorted() IndexedCells {
+	res := make(IndexedCells, 0, len(l))
+	for _, c := range l {
+		res = append(res, c)
+	}
+	slices.SortFunc(res, func(l, r *IndexedCell) int {
+		return int(l.Index) - int(r.Index)
+	})
+	return res
+}
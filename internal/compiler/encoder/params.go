@@ -10,18 +10,21 @@ type paramStack []IndexedCells
 
 func (e *encoder) PushParams(names data.Locals, rest bool) {
 	cells := basics.IndexedMap(names, func(n data.Local, i int) *IndexedCell {
-		c := newCell(ValueCell, n)
+		t := ValueCell
+		if rest && i == len(names)-1 {
+			t = RestCell
+		}
+		e.checkShadowed(n, t)
+		c := e.newLocatedCell(t, n)
 		return newIndexedCell(isa.Operand(i), c)
 	})
-	if rest {
-		cells[len(cells)-1].Type = RestCell
-	}
 	e.params = append(e.params, cells)
 }
 
 func (e *encoder) PopParams() {
 	params := e.params
 	pl := len(params)
+	e.checkUnused(params[pl-1], WarnUnusedParam)
 	e.params = params[0 : pl-1]
 }
 
@@ -30,6 +33,7 @@ func (e *encoder) ResolveParam(n data.Local) (*IndexedCell, bool) {
 	for i := len(params) - 1; i >= 0; i-- {
 		p := params[i]
 		if c, ok := resolveParam(p, n); ok {
+			c.referenced = true
 			return c, ok
 		}
 	}
@@ -0,0 +1,58 @@
+package encoder
+
+import (
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/runtime/isa"
+)
+
+// SetLocation sets the source location of subsequently emitted instructions
+func (e *encoder) SetLocation(loc *data.Location) *data.Location {
+	res := e.location
+	e.location = loc
+	return res
+}
+
+// SetArguments sets the arguments of the form being encoded
+func (e *encoder) SetArguments(args data.Sequence) data.Sequence {
+	res := e.arguments
+	e.arguments = args
+	return res
+}
+
+// ArgLocation returns the source location of an argument of the form being
+// encoded. The parser associates every tail of a List with the location of
+// its first element, so only Lists of arguments can be located
+func (e *encoder) ArgLocation(i int) (*data.Location, bool) {
+	s := e.arguments
+	for ; i > 0 && s != nil; i-- {
+		_, s, _ = s.Split()
+	}
+	if l, ok := s.(*data.List); ok && !l.IsEmpty() {
+		return l.Location()
+	}
+	return nil, false
+}
+
+// emitPosition lazily emits a Pos instruction when the source location has
+// changed, but only ahead of instructions that have some chance of raising
+// an error. Pure control flow is left alone so the optimizer can still
+// recognize the shapes that it's looking for
+func (e *encoder) emitPosition(oc isa.Opcode) {
+	if e.location == e.emitted || !isPositioned(oc) {
+		return
+	}
+	e.emitted = e.location
+	idx := isa.Operand(len(e.positions))
+	e.positions = append(e.positions, e.location)
+	e.code = append(e.code, isa.Pos.New(idx))
+}
+
+func isPositioned(oc isa.Opcode) bool {
+	switch oc {
+	case isa.Jump, isa.CondJump,
+		isa.Return, isa.RetFalse, isa.RetNull, isa.RetTrue:
+		return false
+	default:
+		return !isa.MustGetEffect(oc).Ignore
+	}
+}
@@ -10,7 +10,8 @@ import (
 type (
 	Binding struct {
 		ale.Value
-		Name data.Local
+		Name     data.Local
+		Location *data.Location
 	}
 
 	Bindings []*Binding
@@ -34,7 +35,7 @@ func Locals(e encoder.Encoder, bindings Bindings, body Builder) error {
 	// Bind the popped expression results to names
 	for i := len(bindings) - 1; i >= 0; i-- {
 		b := bindings[i]
-		l, err := e.AddLocal(b.Name, encoder.ValueCell)
+		l, err := addLocal(e, b, encoder.ValueCell)
 		if err != nil {
 			return err
 		}
@@ -52,7 +53,7 @@ func MutualLocals(e encoder.Encoder, bindings Bindings, body Builder) error {
 	// Create references
 	cells := make(encoder.IndexedCells, len(bindings))
 	for i, b := range bindings {
-		c, err := e.AddLocal(b.Name, encoder.ReferenceCell)
+		c, err := addLocal(e, b, encoder.ReferenceCell)
 		if err != nil {
 			return err
 		}
@@ -81,6 +82,18 @@ func MutualLocals(e encoder.Encoder, bindings Bindings, body Builder) error {
 	return e.PopLocals()
 }
 
+// addLocal declares the name of a Binding at the Binding's own location, if
+// it has one, which is where any warnings about the name are reported
+func addLocal(
+	e encoder.Encoder, b *Binding, t encoder.CellType,
+) (*encoder.IndexedCell, error) {
+	if b.Location != nil {
+		prev := e.SetLocation(b.Location)
+		defer e.SetLocation(prev)
+	}
+	return e.AddLocal(b.Name, t)
+}
+
 func BoundValue(e encoder.Encoder, c *encoder.IndexedCell, v ale.Value) error {
 	be := &bindEncoder{
 		Encoder: e,
@@ -5,6 +5,7 @@ import (
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
 	"github.com/kode4food/ale/internal/compiler"
+	"github.com/kode4food/ale/internal/compiler/diagnostic"
 	"github.com/kode4food/ale/internal/compiler/encoder"
 	"github.com/kode4food/ale/internal/runtime/isa"
 	"github.com/kode4food/ale/internal/sequence"
@@ -15,12 +16,18 @@ type (
 	argsEmitter func() (int, error)
 )
 
+// WarnBadArity is reported when a late binding environment compiles a call
+// that the currently bound procedure would reject
+const WarnBadArity = "call to %s will fail: %s"
+
 // Call encodes a function call
 func Call(e encoder.Encoder, l *data.List) error {
 	f, r, ok := l.Split()
 	if !ok {
 		return Null(e)
 	}
+	prev := e.SetArguments(r)
+	defer e.SetArguments(prev)
 	args := sequence.ToVector(r)
 	return callValue(e, f, args)
 }
@@ -51,13 +58,22 @@ func callLocalSymbol(e encoder.Encoder, l data.Local, args data.Vector) error {
 }
 
 func callGlobalSymbol(e encoder.Encoder, s data.Symbol, args data.Vector) error {
-	globals := e.Globals()
-	if v, err := env.ResolveValue(globals, s); err == nil {
+	if entry, in, err := env.ResolveSymbol(e.Globals(), s); err == nil {
+		v, _ := entry.Value()
 		switch v := v.(type) {
 		case compiler.Call:
 			return v(e, args...)
+		case *compiler.Special:
+			return v.Call(e, args...)
 		case data.Procedure:
-			return callStatic(e, v, args)
+			if !isLateBound(e, in) {
+				return callStatic(e, v, args)
+			}
+			if err := v.CheckArity(len(args)); err != nil {
+				e.Warn(diagnostic.Arity, WarnBadArity, s, err)
+			}
+		case *compiler.Denied:
+			return v
 		}
 	}
 	return callDynamic(e, s, args)
@@ -70,6 +86,8 @@ func callNonSymbol(e encoder.Encoder, v ale.Value, args data.Vector) error {
 	switch v := v.(type) {
 	case compiler.Call:
 		return v(e, args...)
+	case *compiler.Special:
+		return v.Call(e, args...)
 	case data.Procedure:
 		return callStatic(e, v, args)
 	}
@@ -22,6 +22,9 @@ func Procedure(e encoder.Encoder, build Builder) (*vm.Procedure, error) {
 	if err != nil {
 		return nil, err
 	}
+	if b, ok := e.(*bindEncoder); ok {
+		fn.Name = b.cell.Name
+	}
 
 	if enc.HasClosure() {
 		return captureClosure(e, fn, enc.Closure)
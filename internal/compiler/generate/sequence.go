@@ -1,6 +1,10 @@
 package generate
 
 import (
+	"cmp"
+	"slices"
+
+	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
 	"github.com/kode4food/ale/internal/compiler/encoder"
@@ -59,10 +63,13 @@ func Object(e encoder.Encoder, a *data.Object) error {
 	if a.IsEmpty() {
 		return Literal(e, data.EmptyObject)
 	}
+	pairs := a.Pairs()
+	slices.SortStableFunc(pairs, func(l, r data.Pair) int {
+		return compareLiterals(l.Car(), r.Car())
+	})
 	args := data.Vector{}
-	for f, r, ok := a.Split(); ok; f, r, ok = r.Split() {
-		v := f.(data.Pair)
-		args = append(args, v.Car(), v.Cdr())
+	for _, p := range pairs {
+		args = append(args, p.Car(), p.Cdr())
 	}
 	f, err := resolveBuiltIn(e, objectSym)
 	if err != nil {
@@ -80,5 +87,14 @@ func Set(e encoder.Encoder, s *data.Set) error {
 	if err != nil {
 		return err
 	}
-	return callStatic(e, f, s.Members())
+	members := s.Members()
+	slices.SortStableFunc(members, compareLiterals)
+	return callStatic(e, f, members)
+}
+
+// compareLiterals orders the elements of an object or set literal, so that
+// the code generated for it doesn't depend on the order in which they're
+// hashed
+func compareLiterals(l, r ale.Value) int {
+	return cmp.Compare(data.ToQuotedString(l), data.ToQuotedString(r))
 }
@@ -3,22 +3,42 @@ package generate
 import (
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
+	"github.com/kode4food/ale/internal/compiler"
+	"github.com/kode4food/ale/internal/compiler/diagnostic"
 	"github.com/kode4food/ale/internal/compiler/encoder"
 	"github.com/kode4food/ale/internal/debug"
+	lang "github.com/kode4food/ale/internal/lang/env"
 	"github.com/kode4food/ale/internal/runtime/isa"
 )
 
+// WarnUndeclared is reported when a late binding environment compiles a
+// reference to a global that hasn't been declared yet
+const WarnUndeclared = "name not declared: %s"
+
 // Global encodes a global symbol constant or retrieval, depending on whether
-// the symbol is already bound in the environment
+// the symbol is already bound in the environment, and whether the environment
+// is late binding. A late binding environment also allows references to
+// globals that haven't been declared yet, reporting a Warning instead
 func Global(e encoder.Encoder, s data.Symbol) error {
-	entry, _, err := env.ResolveSymbol(e.Globals(), s)
+	entry, in, err := env.ResolveSymbol(e.Globals(), s)
 	if err != nil {
-		return err
+		if !e.Globals().Environment().LateBinding() {
+			return err
+		}
+		e.Warn(diagnostic.Undeclared, WarnUndeclared, s)
+		return globalValue(e, s)
 	}
-	if entry.IsBound() {
+	if entry.IsBound() && !isLateBound(e, in) {
 		v, _ := entry.Value()
+		if d, ok := v.(*compiler.Denied); ok {
+			return d
+		}
 		return Literal(e, v)
 	}
+	return globalValue(e, s)
+}
+
+func globalValue(e encoder.Encoder, s data.Symbol) error {
 	if err := Literal(e, s); err != nil {
 		return err
 	}
@@ -26,6 +46,11 @@ func Global(e encoder.Encoder, s data.Symbol) error {
 	return nil
 }
 
+func isLateBound(e encoder.Encoder, in env.Namespace) bool {
+	return e.Globals().Environment().LateBinding() &&
+		in.Domain() != lang.RootDomain
+}
+
 // Reference encodes a potential retrieval and dereference
 func Reference(e encoder.Encoder, l data.Local) error {
 	c, err := local(e, l)
@@ -0,0 +1,102 @@
+package generate
+
+import (
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/compiler/diagnostic"
+	"github.com/kode4food/ale/internal/compiler/encoder"
+	"github.com/kode4food/ale/internal/compiler/ir/analysis"
+	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/types"
+)
+
+// WarnArgumentType is reported when a call passes an argument that can never
+// satisfy the Type that the called procedure declares for it
+const WarnArgumentType = "argument %d expects %s, got %s"
+
+// CheckTypes infers the Types of the values produced by the instructions that
+// have been emitted since the start offset, given the Types of the arguments
+// of the procedure being encoded. It reports calls that pass arguments that
+// can never satisfy the Signatures of the procedures they call, and returns
+// the Type of the value that the instructions leave on the stack
+func CheckTypes(e encoder.Encoder, start int, args []ale.Type) ale.Type {
+	enc := e.Encode()
+	code := enc.Code[start:]
+	inferred, err := analysis.InferTypes(code, enc.Constants, args)
+	if err != nil {
+		return types.BasicAny // Verify will report the underlying problem
+	}
+	var loc *data.Location
+	for i, inst := range enc.Code {
+		oc, op := inst.Split()
+		if oc == isa.Pos {
+			loc = enc.Positions[op]
+			continue
+		}
+		argc, ok := callArgCount(oc, op)
+		if !ok || i < start {
+			continue
+		}
+		if stack, ok := inferred.StackAt(i - start); ok {
+			checkCallTypes(e, loc, stack, argc)
+		}
+	}
+	return inferred.Result
+}
+
+func checkCallTypes(
+	e encoder.Encoder, loc *data.Location, stack []ale.Type, argc int,
+) {
+	top := len(stack) - 1
+	callee, ok := stack[top].(*types.Applicable)
+	if !ok {
+		return
+	}
+	sig, ok := callee.Signature(argc)
+	if !ok {
+		return
+	}
+	for i := range argc {
+		want := sig.ParamType(i)
+		got := stack[top-1-i]
+		if types.Intersects(want, got) {
+			continue
+		}
+		warnAt(e, loc, diagnostic.Type, WarnArgumentType,
+			i+1, want.Name(), TypeName(got),
+		)
+	}
+}
+
+func callArgCount(oc isa.Opcode, op isa.Operand) (int, bool) {
+	switch oc {
+	case isa.Call:
+		return int(op), true
+	case isa.Call0, isa.Call1, isa.Call2, isa.Call3:
+		return isa.MustGetEffect(oc).Pop - 1, true
+	default:
+		return 0, false
+	}
+}
+
+func warnAt(
+	e encoder.Encoder, loc *data.Location, kind data.Keyword, format string,
+	args ...any,
+) {
+	if loc == nil {
+		e.Warn(kind, format, args...)
+		return
+	}
+	prev := e.SetLocation(loc)
+	e.Warn(kind, format, args...)
+	e.SetLocation(prev)
+}
+
+// TypeName returns the name of a Type for reporting. Literal Types are named
+// by their basic Types rather than by their values
+func TypeName(t ale.Type) string {
+	if l, ok := t.(*types.Literal); ok {
+		return l.Type().Name()
+	}
+	return t.Name()
+}
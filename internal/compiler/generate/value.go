@@ -11,6 +11,12 @@ import (
 
 // Value encodes an expression
 func Value(e encoder.Encoder, v ale.Value) error {
+	if l, ok := v.(*data.List); ok {
+		if loc, ok := l.Location(); ok {
+			prev := e.SetLocation(loc)
+			defer e.SetLocation(prev)
+		}
+	}
 	ns := e.Globals()
 	ex, err := macro.Expand(ns, v)
 	if err != nil {
@@ -0,0 +1,118 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kode4food/ale/internal/runtime/isa"
+)
+
+const (
+	// ErrJumpOutOfRange is raised when the analyzer verifies a finalized jump
+	// that targets an offset outside the instructions provided
+	ErrJumpOutOfRange = "jump target out of range: %d"
+
+	// ErrStackUnderflow is raised when the analyzer verifies an instruction
+	// that pops more values than the stack holds
+	ErrStackUnderflow = "stack underflow at offset: %d"
+
+	// ErrStackOverflow is raised when the analyzer verifies an instruction
+	// that pushes the stack beyond the size declared for it
+	ErrStackOverflow = "stack overflow at offset: %d"
+
+	// ErrInconsistentStack is raised when the analyzer verifies paths that
+	// join at an offset with different stack sizes
+	ErrInconsistentStack = "inconsistent stack size at offset: %d"
+
+	// ErrMissingExit is raised when the analyzer verifies a path that runs
+	// past the end of the instructions without exiting
+	ErrMissingExit = "instructions end without exiting"
+)
+
+// VerifyRunnable checks a finalized Runnable for validity. Unlike Verify,
+// which expects the structured output of the encoder, it follows every path
+// through the instructions, including the loops and tail calls that the
+// optimizer introduces. It checks that jumps target offsets within the
+// instructions, that the stack neither underflows nor exceeds the Runnable's
+// StackSize, that paths join with the same stack size, and that every path
+// ends in an exit
+func VerifyRunnable(run *isa.Runnable) error {
+	code := run.Code
+	sizes := make([]int, len(code))
+	for i := range sizes {
+		sizes[i] = -1
+	}
+	if len(code) == 0 {
+		return errors.New(ErrMissingExit)
+	}
+	sizes[0] = 0
+	pending := []int{0}
+	for len(pending) > 0 {
+		pc := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		next, size, err := stepRunnable(run, pc, sizes[pc])
+		if err != nil {
+			return err
+		}
+		for _, n := range next {
+			switch {
+			case sizes[n] == -1:
+				sizes[n] = size
+				pending = append(pending, n)
+			case sizes[n] != size:
+				return fmt.Errorf(ErrInconsistentStack, n)
+			}
+		}
+	}
+	return nil
+}
+
+func stepRunnable(run *isa.Runnable, pc int, size int) ([]int, int, error) {
+	oc, op := run.Code[pc].Split()
+	effect, err := isa.GetEffect(oc)
+	if err != nil {
+		return nil, 0, err
+	}
+	pop := effect.Pop
+	if effect.DPop {
+		pop += int(op)
+	}
+	if size < pop {
+		return nil, 0, fmt.Errorf(ErrStackUnderflow, pc)
+	}
+	size += effect.Push - pop
+	if size > int(run.StackSize) {
+		return nil, 0, fmt.Errorf(ErrStackOverflow, pc)
+	}
+
+	switch oc {
+	case isa.TailCall, isa.TailClos, isa.TailSelf:
+		return nil, size, nil
+	case isa.Jump:
+		next, err := jumpTarget(run.Code, op)
+		return []int{next}, size, err
+	case isa.CondJump:
+		next, err := jumpTarget(run.Code, op)
+		if err != nil {
+			return nil, 0, err
+		}
+		if pc+1 >= len(run.Code) {
+			return nil, 0, errors.New(ErrMissingExit)
+		}
+		return []int{pc + 1, next}, size, nil
+	}
+	if effect.Exit {
+		return nil, size, nil
+	}
+	if pc+1 >= len(run.Code) {
+		return nil, 0, errors.New(ErrMissingExit)
+	}
+	return []int{pc + 1}, size, nil
+}
+
+func jumpTarget(code isa.Instructions, op isa.Operand) (int, error) {
+	if int(op) >= len(code) {
+		return 0, fmt.Errorf(ErrJumpOutOfRange, op)
+	}
+	return int(op), nil
+}
@@ -0,0 +1,66 @@
+package analysis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/ale/internal/assert"
+	"github.com/kode4food/ale/internal/compiler/ir/analysis"
+	"github.com/kode4food/ale/internal/runtime/isa"
+)
+
+func TestVerifyRunnable(t *testing.T) {
+	as := assert.New(t)
+
+	e := assert.GetTestEncoder()
+	e.Emit(isa.ArgsLen)
+	e.Emit(isa.PosInt, 2)
+	e.Emit(isa.NumEq)
+	lbl := e.NewLabel()
+	e.Emit(isa.CondJump, lbl)
+	e.Emit(isa.Arg, 0)
+	e.Emit(isa.Return)
+	e.Emit(isa.Label, lbl)
+	e.Emit(isa.Arg, 1)
+	e.Emit(isa.Arg, 0)
+	e.Emit(isa.TailSelf, 2)
+
+	run, err := e.Encode().Runnable()
+	if as.NoError(err) {
+		as.NoError(analysis.VerifyRunnable(run))
+	}
+}
+
+func TestVerifyRunnableErrors(t *testing.T) {
+	as := assert.New(t)
+
+	verify := func(size isa.Operand, code ...isa.Instruction) error {
+		return analysis.VerifyRunnable(&isa.Runnable{
+			Code:      code,
+			StackSize: size,
+		})
+	}
+
+	as.EqualError(verify(0), analysis.ErrMissingExit)
+	as.EqualError(verify(1, isa.True.New()), analysis.ErrMissingExit)
+	as.EqualError(verify(1, isa.Return.New()),
+		fmt.Sprintf(analysis.ErrStackUnderflow, 0),
+	)
+	as.EqualError(
+		verify(1, isa.True.New(), isa.True.New(), isa.Return.New()),
+		fmt.Sprintf(analysis.ErrStackOverflow, 1),
+	)
+	as.EqualError(verify(0, isa.Jump.New(1)),
+		fmt.Sprintf(analysis.ErrJumpOutOfRange, 1),
+	)
+	as.EqualError(
+		verify(2,
+			isa.True.New(),
+			isa.CondJump.New(3),
+			isa.True.New(),
+			isa.Null.New(),
+			isa.Return.New(),
+		),
+		fmt.Sprintf(analysis.ErrInconsistentStack, 3),
+	)
+}
@@ -0,0 +1,381 @@
+package analysis
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/types"
+)
+
+type (
+	// Resulting is implemented by values that can describe the Type of the
+	// results that they return when called with a number of arguments
+	Resulting interface {
+		ResultType(argc int) ale.Type
+	}
+
+	// Types describes what could be inferred about the Types of the values
+	// that an isa.Instructions stream produces. Result is the Type of the
+	// values that the stream returns or leaves on the stack when it runs to
+	// its end
+	Types struct {
+		Result ale.Type
+		stacks [][]ale.Type
+	}
+
+	inference struct {
+		code     isa.Instructions
+		consts   data.Vector
+		args     []ale.Type
+		target   func(isa.Operand) (int, error)
+		states   []*typeState
+		returned ale.Type
+		ended    ale.Type
+	}
+
+	typeState struct {
+		stack  []ale.Type
+		locals map[isa.Operand]ale.Type
+		visits int
+	}
+)
+
+// maxTypeVisits is how many times the inferred Types at an instruction can
+// change before they're widened to types.BasicAny
+const maxTypeVisits = 16
+
+// InferTypes infers the Types of the values that an encoder's isa.Instructions
+// stream produces, given the Types of the arguments that it's called with.
+// Any argument without a Type is assumed to be types.BasicAny. The Result is
+// the Type of the value left on the stack when the stream runs to its end
+func InferTypes(
+	code isa.Instructions, consts data.Vector, args []ale.Type,
+) (*Types, error) {
+	in := &inference{
+		code:   code,
+		consts: consts,
+		args:   args,
+		target: func(op isa.Operand) (int, error) {
+			return findLabel(code, op)
+		},
+	}
+	if err := in.infer(); err != nil {
+		return nil, err
+	}
+	return in.types(in.ended), nil
+}
+
+// InferResult infers the Type of the values that a Runnable returns when it
+// is called with arguments of any Type
+func InferResult(run *isa.Runnable) (ale.Type, error) {
+	code := run.Code
+	in := &inference{
+		code:   code,
+		consts: run.Constants,
+		target: func(op isa.Operand) (int, error) {
+			if int(op) >= len(code) {
+				return 0, fmt.Errorf(ErrJumpOutOfRange, op)
+			}
+			return int(op), nil
+		},
+	}
+	if err := in.infer(); err != nil {
+		return nil, err
+	}
+	return in.types(in.returned).Result, nil
+}
+
+// StackAt returns the inferred Types of the values on the stack ahead of the
+// instruction at the specified offset, with the top of the stack last. If
+// the instruction is unreachable, false is returned
+func (t *Types) StackAt(pc int) ([]ale.Type, bool) {
+	if pc < 0 || pc >= len(t.stacks) || t.stacks[pc] == nil {
+		return nil, false
+	}
+	return t.stacks[pc], true
+}
+
+func (in *inference) infer() error {
+	in.states = make([]*typeState, len(in.code))
+	if len(in.code) == 0 {
+		return nil
+	}
+	in.states[0] = &typeState{locals: map[isa.Operand]ale.Type{}}
+	pending := []int{0}
+	for len(pending) > 0 {
+		pc := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		s := in.states[pc].clone()
+		next, err := in.step(pc, s)
+		if err != nil {
+			return err
+		}
+		for _, n := range next {
+			if n >= len(in.code) {
+				in.ended = joinTypes(in.ended, s.top())
+				continue
+			}
+			changed, err := in.merge(n, s)
+			if err != nil {
+				return err
+			}
+			if changed {
+				pending = append(pending, n)
+			}
+		}
+	}
+	return nil
+}
+
+func (in *inference) merge(pc int, s *typeState) (bool, error) {
+	cur := in.states[pc]
+	if cur == nil {
+		in.states[pc] = s.clone()
+		return true, nil
+	}
+	if len(cur.stack) != len(s.stack) {
+		return false, fmt.Errorf(ErrInconsistentStack, pc)
+	}
+	changed := false
+	for i, t := range s.stack {
+		if j := joinTypes(cur.stack[i], t); !j.Equal(cur.stack[i]) {
+			cur.stack[i] = j
+			changed = true
+		}
+	}
+	for op, t := range s.locals {
+		prev, ok := cur.locals[op]
+		if !ok {
+			cur.locals[op] = t
+			changed = true
+			continue
+		}
+		if j := joinTypes(prev, t); !j.Equal(prev) {
+			cur.locals[op] = j
+			changed = true
+		}
+	}
+	if changed {
+		cur.visits++
+		if cur.visits > maxTypeVisits {
+			cur.widen()
+		}
+	}
+	return changed, nil
+}
+
+func (in *inference) step(pc int, s *typeState) ([]int, error) {
+	oc, op := in.code[pc].Split()
+	effect, err := isa.GetEffect(oc)
+	if err != nil {
+		return nil, err
+	}
+	switch oc {
+	case isa.Arg:
+		s.push(in.arg(op))
+	case isa.ArgsRest, isa.Vector:
+		s.pop(effect.Pop + dynamicPop(effect, op))
+		s.push(types.BasicVector)
+	case isa.ArgsLen, isa.PosInt, isa.NegInt, isa.Zero:
+		s.push(types.BasicNumber)
+	case isa.Length, isa.Neg:
+		s.pop(1)
+		s.push(types.BasicNumber)
+	case isa.Add, isa.Div, isa.Mod, isa.Mul, isa.Sub:
+		s.pop(2)
+		s.push(types.BasicNumber)
+	case isa.True, isa.False:
+		s.push(types.BasicBoolean)
+	case isa.Empty, isa.Not:
+		s.pop(1)
+		s.push(types.BasicBoolean)
+	case isa.Eq, isa.NumEq, isa.NumGt, isa.NumGte, isa.NumLt, isa.NumLte:
+		s.pop(2)
+		s.push(types.BasicBoolean)
+	case isa.Null:
+		s.push(types.BasicNull)
+	case isa.Const:
+		s.push(typeOf(in.consts[op]))
+	case isa.Load:
+		s.push(s.local(op))
+	case isa.Store:
+		s.locals[op] = s.pop(1)[0]
+	case isa.Dup:
+		s.push(s.top())
+	case isa.Swap:
+		p := s.pop(2)
+		s.push(p[1], p[0])
+	case isa.Get, isa.Nth:
+		s.pop(2)
+		s.push(types.BasicAny, types.BasicBoolean)
+	case isa.Call0, isa.Call1, isa.Call2, isa.Call3:
+		in.call(s, effect.Pop-1)
+	case isa.Call:
+		in.call(s, int(op))
+	case isa.TailCall, isa.TailClos:
+		in.call(s, int(op))
+		in.returned = joinTypes(in.returned, s.top())
+		return nil, nil
+	case isa.TailSelf:
+		return nil, nil
+	case isa.Return:
+		in.returned = joinTypes(in.returned, s.top())
+	case isa.RetTrue, isa.RetFalse:
+		in.returned = joinTypes(in.returned, types.BasicBoolean)
+	case isa.RetNull:
+		in.returned = joinTypes(in.returned, types.BasicNull)
+	case isa.Jump:
+		next, err := in.target(op)
+		return []int{next}, err
+	case isa.CondJump:
+		s.pop(1)
+		next, err := in.target(op)
+		return []int{pc + 1, next}, err
+	default:
+		s.pop(effect.Pop + dynamicPop(effect, op))
+		for range effect.Push {
+			s.push(types.BasicAny)
+		}
+	}
+	if effect.Exit {
+		return nil, nil
+	}
+	return []int{pc + 1}, nil
+}
+
+func (in *inference) call(s *typeState, argc int) {
+	callee := s.pop(1)[0]
+	s.pop(argc)
+	s.push(resultOf(callee, argc))
+}
+
+func (in *inference) arg(op isa.Operand) ale.Type {
+	if int(op) < len(in.args) && in.args[op] != nil {
+		return in.args[op]
+	}
+	return types.BasicAny
+}
+
+func (in *inference) types(res ale.Type) *Types {
+	if res == nil {
+		res = types.BasicAny
+	}
+	stacks := make([][]ale.Type, len(in.states))
+	for pc, s := range in.states {
+		if s != nil {
+			stacks[pc] = append([]ale.Type{}, s.stack...)
+		}
+	}
+	return &Types{
+		Result: res,
+		stacks: stacks,
+	}
+}
+
+func (s *typeState) clone() *typeState {
+	return &typeState{
+		stack:  slices.Clone(s.stack),
+		locals: maps.Clone(s.locals),
+	}
+}
+
+func (s *typeState) push(t ...ale.Type) {
+	s.stack = append(s.stack, t...)
+}
+
+// pop removes values from the top of the stack, returning their Types with
+// the top of the stack first. Values that the stack doesn't hold are assumed
+// to be types.BasicAny, leaving their verification to Verify
+func (s *typeState) pop(n int) []ale.Type {
+	res := make([]ale.Type, n)
+	for i := range res {
+		l := len(s.stack) - 1
+		if l < 0 {
+			res[i] = types.BasicAny
+			continue
+		}
+		res[i] = s.stack[l]
+		s.stack = s.stack[:l]
+	}
+	return res
+}
+
+func (s *typeState) top() ale.Type {
+	if l := len(s.stack) - 1; l >= 0 {
+		return s.stack[l]
+	}
+	return types.BasicAny
+}
+
+func (s *typeState) local(op isa.Operand) ale.Type {
+	if t, ok := s.locals[op]; ok {
+		return t
+	}
+	return types.BasicAny
+}
+
+func (s *typeState) widen() {
+	for i := range s.stack {
+		s.stack[i] = types.BasicAny
+	}
+	for op := range s.locals {
+		s.locals[op] = types.BasicAny
+	}
+}
+
+func dynamicPop(e *isa.Effect, op isa.Operand) int {
+	if e.DPop {
+		return int(op)
+	}
+	return 0
+}
+
+func resultOf(callee ale.Type, argc int) ale.Type {
+	switch t := callee.(type) {
+	case *types.Applicable:
+		if s, ok := t.Signature(argc); ok {
+			return s.Result
+		}
+	case *types.Literal:
+		if r, ok := t.Value().(Resulting); ok {
+			return r.ResultType(argc)
+		}
+	}
+	return types.BasicAny
+}
+
+func typeOf(v ale.Value) ale.Type {
+	if v, ok := v.(ale.Typed); ok {
+		return v.Type()
+	}
+	return types.BasicAny
+}
+
+// joinTypes returns a Type that accepts both of the provided Types. A nil
+// Type is one that hasn't been inferred yet. Literal Types are widened to
+// their basic Types when they're joined with something they don't accept
+func joinTypes(l, r ale.Type) ale.Type {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.Accepts(r):
+		return l
+	case r.Accepts(l):
+		return r
+	default:
+		return types.MakeUnion(widenType(l), widenType(r))
+	}
+}
+
+func widenType(t ale.Type) ale.Type {
+	if l, ok := t.(*types.Literal); ok {
+		return l.Type()
+	}
+	return t
+}
@@ -0,0 +1,105 @@
+package analysis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/compiler/encoder"
+	"github.com/kode4food/ale/internal/compiler/generate"
+	"github.com/kode4food/ale/internal/compiler/ir/analysis"
+	"github.com/kode4food/ale/internal/runtime/isa"
+	"github.com/kode4food/ale/internal/types"
+)
+
+func TestInferTypes(t *testing.T) {
+	as := assert.New(t)
+
+	e := assert.GetTestEncoder()
+	e.Emit(isa.Arg, 1)
+	e.Emit(isa.Arg, 0)
+	e.Emit(isa.Add)
+	enc := e.Encode()
+
+	res, err := analysis.InferTypes(enc.Code, enc.Constants, []ale.Type{
+		types.BasicString, types.BasicNumber,
+	})
+	as.NoError(err)
+	as.Equal(types.BasicNumber, res.Result)
+
+	stack, ok := res.StackAt(2)
+	as.True(ok)
+	as.Equal([]ale.Type{types.BasicNumber, types.BasicString}, stack)
+
+	stack, ok = res.StackAt(0)
+	as.True(ok)
+	as.Empty(stack)
+
+	_, ok = res.StackAt(3)
+	as.False(ok)
+}
+
+func TestInferBranchTypes(t *testing.T) {
+	as := assert.New(t)
+
+	e := assert.GetTestEncoder()
+	as.NoError(generate.Branch(e,
+		func(encoder.Encoder) error { e.Emit(isa.Arg, 0); return nil },
+		func(encoder.Encoder) error { e.Emit(isa.PosInt, 1); return nil },
+		func(encoder.Encoder) error {
+			return generate.Literal(e, S("hello"))
+		},
+	))
+	pred := data.MakeTypePredicate(types.BasicNumber)
+	as.NoError(generate.Literal(e, pred))
+	e.Emit(isa.Call, 1)
+	enc := e.Encode()
+
+	res, err := analysis.InferTypes(enc.Code, enc.Constants, nil)
+	as.NoError(err)
+	as.Equal(types.BasicBoolean, res.Result)
+
+	stack, ok := res.StackAt(len(enc.Code) - 2)
+	as.True(ok)
+	as.Equal("union(number,string)", stack[0].Name())
+}
+
+func TestInferResult(t *testing.T) {
+	as := assert.New(t)
+
+	res, err := analysis.InferResult(&isa.Runnable{
+		Code: isa.Instructions{
+			isa.Zero.New(),
+			isa.Store.New(0),
+			isa.Arg.New(0),
+			isa.CondJump.New(6),
+			isa.Load.New(0),
+			isa.Return.New(),
+			isa.Const.New(0),
+			isa.Store.New(0),
+			isa.Jump.New(2),
+		},
+		Constants: data.Vector{S("looped")},
+	})
+	as.NoError(err)
+	as.Equal("union(number,string)", res.Name())
+
+	res, err = analysis.InferResult(&isa.Runnable{
+		Code: isa.Instructions{
+			isa.Arg.New(0),
+			isa.CondJump.New(3),
+			isa.RetNull.New(),
+			isa.RetTrue.New(),
+		},
+	})
+	as.NoError(err)
+	as.Equal("union(boolean,null)", res.Name())
+
+	_, err = analysis.InferResult(&isa.Runnable{
+		Code: isa.Instructions{isa.Jump.New(5)},
+	})
+	as.EqualError(err, fmt.Sprintf(analysis.ErrJumpOutOfRange, 5))
+}
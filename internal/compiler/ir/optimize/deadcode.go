@@ -48,31 +48,34 @@ func replaceRedundantLocals(c isa.Instructions) (isa.Instructions, bool) {
 			labels[inst.Operand()] = idx
 
 		case isa.Store:
-			if idx == len(c)-1 || c[idx+1].Opcode() != isa.Load {
+			n, ok := nextInstruction(c, idx)
+			if !ok || c[n].Opcode() != isa.Load {
 				break
 			}
 			op := inst.Operand()
-			next := c[idx+1]
-			if next.Operand() != op || hasConflictingLoadStore(c[idx+2:], op) {
+			next := c[n]
+			if next.Operand() != op || hasConflictingLoadStore(c[n+1:], op) {
 				break
 			}
-			c = slices.Concat(c[:idx], c[idx+2:])
+			c = slices.Concat(c[:idx], c[idx+1:n], c[n+1:])
 			dirty = true
 			continue
 
 		case isa.Load:
-			if idx == len(c)-1 || c[idx+1].Opcode() != isa.Store {
+			n, ok := nextInstruction(c, idx)
+			if !ok || c[n].Opcode() != isa.Store {
 				break
 			}
-			next := c[idx+1]
+			next := c[n]
 			from := inst.Operand()
 			to := next.Operand()
-			if hasConflictingStore(c[idx+2:], from, to) {
+			if hasConflictingStore(c[n+1:], from, to) {
 				break
 			}
 			c = slices.Concat(
 				c[:idx],
-				mapIneffectiveLoads(c[idx+2:], isa.Load.New(to), inst),
+				c[idx+1:n],
+				mapIneffectiveLoads(c[n+1:], isa.Load.New(to), inst),
 			)
 			dirty = true
 			continue
@@ -86,6 +89,16 @@ func replaceRedundantLocals(c isa.Instructions) (isa.Instructions, bool) {
 	return res, dirty
 }
 
+// nextInstruction returns the index of the next Instruction that isn't a Pos
+func nextInstruction(c isa.Instructions, idx int) (int, bool) {
+	for n := idx + 1; n < len(c); n++ {
+		if c[n].Opcode() != isa.Pos {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
 func hasConflictingLoadStore(c isa.Instructions, op isa.Operand) bool {
 	load := isa.Load.New(op)
 	store := isa.Store.New(op)
@@ -60,7 +60,7 @@ func (m *inlineMapper) perform(i isa.Instructions) isa.Instructions {
 	argc := getCallArgCount(i[1])
 	c := m.relabel(p.Code)
 	c = paramBranchFor(c, argc)
-	if hasTailCallInstruction(c) {
+	if hasTailOrSelfCall(c) {
 		return i
 	}
 	c = m.reindex(p, c)
@@ -216,10 +216,10 @@ func immediatelyPopArg(c isa.Instructions) (isa.Instructions, bool) {
 	return nil, false
 }
 
-func hasTailCallInstruction(c isa.Instructions) bool {
+func hasTailOrSelfCall(c isa.Instructions) bool {
 	return slices.ContainsFunc(c, func(i isa.Instruction) bool {
 		switch i.Opcode() {
-		case isa.TailCall, isa.TailClos:
+		case isa.TailCall, isa.TailClos, isa.TailSelf, isa.CallSelf:
 			return true
 		default:
 			return false
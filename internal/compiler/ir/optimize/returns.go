@@ -1,17 +1,21 @@
 package optimize
 
 import (
+	"slices"
+
+	"github.com/kode4food/ale/internal/compiler"
 	"github.com/kode4food/ale/internal/compiler/encoder"
 	"github.com/kode4food/ale/internal/compiler/ir/visitor"
 	"github.com/kode4food/ale/internal/runtime/isa"
 )
 
-type returnSplitter struct{}
+type returnSplitter struct{ *encoder.Encoded }
 
-// splitReturns rolls standalone returns into the preceding branches
+// splitReturns rolls standalone returns into the preceding branches, along
+// with any check of the result that they return
 func splitReturns(e *encoder.Encoded) *encoder.Encoded {
 	root := visitor.Branched(e.Code)
-	visitor.Visit(root, returnSplitter{})
+	visitor.Visit(root, returnSplitter{e})
 	return e.WithCode(root.Code())
 }
 
@@ -20,52 +24,72 @@ func (returnSplitter) ExitRoot(visitor.Node)             {}
 func (returnSplitter) EnterBranches(visitor.Branches)    {}
 func (returnSplitter) Instructions(visitor.Instructions) {}
 
-func (returnSplitter) ExitBranches(b visitor.Branches) {
+func (r returnSplitter) ExitBranches(b visitor.Branches) {
 	i, ok := b.Epilogue().(visitor.Instructions)
 	if !ok {
 		return
 	}
 	code := i.Code()
-	if len(code) != 1 {
-		return
-	}
-	if oc := code[0].Opcode(); oc == isa.Return {
+	if r.isReturn(code) {
 		i.Set(isa.Instructions{})
-		addReturnToBranches(b)
+		addReturnToBranches(b, slices.Clone(code))
+	}
+}
+
+func (r returnSplitter) isReturn(code isa.Instructions) bool {
+	code = slices.DeleteFunc(slices.Clone(code), func(i isa.Instruction) bool {
+		return i.Opcode() == isa.Pos
+	})
+	switch len(code) {
+	case 1:
+		return code[0].Opcode() == isa.Return
+	case 3:
+		return isResultCheck(r.Encoded, code[0], code[1]) &&
+			code[2].Opcode() == isa.Return
+	default:
+		return false
+	}
+}
+
+// isResultCheck returns whether a pair of instructions calls a ResultCheck
+func isResultCheck(e *encoder.Encoded, c, call isa.Instruction) bool {
+	oc, op := c.Split()
+	if oc != isa.Const || call != isa.Call.New(1) {
+		return false
 	}
+	_, ok := e.Constants[op].(*compiler.ResultCheck)
+	return ok
 }
 
-func addReturnToNode(n visitor.Node) {
+func addReturnToNode(n visitor.Node, ret isa.Instructions) {
 	switch n := n.(type) {
 	case visitor.Branches:
-		addReturnToBranches(n)
+		addReturnToBranches(n, ret)
 	case visitor.Instructions:
-		addReturnToInstructions(n)
+		addReturnToInstructions(n, ret)
 	}
 }
 
-func addReturnToBranches(b visitor.Branches) {
-	if addReturnToEpilogue(b.Epilogue()) {
+func addReturnToBranches(b visitor.Branches, ret isa.Instructions) {
+	if addReturnToEpilogue(b.Epilogue(), ret) {
 		return
 	}
-	addReturnToNode(b.ThenBranch())
-	addReturnToNode(b.ElseBranch())
+	addReturnToNode(b.ThenBranch(), ret)
+	addReturnToNode(b.ElseBranch(), ret)
 }
 
-func addReturnToEpilogue(n visitor.Node) bool {
+func addReturnToEpilogue(n visitor.Node, ret isa.Instructions) bool {
 	if i, ok := n.(visitor.Instructions); ok {
 		if len(i.Code()) > 0 {
-			addReturnToInstructions(i)
+			addReturnToInstructions(i, ret)
 			return true
 		}
 		return false
 	}
-	addReturnToBranches(n.(visitor.Branches))
+	addReturnToBranches(n.(visitor.Branches), ret)
 	return true
 }
 
-func addReturnToInstructions(i visitor.Instructions) {
-	code := i.Code()
-	code = append(code, isa.Return.New())
-	i.Set(code)
+func addReturnToInstructions(i visitor.Instructions, ret isa.Instructions) {
+	i.Set(slices.Concat(i.Code(), ret))
 }
@@ -19,6 +19,10 @@ var (
 		{visitor.AnyOpcode}, tailCallOpcode, {isa.Return},
 	}
 
+	checkedSelfCallPattern = visitor.Pattern{
+		{isa.CallSelf}, {isa.Const}, {isa.Call}, {isa.Return},
+	}
+
 	selfCallsInTailPosition = globalReplace(
 		visitor.Pattern{{isa.CallSelf}, {isa.Return}},
 		func(i isa.Instructions) isa.Instructions {
@@ -52,6 +56,21 @@ func (m tailCallMapper) perform(i isa.Instructions) isa.Instructions {
 	}
 }
 
+// uncheckedSelfCalls removes the ResultChecks that follow self-calls in tail
+// position. The called procedure has already checked its own result, and
+// removing the check allows the self-call to become a tail call
+func uncheckedSelfCalls(e *encoder.Encoded) *encoder.Encoded {
+	r := visitor.Replace(checkedSelfCallPattern,
+		func(i isa.Instructions) isa.Instructions {
+			if !isResultCheck(e, i[1], i[2]) {
+				return i
+			}
+			return isa.Instructions{i[0], i[3]}
+		},
+	)
+	return performReplace(e, r)
+}
+
 func (m tailCallMapper) canTailCall(i isa.Instruction) (*vm.Closure, bool) {
 	if oc, op := i.Split(); oc == isa.Const {
 		c, ok := m.Constants[op].(*vm.Closure)
@@ -3,8 +3,12 @@ package optimize_test
 import (
 	"testing"
 
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/assert"
 	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/compiler"
+	"github.com/kode4food/ale/internal/compiler/encoder"
 	"github.com/kode4food/ale/internal/compiler/generate"
 	"github.com/kode4food/ale/internal/compiler/ir/optimize"
 	"github.com/kode4food/ale/internal/runtime/isa"
@@ -24,3 +28,33 @@ func TestTailCalls(t *testing.T) {
 		isa.TailClos.New(2),
 	}, optimize.Encoded(e1.Encode()).Code)
 }
+
+func TestCheckedSelfCalls(t *testing.T) {
+	as := assert.New(t)
+
+	e1 := assert.GetTestEncoder()
+	check := &compiler.ResultCheck{Procedure: data.MakeProcedure(
+		func(args ...ale.Value) ale.Value { return args[0] }, 1,
+	)}
+	as.NoError(generate.Branch(e1,
+		func(encoder.Encoder) error { e1.Emit(isa.True); return nil },
+		func(encoder.Encoder) error { e1.Emit(isa.CallSelf, 0); return nil },
+		func(encoder.Encoder) error { e1.Emit(isa.Zero); return nil },
+	))
+	as.NoError(generate.Literal(e1, check))
+	e1.Emit(isa.Call, 1)
+	e1.Emit(isa.Return)
+
+	as.Instructions(isa.Instructions{
+		isa.True.New(),
+		isa.CondJump.New(0),
+		isa.Zero.New(),
+		isa.Const.New(0),
+		isa.Call1.New(),
+		isa.Return.New(),
+		isa.Jump.New(1),
+		isa.Label.New(0),
+		isa.TailSelf.New(0),
+		isa.Label.New(1),
+	}, optimize.Encoded(e1.Encode()).Code)
+}
@@ -24,7 +24,9 @@ type (
 const AnyOpcode = isa.OpcodeMask + 1
 
 // Replace visits all Instruction nodes and if any of the instructions therein
-// match the provided Pattern, they will be replaced using the provided Mapper
+// match the provided Pattern, they will be replaced using the provided Mapper.
+// Pos instructions are transparent to the matching process. Any found within
+// a match are withheld from the Mapper and placed ahead of its result
 func Replace(pattern Pattern, mapper Mapper) *Replacer {
 	return &Replacer{
 		pattern: pattern,
@@ -43,8 +45,12 @@ func (r *Replacer) Instructions(i Instructions) {
 	var state, start, found int
 	res := isa.Instructions{}
 	for pc := 0; pc < len(code); {
-		inst := code[pc]
-		if oc := inst.Opcode(); !pattern.matchesState(oc, state) {
+		oc := code[pc].Opcode()
+		if oc == isa.Pos {
+			pc++
+			continue
+		}
+		if !pattern.matchesState(oc, state) {
 			if state == 0 {
 				pc++
 			} else {
@@ -60,8 +66,10 @@ func (r *Replacer) Instructions(i Instructions) {
 			state++
 			continue
 		}
+		pos, matched := splitPositions(code[found:pc])
 		res = append(res, code[start:found]...)
-		res = append(res, r.mapper(code[found:pc])...)
+		res = append(res, pos...)
+		res = append(res, r.mapper(matched)...)
 		start = pc
 		state = 0
 	}
@@ -69,6 +77,21 @@ func (r *Replacer) Instructions(i Instructions) {
 	i.Set(res)
 }
 
+func splitPositions(
+	code isa.Instructions,
+) (isa.Instructions, isa.Instructions) {
+	var pos isa.Instructions
+	res := make(isa.Instructions, 0, len(code))
+	for _, inst := range code {
+		if inst.Opcode() == isa.Pos {
+			pos = append(pos, inst)
+			continue
+		}
+		res = append(res, inst)
+	}
+	return pos, res
+}
+
 func (p Pattern) matchesState(opcode isa.Opcode, state int) bool {
 	if len(p[state]) == 1 && p[state][0] == AnyOpcode {
 		return true
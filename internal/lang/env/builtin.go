@@ -5,36 +5,127 @@ import "github.com/kode4food/ale/data"
 const (
 	Include = data.Local("#include")
 
-	Bytes       = data.Local("bytes")
-	Chan        = data.Local("chan")
-	CurrentTime = data.Local("current-time")
-	Defer       = data.Local("%defer")
-	GenSym      = data.Local("gensym")
-	Go          = data.Local("%go")
-	IsA         = data.Local("%is-a")
-	List        = data.Local("list")
-	Macro       = data.Local("macro")
-	Object      = data.Local("object")
-	Read        = data.Local("read")
-	Recover     = data.Local("recover")
-	ReaderStr   = data.Local("str!")
-	Set         = data.Local("set")
-	Str         = data.Local("str")
-	Sym         = data.Local("sym")
-	TypeOf      = data.Local("%type-of")
-	Vector      = data.Local("vector")
+	AddWatch      = data.Local("add-watch")
+	Atom          = data.Local("atom")
+	Bytes         = data.Local("bytes")
+	Cancelled     = data.Local("cancelled?")
+	Chan          = data.Local("chan")
+	Compare       = data.Local("compare")
+	CompareAndSet = data.Local("compare-and-set!")
+	CurrentTime   = data.Local("current-time")
+	Defer         = data.Local("%defer")
+	Deref         = data.Local("deref")
+	Disasm        = data.Local("disasm")
+	DoneChan      = data.Local("done-chan")
+	Error         = data.Local("error")
+	ErrorCause    = data.Local("error-cause")
+	ErrorKind     = data.Local("error-kind")
+	GenSym        = data.Local("gensym")
+	Go            = data.Local("%go")
+	IsA           = data.Local("%is-a")
+	List          = data.Local("list")
+	Macro         = data.Local("macro")
+	Object        = data.Local("object")
+	Profile       = data.Local("%profile")
+	Read          = data.Local("read")
+	Recover       = data.Local("recover")
+	ReaderStr     = data.Local("str!")
+	RemoveWatch   = data.Local("remove-watch")
+	Reset         = data.Local("reset!")
+	Select        = data.Local("%select")
+	Set           = data.Local("set")
+	Sort          = data.Local("sort")
+	Str           = data.Local("str")
+	Swap          = data.Local("swap!")
+	Sym           = data.Local("sym")
+	TypeOf        = data.Local("%type-of")
+	Vector        = data.Local("vector")
 
 	SyntaxQuote = data.Local("syntax-quote")
 
-	Asm           = data.Local("asm")
-	Eval          = data.Local("eval")
-	Import        = data.Local("import")
-	Lambda        = data.Local("lambda")
-	Let           = data.Local("let")
-	LetMutual     = data.Local("let-rec")
-	MacroExpand1  = data.Local("macroexpand-1")
-	MacroExpand   = data.Local("macroexpand")
-	Special       = data.Local("special")
-	Declared      = data.Local("declared")
-	MakeNamespace = data.Local("%mk-ns")
+	JSONDomain    = data.Local("json")
+	JSONRead      = data.Local("read")
+	JSONReadLines = data.Local("read-lines")
+	JSONWrite     = data.Local("write")
+
+	MathDomain         = data.Local("math")
+	MathAbs            = data.Local("abs")
+	MathAcos           = data.Local("acos")
+	MathAsin           = data.Local("asin")
+	MathAtan           = data.Local("atan")
+	MathBitAnd         = data.Local("bit-and")
+	MathBitNot         = data.Local("bit-not")
+	MathBitOr          = data.Local("bit-or")
+	MathBitXor         = data.Local("bit-xor")
+	MathCeil           = data.Local("ceil")
+	MathCos            = data.Local("cos")
+	MathDenominator    = data.Local("denominator")
+	MathE              = data.Local("e")
+	MathExactToInexact = data.Local("exact->inexact")
+	MathExp            = data.Local("exp")
+	MathExpt           = data.Local("expt")
+	MathFloor          = data.Local("floor")
+	MathGCD            = data.Local("gcd")
+	MathInexactToExact = data.Local("inexact->exact")
+	MathLCM            = data.Local("lcm")
+	MathLog            = data.Local("log")
+	MathMax            = data.Local("max")
+	MathMin            = data.Local("min")
+	MathNumerator      = data.Local("numerator")
+	MathPi             = data.Local("pi")
+	MathPow            = data.Local("pow")
+	MathRound          = data.Local("round")
+	MathShiftLeft      = data.Local("shift-left")
+	MathShiftRight     = data.Local("shift-right")
+	MathSin            = data.Local("sin")
+	MathSqrt           = data.Local("sqrt")
+	MathTan            = data.Local("tan")
+	MathTruncate       = data.Local("truncate")
+
+	RegexDomain  = data.Local("regex")
+	RegexCompile = data.Local("compile")
+	RegexFind    = data.Local("find")
+	RegexFindAll = data.Local("find-all")
+	RegexGroups  = data.Local("groups")
+	RegexIsMatch = data.Local("match?")
+	RegexReplace = data.Local("replace")
+	RegexSplit   = data.Local("split")
+
+	StringDomain      = data.Local("string")
+	StringEndsWith    = data.Local("ends-with?")
+	StringFormat      = data.Local("format")
+	StringIndexOf     = data.Local("index-of")
+	StringJoin        = data.Local("join")
+	StringLastIndexOf = data.Local("last-index-of")
+	StringLower       = data.Local("lower")
+	StringPadLeft     = data.Local("pad-left")
+	StringPadRight    = data.Local("pad-right")
+	StringReplace     = data.Local("replace")
+	StringSplit       = data.Local("split")
+	StringStartsWith  = data.Local("starts-with?")
+	StringTrim        = data.Local("trim")
+	StringTrimLeft    = data.Local("trim-left")
+	StringTrimRight   = data.Local("trim-right")
+	StringUpper       = data.Local("upper")
+
+	Alias        = data.Local("alias")
+	Asm          = data.Local("asm")
+	Eval         = data.Local("eval")
+	Import       = data.Local("import")
+	Require      = data.Local("require")
+	Lambda       = data.Local("lambda")
+	Let          = data.Local("let")
+	LetMutual    = data.Local("let-rec")
+	MacroExpand1 = data.Local("macroexpand-1")
+	MacroExpand  = data.Local("macroexpand")
+	Special      = data.Local("special")
+	Warn         = data.Local("%warn")
+
+	Declared        = data.Local("declared")
+	Entries         = data.Local("entries")
+	MakeNamespace   = data.Local("%mk-ns")
+	RemoveNamespace = data.Local("remove-namespace")
+	Resolve         = data.Local("resolve")
+	Unbind          = data.Local("unbind")
+	Undeclare       = data.Local("undeclare")
 )
@@ -17,4 +17,6 @@ const (
 	In   = data.Local("*in*")
 	Out  = data.Local("*out*")
 	Err  = data.Local("*err*")
+
+	RequirePath = data.Local("*require-path*")
 )
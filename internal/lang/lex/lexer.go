@@ -87,6 +87,7 @@ var (
 	}
 
 	Values = Matchers{
+		patternMatcher(lang.Regex, regexState),
 		patternMatcher(lang.String, stringState),
 		patternMatcher(lang.Ratio, ratioState),
 		patternMatcher(lang.Float, floatState),
@@ -196,20 +197,18 @@ func anyCharMatcher(t tokenizer) Matcher {
 }
 
 func exactMatcher(p string, t tokenizer) Matcher {
-	token := t(p)
 	return func(input string) (*Token, string) {
 		if input == p {
-			return token, ``
+			return t(p), ``
 		}
 		return nil, input
 	}
 }
 
 func prefixMatcher(p string, t tokenizer) Matcher {
-	token := t(p)
 	return func(input string) (*Token, string) {
 		if strings.HasPrefix(input, p) {
-			return token, input[len(p):]
+			return t(p), input[len(p):]
 		}
 		return nil, input
 	}
@@ -251,6 +250,20 @@ func stringState(m string) *Token {
 	return String.FromValue(m, data.String(s))
 }
 
+func regexState(m string) *Token {
+	eos := len(m) - 1
+	if len(m) <= len(lang.RegexStart) || m[eos] != '"' {
+		err := data.String(ErrStringNotTerminated.Error())
+		return Error.FromValue(m, err)
+	}
+	src := strings.ReplaceAll(m[len(lang.RegexStart):eos], `\"`, `"`)
+	res, err := data.CompileRegex(src)
+	if err != nil {
+		return Error.FromValue(m, data.String(err.Error()))
+	}
+	return Regex.FromValue(m, res)
+}
+
 func ratioState(m string) *Token {
 	res, err := data.ParseRatio(m)
 	return tokenizeNumber(m, res, err)
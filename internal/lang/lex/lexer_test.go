@@ -116,6 +116,26 @@ func TestStrings(t *testing.T) {
 	})
 }
 
+func TestRegex(t *testing.T) {
+	as := assert.New(t)
+	l := lex.StripWhitespace(
+		read.MustTokenize(` #"\d+(\.\d+)?" #"say \"hi\"" "\d"`),
+	)
+	assertTokenSequence(t, l, []*lex.Token{
+		T(lex.Regex, data.MustCompileRegex(`\d+(\.\d+)?`)),
+		T(lex.Regex, data.MustCompileRegex(`say "hi"`)),
+		T(lex.String, S(`d`)),
+	})
+
+	v := sequence.ToVector(l)
+	as.Equal(`say "hi"`, v[1].(*lex.Token).Value().(*data.Regex).Source())
+
+	l = read.MustTokenize(`#"(unclosed"`)
+	assertTokenSequence(t, l, []*lex.Token{
+		T(lex.Error, S("error parsing regexp")),
+	})
+}
+
 func TestMultiLine(t *testing.T) {
 	as := assert.New(t)
 
@@ -26,6 +26,7 @@ const (
 	Identifier
 	Dot
 	String
+	Regex
 	Number
 	ListStart
 	ListEnd
@@ -8,18 +8,31 @@ import (
 
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/types"
 )
 
 type (
+	// ParamCase describes one of the parameter cases of a Lambda. Types
+	// holds the annotated Type of each of its Params, and Result the
+	// annotated Type of its Body. Anything not annotated is types.BasicAny
 	ParamCase struct {
 		Signature ale.Value
 		Body      data.Sequence
+		Result    ale.Type
 		Params    data.Locals
+		Types     []ale.Type
 		Rest      bool
 	}
 
 	ParamCases struct {
-		Cases   []*ParamCase
+		Cases []*ParamCase
+		Arity
+	}
+
+	// Arity describes the argument counts that a set of ParamCases accepts.
+	// Fixed is a bitmap of the accepted fixed counts, while any count of at
+	// least LowRest is accepted if HasRest is set
+	Arity struct {
 		Fixed   []uint8
 		HasRest bool
 		LowRest int
@@ -34,14 +47,14 @@ const (
 	ErrNoCasesDefined = "no parameter cases defined"
 
 	// ErrUnexpectedCaseSyntax is raised when a call to Lambda doesn't include
-	// a proper parameter case initializer. If it first encounters a Vector,
-	// the parsing will assume multiple parameter cases, otherwise it will
-	// assume a single parameter case
+	// a proper parameter case initializer. If it first encounters a Vector
+	// that isn't a typed parameter, the parsing will assume multiple
+	// parameter cases, otherwise it will assume a single parameter case
 	ErrUnexpectedCaseSyntax = "unexpected case syntax: %s"
 
 	// ErrUnexpectedParamSyntax is raised when a Lambda parameter case is
 	// represented by an unexpected syntax. Valid syntax representations are
-	// data.List, data.Cons, or data.Local
+	// data.List, data.Cons, data.Local, or a typed data.Vector
 	ErrUnexpectedParamSyntax = "unexpected parameter syntax: %s"
 
 	// ErrNoCaseBodyDefined is raised when a Lambda parameter case defines its
@@ -69,12 +82,19 @@ func ParseCases(s data.Sequence) (*ParamCases, error) {
 	}
 	res := new(ParamCases)
 	f := s.Car()
-	switch f.(type) {
+	switch f := f.(type) {
 	case *data.List, *data.Cons, data.Local:
 		return res, addParsedCase(res, s)
 	case data.Vector:
+		if isTypedParam(f) {
+			return res, addParsedCase(res, s)
+		}
 		for f, r, ok := s.Split(); ok; f, r, ok = r.Split() {
-			if err := addParsedCase(res, f.(data.Vector)); err != nil {
+			c, ok := f.(data.Vector)
+			if !ok {
+				return nil, fmt.Errorf(ErrUnexpectedCaseSyntax, f)
+			}
+			if err := addParsedCase(res, c); err != nil {
 				return nil, err
 			}
 		}
@@ -100,11 +120,11 @@ func (pc *ParamCases) MakeArgFetchers() []ArgFetcher {
 	return res
 }
 
-func (pc *ParamCases) MakeArityChecker() data.ArityChecker {
-	if pc.HasRest {
-		return pc.makeRestChecker()
+func (a *Arity) MakeArityChecker() data.ArityChecker {
+	if a.HasRest {
+		return a.makeRestChecker()
 	}
-	return pc.makeFixedChecker()
+	return a.makeFixedChecker()
 }
 
 func (pc *ParamCases) addParamCase(added *ParamCase) error {
@@ -121,26 +141,26 @@ func (pc *ParamCases) addParamCase(added *ParamCase) error {
 	return nil
 }
 
-func (pc *ParamCases) isReachable(i int, isRest bool) bool {
-	if len(pc.Cases) == 0 {
+func (a *Arity) isReachable(i int, isRest bool) bool {
+	if len(a.Fixed) == 0 && !a.HasRest {
 		return true
 	}
-	if pc.HasRest {
-		return i < pc.LowRest
+	if a.HasRest {
+		return i < a.LowRest
 	}
 	if isRest {
 		return true
 	}
 	index, offset := i/arityBits, i%arityBits
-	if index < len(pc.Fixed) {
-		return (pc.Fixed[index] & (1 << offset)) == 0
+	if index < len(a.Fixed) {
+		return (a.Fixed[index] & (1 << offset)) == 0
 	}
 	return true
 }
 
-func (pc *ParamCases) makeFixedChecker() data.ArityChecker {
-	fixed := pc.Fixed
-	signatures := pc.signatures()
+func (a *Arity) makeFixedChecker() data.ArityChecker {
+	fixed := a.Fixed
+	signatures := a.signatures()
 	return func(i int) error {
 		index, offset := i/arityBits, i%arityBits
 		if index >= len(fixed) || fixed[index]&(1<<offset) == 0 {
@@ -150,9 +170,9 @@ func (pc *ParamCases) makeFixedChecker() data.ArityChecker {
 	}
 }
 
-func (pc *ParamCases) makeRestChecker() data.ArityChecker {
-	lowRest := pc.LowRest
-	fixedChecker := pc.makeFixedChecker()
+func (a *Arity) makeRestChecker() data.ArityChecker {
+	lowRest := a.LowRest
+	fixedChecker := a.makeFixedChecker()
 	return func(i int) error {
 		if i >= lowRest {
 			return nil
@@ -161,42 +181,42 @@ func (pc *ParamCases) makeRestChecker() data.ArityChecker {
 	}
 }
 
-func (pc *ParamCases) addFixed(i int) {
+func (a *Arity) addFixed(i int) {
 	index, offset := i/arityBits, i%arityBits
-	for len(pc.Fixed) <= index {
-		pc.Fixed = append(pc.Fixed, 0)
+	for len(a.Fixed) <= index {
+		a.Fixed = append(a.Fixed, 0)
 	}
-	pc.Fixed[index] |= 1 << offset
+	a.Fixed[index] |= 1 << offset
 }
 
-func (pc *ParamCases) addRest(i int) {
-	if pc.HasRest {
-		pc.LowRest = min(pc.LowRest, i)
+func (a *Arity) addRest(i int) {
+	if a.HasRest {
+		a.LowRest = min(a.LowRest, i)
 	} else {
-		pc.LowRest = i
-		pc.HasRest = true
+		a.LowRest = i
+		a.HasRest = true
 	}
 }
 
-func (pc *ParamCases) signatures() string {
+func (a *Arity) signatures() string {
 	var res []string
-	for _, r := range pc.fixedRanges() {
-		if pc.HasRest && r[1] >= pc.LowRest-1 {
+	for _, r := range a.fixedRanges() {
+		if a.HasRest && r[1] >= a.LowRest-1 {
 			res = append(res, formatOrMore(r[0]))
 			return strings.Join(res, ", ")
 		}
 		res = append(res, formatRange(r))
 	}
 
-	if pc.HasRest {
-		res = append(res, formatOrMore(pc.LowRest))
+	if a.HasRest {
+		res = append(res, formatOrMore(a.LowRest))
 	}
 
 	return strings.Join(res, ", ")
 }
 
-func (pc *ParamCases) fixedRanges() [][2]int {
-	fixed := pc.fixedSet()
+func (a *Arity) fixedRanges() [][2]int {
+	fixed := a.fixedSet()
 	if len(fixed) == 0 {
 		return [][2]int{}
 	}
@@ -213,11 +233,11 @@ func (pc *ParamCases) fixedRanges() [][2]int {
 	return res
 }
 
-func (pc *ParamCases) fixedSet() []int {
+func (a *Arity) fixedSet() []int {
 	var res []int
-	for i := range len(pc.Fixed) * arityBits {
+	for i := range len(a.Fixed) * arityBits {
 		index, offset := i/arityBits, i%arityBits
-		if pc.Fixed[index]&(1<<offset) != 0 {
+		if a.Fixed[index]&(1<<offset) != 0 {
 			res = append(res, i)
 		}
 	}
@@ -226,21 +246,48 @@ func (pc *ParamCases) fixedSet() []int {
 
 func parseParamCase(s data.Sequence) (*ParamCase, error) {
 	f, body, _ := s.Split()
-	argNames, restArg, err := parseParamNames(f)
+	argNames, argTypes, restArg, err := parseParamNames(f)
 	if err != nil {
 		return nil, err
 	}
 	if body.IsEmpty() {
 		return nil, fmt.Errorf(ErrNoCaseBodyDefined, f)
 	}
+	result, body, err := parseResultType(body)
+	if err != nil {
+		return nil, err
+	}
 	return &ParamCase{
 		Signature: f,
 		Params:    argNames,
+		Types:     argTypes,
 		Rest:      restArg,
+		Result:    result,
 		Body:      body,
 	}, nil
 }
 
+// IsTyped returns whether any of the ParamCase's Params or its Result have
+// been annotated with a Type other than types.BasicAny
+func (c *ParamCase) IsTyped() bool {
+	if !isAny(c.Result) {
+		return true
+	}
+	return slices.ContainsFunc(c.Types, func(t ale.Type) bool {
+		return !isAny(t)
+	})
+}
+
+// TypeSignature returns a description of the ParamCase that is suitable for
+// building an Applicable Type
+func (c *ParamCase) TypeSignature() types.Signature {
+	return types.Signature{
+		Params:    c.Types,
+		Result:    c.Result,
+		TakesRest: c.Rest,
+	}
+}
+
 func (c *ParamCase) fixedArgs() data.Locals {
 	if c.Rest {
 		return c.Params[0 : len(c.Params)-1]
@@ -279,43 +326,115 @@ func (c *ParamCase) makeArgFetcher() ArgFetcher {
 	}
 }
 
-func parseParamNames(v ale.Value) (data.Locals, bool, error) {
+func parseParamNames(v ale.Value) (data.Locals, []ale.Type, bool, error) {
 	switch v := v.(type) {
 	case data.Local:
-		return data.Locals{v}, true, nil
+		return data.Locals{v}, []ale.Type{types.BasicAny}, true, nil
+	case data.Vector:
+		n, t, err := parseParamName(v)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return data.Locals{n}, []ale.Type{t}, true, nil
 	case *data.List:
-		return parseListParamNames(v), false, nil
+		n, t, err := parseListParamNames(v)
+		return n, t, false, err
 	case *data.Cons:
-		return parseConsParamNames(v), true, nil
+		n, t, err := parseConsParamNames(v)
+		return n, t, true, err
 	default:
-		return nil, false, fmt.Errorf(ErrUnexpectedParamSyntax, v)
+		return nil, nil, false, fmt.Errorf(ErrUnexpectedParamSyntax, v)
 	}
 }
 
-func parseListParamNames(l *data.List) data.Locals {
+func parseListParamNames(l *data.List) (data.Locals, []ale.Type, error) {
 	var an data.Locals
+	var at []ale.Type
 	for f, r, ok := l.Split(); ok; f, r, ok = r.Split() {
-		n := f.(data.Local)
+		n, t, err := parseParamName(f)
+		if err != nil {
+			return nil, nil, err
+		}
 		an = append(an, n)
+		at = append(at, t)
 	}
-	return an
+	return an, at, nil
 }
 
-func parseConsParamNames(c *data.Cons) data.Locals {
+func parseConsParamNames(c *data.Cons) (data.Locals, []ale.Type, error) {
 	var an data.Locals
+	var at []ale.Type
 	next := c
 	for {
-		an = append(an, next.Car().(data.Local))
-
+		n, t, err := parseParamName(next.Car())
+		if err != nil {
+			return nil, nil, err
+		}
+		an = append(an, n)
+		at = append(at, t)
 		cdr := next.Cdr()
 		if nc, ok := cdr.(*data.Cons); ok {
 			next = nc
 			continue
 		}
+		n, t, err = parseParamName(cdr)
+		if err != nil {
+			return nil, nil, err
+		}
+		return append(an, n), append(at, t), nil
+	}
+}
 
-		an = append(an, cdr.(data.Local))
-		return an
+func parseParamName(v ale.Value) (data.Local, ale.Type, error) {
+	switch v := v.(type) {
+	case data.Local:
+		return v, types.BasicAny, nil
+	case data.Vector:
+		if len(v) != 2 {
+			break
+		}
+		n, ok := v[0].(data.Local)
+		if !ok {
+			break
+		}
+		t, err := ParseType(v[1])
+		if err != nil {
+			return "", nil, err
+		}
+		return n, t, nil
+	}
+	return "", nil, fmt.Errorf(ErrUnexpectedParamSyntax, v)
+}
+
+// isTypedParam returns whether a Vector is a parameter name followed by its
+// Type, such as [xs :number], rather than a parameter case. A case whose rest
+// parameter is followed by nothing but a Type would otherwise look the same,
+// so a Vector like that is always treated as a typed parameter
+func isTypedParam(v data.Vector) bool {
+	if len(v) != 2 {
+		return false
+	}
+	_, ok := v[0].(data.Local)
+	return ok && isTypeSyntax(v[1])
+}
+
+// parseResultType treats the start of a body as an annotation of its Result
+// Type if it's the ResultMarker followed by a Type and at least one other
+// form. Any other body is left as it is
+func parseResultType(body data.Sequence) (ale.Type, data.Sequence, error) {
+	f, r, _ := body.Split()
+	if f != ResultMarker {
+		return types.BasicAny, body, nil
+	}
+	f, r, _ = r.Split()
+	if r.IsEmpty() {
+		return types.BasicAny, body, nil
+	}
+	t, err := ParseType(f)
+	if err != nil {
+		return nil, nil, err
 	}
+	return t, r, nil
 }
 
 func formatRange(r [2]int) string {
@@ -8,6 +8,7 @@ import (
 	"github.com/kode4food/ale/internal/assert"
 	. "github.com/kode4food/ale/internal/assert/helpers"
 	"github.com/kode4food/ale/internal/lang/params"
+	"github.com/kode4food/ale/internal/types"
 )
 
 func TestReachability(t *testing.T) {
@@ -80,3 +81,72 @@ func TestUnmatchedCase(t *testing.T) {
 		(test 1 2)
 	`, fmt.Errorf(params.ErrUnmatchedCase, 2, "0-1, 3, 5"))
 }
+
+func TestTypeAnnotations(t *testing.T) {
+	as := assert.New(t)
+
+	as.MustEvalTo(`
+		(define-lambda test
+			[([x :number]) :- :number (+ x 1)]
+			[([x [:string :null]] . [r :keyword]) [x r]])
+		[(test 1) (test "a" :b :c) (test '() :d)]
+	`, V(I(2), V(S("a"), V(K("b"), K("c"))), V(data.Null, V(K("d")))))
+
+	as.MustEvalTo(`
+		(define (k . [xs :number]) xs)
+		[((lambda [xs :number] xs) 1 2) (k 3) (k)]
+	`, V(V(I(1), I(2)), V(I(3)), V()))
+	as.MustEvalTo(`((lambda [xs :number] :- :vector xs) 4)`, V(I(4)))
+	as.MustEvalTo(`((lambda [(x) x] [(x y) y]) 5)`, I(5))
+
+	as.MustEvalTo(`((lambda () :number))`, K("number"))
+	as.MustEvalTo(`((lambda () [:keyword :null] :string))`, K("string"))
+	as.MustEvalTo(`((lambda () :- :number))`, K("number"))
+	as.MustEvalTo(`((lambda (x) :- :number x) 1)`, I(1))
+	as.MustEvalTo(`
+		(define (k x) :ok x)
+		(define (v x) [:a] x)
+		[(k 1) (v 2)]
+	`, V(I(1), I(2)))
+
+	as.ErrorWith(`(lambda ([x :thing]) x)`,
+		fmt.Errorf(params.ErrUnknownType, ":thing"),
+	)
+	as.ErrorWith(`(lambda (x) :- :strin x)`,
+		fmt.Errorf(params.ErrUnknownType, ":strin"),
+	)
+	as.ErrorWith(`(lambda ([x [:number 1]]) x)`,
+		fmt.Errorf(params.ErrUnexpectedTypeSyntax, "[:number 1]"),
+	)
+	as.ErrorWith(`(lambda ([x]) x)`,
+		fmt.Errorf(params.ErrUnexpectedParamSyntax, "[x]"),
+	)
+	as.ErrorWith(`(lambda (x . 1) x)`,
+		fmt.Errorf(params.ErrUnexpectedParamSyntax, "1"),
+	)
+	as.ErrorWith(`(lambda [xs :thing] xs)`,
+		fmt.Errorf(params.ErrUnknownType, ":thing"),
+	)
+	as.ErrorWith(`(lambda [(x) x] x)`,
+		fmt.Errorf(params.ErrUnexpectedCaseSyntax, "x"),
+	)
+}
+
+func TestParseType(t *testing.T) {
+	as := assert.New(t)
+
+	typ, err := params.ParseType(K("number"))
+	as.NoError(err)
+	as.Equal(types.BasicNumber, typ)
+
+	typ, err = params.ParseType(V(K("string"), K("null")))
+	as.NoError(err)
+	as.Equal("union(null,string)", typ.Name())
+
+	typ, err = params.ParseType(V(K("number"), K("any")))
+	as.NoError(err)
+	as.Equal(types.BasicAny, typ)
+
+	_, err = params.ParseType(V())
+	as.EqualError(err, fmt.Sprintf(params.ErrUnexpectedTypeSyntax, "[]"))
+}
@@ -0,0 +1,93 @@
+package params
+
+import (
+	"fmt"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/types"
+)
+
+const (
+	// ErrUnknownType is raised when a parameter or result annotation names a
+	// Type that isn't known
+	ErrUnknownType = "unknown type: %s"
+
+	// ErrUnexpectedTypeSyntax is raised when a parameter or result annotation
+	// is neither a Keyword nor a Vector of Keywords
+	ErrUnexpectedTypeSyntax = "unexpected type syntax: %s"
+)
+
+// ResultMarker precedes the Type annotation of a lambda's result, as in
+// (lambda ([x :number]) :- :number (* x 2))
+const ResultMarker = data.Keyword("-")
+
+var namedTypes = map[data.Keyword]ale.Type{
+	"any":       types.BasicAny,
+	"boolean":   types.BasicBoolean,
+	"bytes":     types.BasicBytes,
+	"cons":      types.BasicCons,
+	"error":     types.BasicError,
+	"keyword":   types.BasicKeyword,
+	"list":      types.MakeNamedUnion("list", types.BasicList, types.BasicNull),
+	"null":      types.BasicNull,
+	"number":    types.BasicNumber,
+	"object":    types.BasicObject,
+	"procedure": types.BasicProcedure,
+	"regex":     types.BasicRegex,
+	"set":       types.BasicSet,
+	"string":    types.BasicString,
+	"symbol":    types.BasicSymbol,
+	"vector":    types.BasicVector,
+}
+
+// ParseType returns the Type that an annotation describes. An annotation is
+// either a Keyword that names a Type, such as :number, or a Vector of them
+// that describes the Union of their Types, such as [:string :null]
+func ParseType(v ale.Value) (ale.Type, error) {
+	switch v := v.(type) {
+	case data.Keyword:
+		if t, ok := namedTypes[v]; ok {
+			return t, nil
+		}
+		return nil, fmt.Errorf(ErrUnknownType, v)
+	case data.Vector:
+		if !isTypeSyntax(v) {
+			break
+		}
+		res := make([]ale.Type, len(v))
+		for i, e := range v {
+			t, err := ParseType(e)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = t
+		}
+		return types.MakeUnion(res[0], res[1:]...), nil
+	}
+	return nil, fmt.Errorf(ErrUnexpectedTypeSyntax, v)
+}
+
+func isTypeSyntax(v ale.Value) bool {
+	switch v := v.(type) {
+	case data.Keyword:
+		return true
+	case data.Vector:
+		if len(v) == 0 {
+			return false
+		}
+		for _, e := range v {
+			if _, ok := e.(data.Keyword); !ok {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func isAny(t ale.Type) bool {
+	_, ok := t.(*types.Any)
+	return ok
+}
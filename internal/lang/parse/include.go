@@ -29,7 +29,12 @@ func (p *parser) processInclude(v ale.Value) (ale.Value, error) {
 	if err != nil {
 		return data.Null, err
 	}
-	p.seq = sequence.Concat(lex.StripWhitespace(inc), p.seq)
+	p.seq = sequence.Concat(
+		data.NewList(sourceMarker(path)),
+		lex.StripWhitespace(inc),
+		data.NewList(sourceMarker(p.source)),
+		p.seq,
+	)
 	t, err := p.nextToken()
 	if err != nil {
 		return data.Null, p.maybeWrap(err)
@@ -78,6 +83,9 @@ func fetchOpenCall(ns env.Namespace) (data.Procedure, error) {
 	if open, ok := getMapped[data.Procedure](v, stream.OpenKey); ok {
 		return open, nil
 	}
+	if err, ok := v.(error); ok {
+		return nil, err
+	}
 	return nil, fmt.Errorf(ErrExpectedFileSystem, v)
 }
 
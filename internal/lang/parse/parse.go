@@ -13,6 +13,14 @@ type Tokenizer func(data.String) (data.Sequence, error)
 // FromString returns a Lazy Sequence of scanned data structures
 func FromString(
 	ns env.Namespace, tokenize Tokenizer, str data.String,
+) (data.Sequence, error) {
+	return FromSource(ns, tokenize, "", str)
+}
+
+// FromSource returns a Lazy Sequence of scanned data structures, whose lists
+// are associated with Locations in the named source
+func FromSource(
+	ns env.Namespace, tokenize Tokenizer, source string, str data.String,
 ) (data.Sequence, error) {
 	lexer, err := tokenize(str)
 	if err != nil {
@@ -24,6 +32,7 @@ func FromString(
 		ns:       ns,
 		tokenize: tokenize,
 		seq:      lex.StripWhitespace(lexer),
+		source:   source,
 	}
 
 	res = func() (ale.Value, data.Sequence, bool) {
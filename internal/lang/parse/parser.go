@@ -20,8 +20,13 @@ type (
 		tokenize Tokenizer
 		seq      data.Sequence
 		token    *lex.Token
+		source   string
 	}
 
+	// sourceMarker is spliced into the Token stream in order to switch the
+	// source name that the parser attaches to the Locations of its lists
+	sourceMarker string
+
 	handler func(*parser, *lex.Token) (ale.Value, error)
 )
 
@@ -72,6 +77,13 @@ func (p *parser) nextValue() (ale.Value, bool, error) {
 
 func (p *parser) nextToken() (*lex.Token, error) {
 	token, seq, ok := p.seq.Split()
+	for ; ok; token, seq, ok = seq.Split() {
+		if s, ok := token.(sourceMarker); ok {
+			p.source = string(s)
+			continue
+		}
+		break
+	}
 	if !ok {
 		return nil, nil
 	}
@@ -109,8 +121,12 @@ func (p *parser) prefixed(s data.Symbol) (ale.Value, error) {
 	return nil, p.errorf(ErrPrefixedNotPaired, s)
 }
 
+// list parses the elements of a List. Every tail of the result is associated
+// with the location of its first element, so that forms taken from the rest
+// of a List, such as the parameters of a define, can still be located
 func (p *parser) list() (ale.Value, error) {
 	res := data.Vector{}
+	var locs []*data.Location
 	var sawDotAt = -1
 	for pos := 0; ; pos++ {
 		t, err := p.nextToken()
@@ -128,17 +144,19 @@ func (p *parser) list() (ale.Value, error) {
 			sawDotAt = pos
 		case lex.ListEnd:
 			if sawDotAt == -1 {
-				return data.NewList(res...), nil
+				return makeLocatedList(res, locs, data.Null), nil
 			} else if sawDotAt != len(res)-1 {
 				return nil, p.error(ErrInvalidListSyntax)
 			}
-			return makeDottedList(res...), nil
+			return makeDottedList(res, locs), nil
 		default:
+			loc := data.NewLocation(p.source, t.Line()+1, t.Column()+1)
 			v, err := p.value(t)
 			if err != nil {
 				return nil, err
 			}
 			res = append(res, v)
+			locs = append(locs, loc)
 		}
 	}
 	return nil, p.error(ErrListNotClosed)
@@ -221,13 +239,22 @@ func (p *parser) identifier() (ale.Value, error) {
 	return data.ParseSymbol(n)
 }
 
-func makeDottedList(vals ...ale.Value) ale.Value {
+func makeLocatedList(
+	vals data.Vector, locs []*data.Location, res *data.List,
+) *data.List {
+	for i := len(vals) - 1; i >= 0; i-- {
+		res = res.Prepend(vals[i]).(*data.List)
+		if i > 0 {
+			res = res.WithLocation(locs[i])
+		}
+	}
+	return res
+}
+
+func makeDottedList(vals data.Vector, locs []*data.Location) ale.Value {
 	l := len(vals)
 	if res, ok := vals[l-1].(*data.List); ok {
-		for i := l - 2; i >= 0; i-- {
-			res = res.Prepend(vals[i]).(*data.List)
-		}
-		return res
+		return makeLocatedList(vals[:l-1], locs, res)
 	}
 	var res = data.NewCons(vals[l-2], vals[l-1])
 	for i := l - 3; i >= 0; i-- {
@@ -257,14 +284,23 @@ func getValueHandlers() *[lex.EOF + 1]handler {
 	return &handlers
 }
 
-func listStartHandler(p *parser, _ *lex.Token) (ale.Value, error) {
+func listStartHandler(p *parser, t *lex.Token) (ale.Value, error) {
+	// tokens can be shared, so the location has to be captured up front
+	loc := data.NewLocation(p.source, t.Line()+1, t.Column()+1)
 	res, err := p.list()
 	if err != nil {
 		return nil, err
 	}
+	if l, ok := res.(*data.List); ok {
+		res = l.WithLocation(loc)
+	}
 	return p.processInclude(res)
 }
 
+func (sourceMarker) Equal(ale.Value) bool {
+	return false
+}
+
 func makePrefixedHandler(s data.Symbol) handler {
 	return func(p *parser, t *lex.Token) (ale.Value, error) {
 		return p.prefixed(s)
@@ -3,10 +3,14 @@ package parse_test
 import (
 	"errors"
 	"testing"
+	"testing/fstest"
 
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/core/bootstrap"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/assert"
 	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/basics"
 	"github.com/kode4food/ale/internal/lang/lex"
 	"github.com/kode4food/ale/internal/lang/parse"
 	"github.com/kode4food/ale/internal/sequence"
@@ -210,3 +214,51 @@ func TestReaderErrors(t *testing.T) {
 
 	testReaderError(t, `"unterminated`, lex.ErrStringNotTerminated.Error())
 }
+
+func TestReadLocations(t *testing.T) {
+	as := assert.New(t)
+	ns := assert.GetTestNamespace()
+	tr := read.MustFromSource(ns, "test.ale", "99\n  (first\n    (rest x))")
+	v := sequence.ToVector(tr)
+	as.Equal(2, len(v))
+
+	outer := v[1].(*data.List)
+	loc, ok := outer.Location()
+	as.True(ok)
+	as.String("test.ale:2:3", loc.String())
+
+	inner := outer.Cdr().(*data.List).Car().(*data.List)
+	loc, ok = inner.Location()
+	as.True(ok)
+	as.String("test.ale:3:5", loc.String())
+
+	loc, ok = outer.Cdr().(*data.List).Location()
+	as.True(ok)
+	as.String("test.ale:3:5", loc.String())
+
+	loc, ok = inner.Cdr().(*data.List).Location()
+	as.True(ok)
+	as.String("test.ale:3:11", loc.String())
+
+	loc, ok = data.NewList(I(1)).Location()
+	as.False(ok)
+	as.Nil(loc)
+}
+
+func TestIncludeLocations(t *testing.T) {
+	as := assert.New(t)
+	ns := assert.GetTestNamespace()
+	bootstrap.MustBindFileSystem(ns, fstest.MapFS{
+		"inc.ale": {Data: []byte("\n(included)")},
+	})
+	src := data.String(`(before) (#include "inc.ale") (after)`)
+	tr := read.MustFromSource(ns, "main.ale", src)
+	v := sequence.ToVector(tr)
+	as.Equal(3, len(v))
+
+	locs := basics.Map(v, func(v ale.Value) string {
+		loc, _ := v.(*data.List).Location()
+		return loc.String()
+	})
+	as.Equal([]string{"main.ale:1:1", "inc.ale:2:1", "main.ale:1:31"}, locs)
+}
@@ -56,6 +56,9 @@ const (
 	StringQuote = `"`
 	String      = `(")(?P<s>(\\\\|\\"|\\[^\\"]|[^"\\])*)("?)`
 
+	RegexStart = ReaderPrefix + StringQuote
+	Regex      = ReaderPrefix + String
+
 	numTail = localStart + `*`
 
 	Float = `[+-]?((0|[1-9]\d*)\.\d+([eE][+-]?\d+)?|` +
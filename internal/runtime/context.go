@@ -0,0 +1,122 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/debug"
+	"github.com/kode4food/ale/internal/types"
+)
+
+type (
+	// ContextCaller is implemented by Procedures that observe the context of
+	// the evaluation that calls them
+	ContextCaller interface {
+		data.Procedure
+
+		// CallContext invokes this Procedure with the provided context and
+		// arguments
+		CallContext(context.Context, ...ale.Value) ale.Value
+	}
+
+	// ContextCall is the type of function that can be turned into a
+	// ContextCaller
+	ContextCall func(context.Context, ...ale.Value) ale.Value
+
+	contextProcedure struct {
+		call  ContextCall
+		arity data.ArityChecker
+	}
+)
+
+// compile-time checks for interface implementation
+var _ interface {
+	data.Mapped
+	ContextCaller
+} = (*contextProcedure)(nil)
+
+// MakeContextProcedure constructs a Procedure from a func that receives the
+// context of the calling evaluation. When the Procedure is called without
+// one, the func receives context.Background()
+func MakeContextProcedure(c ContextCall, arity ...int) data.Procedure {
+	check, err := data.MakeArityChecker(arity...)
+	if err != nil {
+		panic(debug.ProgrammerErrorf("%w", err))
+	}
+	return &contextProcedure{
+		call:  c,
+		arity: check,
+	}
+}
+
+// Call invokes a Procedure, passing the provided context along if the
+// Procedure is able to observe it
+func Call(ctx context.Context, fn data.Procedure, args ...ale.Value) ale.Value {
+	if c, ok := fn.(ContextCaller); ok {
+		return c.CallContext(ctx, args...)
+	}
+	return fn.Call(args...)
+}
+
+// CheckContext raises a cancellation Error if the context is done
+func CheckContext(ctx context.Context) {
+	if ctx.Err() != nil {
+		panic(ContextError(ctx))
+	}
+}
+
+// ContextError returns the Error that is raised when evaluation is stopped
+// because its context is done. If the context was cancelled with an Error as
+// its cause, that Error is returned
+func ContextError(ctx context.Context) *data.Error {
+	cause := context.Cause(ctx)
+	if e, ok := cause.(*data.Error); ok {
+		return e
+	}
+	return data.NewError(data.CancelledErrorKind, cause.Error(), data.Null).
+		WithCause(cause)
+}
+
+// IgnoreCancelled is deferred by goroutines that call Procedures with a
+// context. It quietly stops the goroutine if it was interrupted because the
+// context is done, and re-raises anything else
+func IgnoreCancelled(ctx context.Context) {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+	if err, ok := rec.(error); ok && ctx.Err() != nil {
+		if errors.Is(err, context.Cause(ctx)) {
+			return
+		}
+	}
+	panic(rec)
+}
+
+func (p *contextProcedure) CheckArity(argc int) error {
+	return p.arity(argc)
+}
+
+func (p *contextProcedure) Call(args ...ale.Value) ale.Value {
+	return p.call(context.Background(), args...)
+}
+
+func (p *contextProcedure) CallContext(
+	ctx context.Context, args ...ale.Value,
+) ale.Value {
+	return p.call(ctx, args...)
+}
+
+func (p *contextProcedure) Type() ale.Type {
+	return types.MakeLiteral(types.BasicProcedure, p)
+}
+
+func (p *contextProcedure) Equal(other ale.Value) bool {
+	return p == other
+}
+
+func (p *contextProcedure) Get(key ale.Value) (ale.Value, bool) {
+	return data.DumpMapped(p).Get(key)
+}
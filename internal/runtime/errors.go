@@ -6,15 +6,17 @@ import (
 	"runtime"
 	"slices"
 
+	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/debug"
 	"github.com/kode4food/ale/internal/strings"
 )
 
-type aleRuntimeError struct {
-	wrapped error
-	*data.Object
-	message string
+// raisedValue is raised in place of a Value that isn't an error. It can be
+// traced like any other error, but is passed to Ale rescue code unchanged
+type raisedValue struct {
+	value ale.Value
+	err   *data.Error
 }
 
 // ErrUnexpectedType maps a Go interface conversion error to something that
@@ -35,17 +37,25 @@ var interfaceConversion = [...]*regexp.Regexp{
 			`not [^.]+[.](?P<expected>[a-zA-Z0-9]+).*$`),
 }
 
-func AleRuntimeError(wrapped error, format string, a ...any) error {
-	message := fmt.Sprintf(format, a...)
-	object, _ := data.ValuesToObject(
-		data.Keyword("message"), data.String(message),
-		data.Keyword("wrapped"), data.String(wrapped.Error()),
-	)
-	return &aleRuntimeError{
-		Object:  object,
-		message: message,
-		wrapped: wrapped,
+// RaiseValue returns the error that is raised for a Value. If the Value is
+// already an error, it's returned as-is. Otherwise, the Value is wrapped in an
+// error of the kind :error that Recovered unwraps again
+func RaiseValue(v ale.Value) error {
+	if err, ok := v.(error); ok {
+		return err
 	}
+	return &raisedValue{
+		value: v,
+		err:   data.NewError(data.ErrorKind, data.ToString(v), data.Null),
+	}
+}
+
+func (r *raisedValue) Error() string {
+	return r.err.Error()
+}
+
+func (r *raisedValue) Unwrap() error {
+	return r.err
 }
 
 func NormalizeGoRuntimeErrors() {
@@ -63,29 +73,18 @@ func NormalizeGoRuntimeError(value any) any {
 	}
 }
 
-func (a *aleRuntimeError) Error() string {
-	return a.message
-}
-
-func (a *aleRuntimeError) String() string {
-	return a.message
-}
-
-func (a *aleRuntimeError) Unwrap() error {
-	return a.wrapped
-}
-
 func normalizeTypeAssertionError(e *runtime.TypeAssertionError) error {
 	for _, re := range interfaceConversion {
 		if m := re.FindStringSubmatch(e.Error()); m != nil {
 			names := re.SubexpNames()
 			expected := slices.Index(names, "expected")
 			got := slices.Index(names, "got")
-			return AleRuntimeError(e,
-				ErrUnexpectedType,
+			msg := fmt.Sprintf(ErrUnexpectedType,
 				strings.CamelToWords(m[got]),
 				strings.CamelToWords(m[expected]),
 			)
+			return data.NewError(data.TypeErrorKind, msg, data.Null).
+				WithCause(e)
 		}
 	}
 	panic(debug.ProgrammerError("could not normalize type assertion error"))
@@ -29,6 +29,7 @@ type (
 		Constants  data.Vector
 		Globals    env.Namespace
 		Code       Instructions
+		Positions  Positions
 		LocalCount Operand
 		StackSize  Operand
 	}
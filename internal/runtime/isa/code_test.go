@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/assert"
 	. "github.com/kode4food/ale/internal/assert/helpers"
 	"github.com/kode4food/ale/internal/runtime/isa"
@@ -65,3 +66,33 @@ func TestInstructionOperandSizeError(t *testing.T) {
 		fmt.Errorf(isa.ErrExpectedOperand, isa.OperandMask+1),
 	)
 }
+
+func TestPositionsLookup(t *testing.T) {
+	as := assert.New(t)
+
+	l1 := data.NewLocation("test.ale", 1, 1)
+	l2 := data.NewLocation("test.ale", 2, 5)
+	p := isa.Positions{
+		{PC: 2, Location: l1},
+		{PC: 5, Location: l2},
+		{PC: 9, Location: nil},
+	}
+
+	_, ok := p.Lookup(0)
+	as.False(ok)
+
+	loc, ok := p.Lookup(2)
+	as.True(ok)
+	as.Equal(l1, loc)
+
+	loc, ok = p.Lookup(4)
+	as.True(ok)
+	as.Equal(l1, loc)
+
+	loc, ok = p.Lookup(8)
+	as.True(ok)
+	as.Equal(l2, loc)
+
+	_, ok = p.Lookup(12)
+	as.False(ok)
+}
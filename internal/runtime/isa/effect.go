@@ -31,6 +31,7 @@ const (
 	Integer
 	Labels
 	Locals
+	Locations
 	Stack
 )
 
@@ -128,6 +129,9 @@ var Effects = map[Opcode]*Effect{
 	NumLte: {Pop: 2, Push: 1},
 	PosInt: {Push: 1, Operand: Integer},
 	Sub:    {Pop: 2, Push: 1},
+
+	// Position Markers
+	Pos: {Ignore: true, Operand: Locations},
 }
 
 func GetEffect(oc Opcode) (*Effect, error) {
@@ -111,6 +111,9 @@ const (
 	NumLte // Pop two numbers, push true if second <= first
 	PosInt // Push positive integer (int = operand)
 	Sub    // Pop two numbers, push their difference
+
+	// Position Markers
+	Pos // Marks a source position (not executed)
 )
 
 // New creates a new Instruction instance from an Opcode
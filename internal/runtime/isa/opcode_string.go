@@ -80,20 +80,21 @@ func _() {
 	_ = x[NumLte-68]
 	_ = x[PosInt-69]
 	_ = x[Sub-70]
+	_ = x[Pos-71]
 }
 
 const (
-	_Opcode_name_0 = "LabelNoOpArgArgsLenArgsPopArgsPushArgsRestClosureEnvBindEnvPrivateEnvPublicEnvValueLoadNewRefRefBindRefValueStoreConstDupFalseNullPopSwapTrueZeroCallCall0Call1Call2Call3CallSelfCallWithTailCallTailClosTailSelfCondJumpDelayJumpPanicRetFalseRetNullRetTrueReturnAppendAssocCarCdrConsDissocEmptyGetLazySeqLengthNthReverseVectorEqNotAddDivModMulNegNegIntNumEqNumGtNumGteNumLtNumLtePosIntSub"
+	_Opcode_name_0 = "LabelNoOpArgArgsLenArgsPopArgsPushArgsRestClosureEnvBindEnvPrivateEnvPublicEnvValueLoadNewRefRefBindRefValueStoreConstDupFalseNullPopSwapTrueZeroCallCall0Call1Call2Call3CallSelfCallWithTailCallTailClosTailSelfCondJumpDelayJumpPanicRetFalseRetNullRetTrueReturnAppendAssocCarCdrConsDissocEmptyGetLazySeqLengthNthReverseVectorEqNotAddDivModMulNegNegIntNumEqNumGtNumGteNumLtNumLtePosIntSubPos"
 	_Opcode_name_1 = "OpcodeMask"
 )
 
 var (
-	_Opcode_index_0 = [...]uint16{0, 5, 9, 12, 19, 26, 34, 42, 49, 56, 66, 75, 83, 87, 93, 100, 108, 113, 118, 121, 126, 130, 133, 137, 141, 145, 149, 154, 159, 164, 169, 177, 185, 193, 201, 209, 217, 222, 226, 231, 239, 246, 253, 259, 265, 270, 273, 276, 280, 286, 291, 294, 301, 307, 310, 317, 323, 325, 328, 331, 334, 337, 340, 343, 349, 354, 359, 365, 370, 376, 382, 385}
+	_Opcode_index_0 = [...]uint16{0, 5, 9, 12, 19, 26, 34, 42, 49, 56, 66, 75, 83, 87, 93, 100, 108, 113, 118, 121, 126, 130, 133, 137, 141, 145, 149, 154, 159, 164, 169, 177, 185, 193, 201, 209, 217, 222, 226, 231, 239, 246, 253, 259, 265, 270, 273, 276, 280, 286, 291, 294, 301, 307, 310, 317, 323, 325, 328, 331, 334, 337, 340, 343, 349, 354, 359, 365, 370, 376, 382, 385, 388}
 )
 
 func (i Opcode) String() string {
 	switch {
-	case i <= 70:
+	case i <= 71:
 		return _Opcode_name_0[_Opcode_index_0[i]:_Opcode_index_0[i+1]]
 	case i == 127:
 		return _Opcode_name_1
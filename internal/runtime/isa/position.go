@@ -0,0 +1,38 @@
+package isa
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/kode4food/ale/data"
+)
+
+type (
+	// Position associates the Instruction found at a program counter with the
+	// source Location that it was generated from. The Location remains in
+	// effect until the program counter of the next Position
+	Position struct {
+		Location *data.Location
+		PC       int
+	}
+
+	// Positions is a table of Position entries, ordered by program counter
+	Positions []Position
+)
+
+// Lookup returns the source Location in effect for the provided program
+// counter, if there is one
+func (p Positions) Lookup(pc int) (*data.Location, bool) {
+	idx, found := slices.BinarySearchFunc(p, pc,
+		func(e Position, pc int) int {
+			return cmp.Compare(e.PC, pc)
+		},
+	)
+	if !found {
+		idx--
+	}
+	if idx < 0 || p[idx].Location == nil {
+		return nil, false
+	}
+	return p[idx].Location, true
+}
@@ -0,0 +1,164 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kode4food/ale/data"
+)
+
+type (
+	// Limits constrain the resources that an evaluation can consume. A limit
+	// with a zero value isn't enforced
+	Limits struct {
+		// MaxInstructions is the number of virtual machine instructions that
+		// the evaluation and its goroutines can execute in total
+		MaxInstructions int64
+
+		// MaxDepth is the depth to which calls can be nested within any one
+		// of the evaluation's goroutines
+		MaxDepth int
+
+		// Timeout is the wall-clock time that the evaluation can run for
+		Timeout time.Duration
+	}
+
+	// Meter tracks the resources consumed by an evaluation against its
+	// Limits. Each goroutine of the evaluation tracks its own call depth
+	Meter struct {
+		*meterBudget
+		depth atomic.Int64
+	}
+
+	meterBudget struct {
+		limits Limits
+		used   atomic.Int64
+		cancel context.CancelCauseFunc
+	}
+
+	meterKey struct{}
+)
+
+// Error messages
+const (
+	// ErrInstructionLimit is raised when an evaluation executes more
+	// instructions than its limits allow
+	ErrInstructionLimit = "instruction limit exceeded: %d"
+
+	// ErrDepthLimit is raised when calls are nested more deeply than the
+	// limits of an evaluation allow
+	ErrDepthLimit = "call depth limit exceeded: %d"
+
+	// ErrDeadline is raised when an evaluation runs for longer than its
+	// limits allow
+	ErrDeadline = "evaluation deadline exceeded: %s"
+)
+
+// MeterBatch is the number of instructions that the virtual machine executes
+// between reports to a Meter. An evaluation can overrun its instruction limit
+// by up to this many instructions per running goroutine
+const MeterBatch = 64
+
+// WithLimits returns a context that applies the provided Limits to any
+// evaluation performed with it. When an instruction or time limit is
+// exceeded, the context is cancelled with the Error that was raised
+func WithLimits(
+	parent context.Context, limits Limits,
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	stop := func() { cancel(nil) }
+	if limits.Timeout > 0 {
+		err := data.NewError(
+			data.DeadlineErrorKind,
+			fmt.Sprintf(ErrDeadline, limits.Timeout),
+			data.Null,
+		).WithCause(context.DeadlineExceeded)
+		var stopTimer context.CancelFunc
+		ctx, stopTimer = context.WithTimeoutCause(ctx, limits.Timeout, err)
+		stop = func() {
+			stopTimer()
+			cancel(nil)
+		}
+	}
+	m := &Meter{
+		meterBudget: &meterBudget{
+			limits: limits,
+			cancel: cancel,
+		},
+	}
+	return context.WithValue(ctx, meterKey{}, m), stop
+}
+
+// MeterOf returns the Meter of the evaluation that is using the provided
+// context, or nil if it has no Limits
+func MeterOf(ctx context.Context) *Meter {
+	m, _ := ctx.Value(meterKey{}).(*Meter)
+	return m
+}
+
+// ForkContext returns a context for a new goroutine of an evaluation, or for
+// an evaluation that runs concurrently with others that share its context.
+// The goroutine shares the evaluation's Limits and Profiler, but tracks its
+// own call depth and profiled calls
+func ForkContext(ctx context.Context) context.Context {
+	if m := MeterOf(ctx); m != nil {
+		ctx = context.WithValue(ctx, meterKey{}, &Meter{
+			meterBudget: m.meterBudget,
+		})
+	}
+	if p := ProfilerOf(ctx); p != nil {
+		ctx = context.WithValue(ctx, profilerKey{}, p.fork())
+	}
+	return ctx
+}
+
+// Counting returns whether the Meter limits the number of instructions
+func (m *Meter) Counting() bool {
+	return m != nil && m.limits.MaxInstructions > 0
+}
+
+// Enter records that a call is being made, raising an Error if doing so
+// would exceed the depth limit
+func (m *Meter) Enter() {
+	max := int64(m.limits.MaxDepth)
+	if max > 0 && m.depth.Add(1) > max {
+		m.depth.Add(-1)
+		panic(data.NewError(
+			data.DepthLimitErrorKind,
+			fmt.Sprintf(ErrDepthLimit, max),
+			data.Null,
+		))
+	}
+}
+
+// Leave records that a call has returned
+func (m *Meter) Leave() {
+	if m.limits.MaxDepth > 0 {
+		m.depth.Add(-1)
+	}
+}
+
+// Step records that instructions were executed, raising an Error and
+// cancelling the evaluation if the instruction limit has been exceeded
+func (m *Meter) Step(n int) {
+	max := m.limits.MaxInstructions
+	if m.used.Add(int64(n)) > max {
+		err := data.NewError(
+			data.InstructionLimitErrorKind,
+			fmt.Sprintf(ErrInstructionLimit, max),
+			data.Null,
+		)
+		m.cancel(err)
+		panic(err)
+	}
+}
+
+// Flush records that instructions were executed without checking the
+// instruction limit. It's only used while an Error is already being raised,
+// so that the Error isn't replaced. Calls that return report their remaining
+// instructions using Step, so that short calls are also held to the limit
+func (m *Meter) Flush(n int) {
+	m.used.Add(int64(n))
+}
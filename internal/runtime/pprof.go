@@ -0,0 +1,188 @@
+package runtime
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+)
+
+type (
+	pprofEncoder struct {
+		strings   map[string]uint64
+		table     []string
+		functions map[pprofFunction]uint64
+		order     []pprofFunction
+	}
+
+	pprofFunction struct {
+		name   string
+		source string
+		line   int
+	}
+
+	protoBuffer []byte
+)
+
+// Fields of the messages of the profile.proto format that pprof reads
+const (
+	profileSampleType  = 1
+	profileSample      = 2
+	profileLocation    = 4
+	profileFunction    = 5
+	profileStringTable = 6
+	profileTimeNanos   = 9
+	profileDuration    = 10
+	profilePeriodType  = 11
+	profilePeriod      = 12
+	profileDefaultType = 14
+
+	valueTypeType = 1
+	valueTypeUnit = 2
+
+	sampleLocationID = 1
+	sampleValue      = 2
+
+	locationID   = 1
+	locationLine = 4
+
+	lineFunctionID = 1
+	lineLine       = 2
+
+	functionID        = 1
+	functionName      = 2
+	functionFilename  = 4
+	functionStartLine = 5
+)
+
+var (
+	pprofPeriodType  = [2]string{"time", "nanoseconds"}
+	pprofSampleTypes = [][2]string{
+		{"samples", "count"},
+		pprofPeriodType,
+		{"alloc_space", "bytes"},
+		{"alloc_objects", "count"},
+	}
+)
+
+// WritePprof writes the Profile in the gzipped protocol buffer format that
+// is read by the pprof tool. Each Ale procedure is written as a function,
+// named for its domain and name, that starts at its source position
+func (p *Profile) WritePprof(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(p.encodePprof()); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func (p *Profile) encodePprof() []byte {
+	e := &pprofEncoder{
+		strings:   map[string]uint64{},
+		functions: map[pprofFunction]uint64{},
+	}
+	e.string("")
+
+	var res protoBuffer
+	for _, t := range pprofSampleTypes {
+		res.message(profileSampleType, e.valueType(t[0], t[1]))
+	}
+	period := int64(p.Period)
+	for _, s := range p.Samples {
+		var sample protoBuffer
+		ids := make([]uint64, len(s.Stack))
+		for i, f := range s.Stack {
+			ids[i] = e.function(f)
+		}
+		sample.packed(sampleLocationID, ids...)
+		sample.packed(sampleValue,
+			uint64(s.Count), uint64(s.Count*period),
+			uint64(s.AllocBytes), uint64(s.AllocObjects),
+		)
+		res.message(profileSample, sample)
+	}
+	for i, f := range e.order {
+		id := uint64(i + 1)
+		var line, loc, fn protoBuffer
+		line.varint(lineFunctionID, id)
+		line.varint(lineLine, uint64(f.line))
+		loc.varint(locationID, id)
+		loc.message(locationLine, line)
+		res.message(profileLocation, loc)
+
+		fn.varint(functionID, id)
+		fn.varint(functionName, e.string(f.name))
+		fn.varint(functionFilename, e.string(f.source))
+		fn.varint(functionStartLine, uint64(f.line))
+		res.message(profileFunction, fn)
+	}
+	periodType := e.valueType(pprofPeriodType[0], pprofPeriodType[1])
+	defaultType := e.string(pprofPeriodType[0])
+	for _, s := range e.table {
+		res.bytes(profileStringTable, []byte(s))
+	}
+	res.varint(profileTimeNanos, uint64(p.Start.UnixNano()))
+	res.varint(profileDuration, uint64(p.Duration))
+	res.message(profilePeriodType, periodType)
+	res.varint(profilePeriod, uint64(period))
+	res.varint(profileDefaultType, defaultType)
+	return res
+}
+
+func (e *pprofEncoder) valueType(typ, unit string) protoBuffer {
+	var res protoBuffer
+	res.varint(valueTypeType, e.string(typ))
+	res.varint(valueTypeUnit, e.string(unit))
+	return res
+}
+
+func (e *pprofEncoder) function(f *Frame) uint64 {
+	fn := pprofFunction{name: f.qualifiedName()}
+	if l := f.Location; l != nil {
+		fn.source = l.Source
+		fn.line = l.Line
+	}
+	if id, ok := e.functions[fn]; ok {
+		return id
+	}
+	e.order = append(e.order, fn)
+	id := uint64(len(e.order))
+	e.functions[fn] = id
+	return id
+}
+
+func (e *pprofEncoder) string(s string) uint64 {
+	if id, ok := e.strings[s]; ok {
+		return id
+	}
+	id := uint64(len(e.table))
+	e.table = append(e.table, s)
+	e.strings[s] = id
+	return id
+}
+
+func (b *protoBuffer) varint(field int, v uint64) {
+	b.tag(field, 0)
+	*b = binary.AppendUvarint(*b, v)
+}
+
+func (b *protoBuffer) bytes(field int, v []byte) {
+	b.tag(field, 2)
+	*b = binary.AppendUvarint(*b, uint64(len(v)))
+	*b = append(*b, v...)
+}
+
+func (b *protoBuffer) message(field int, m protoBuffer) {
+	b.bytes(field, m)
+}
+
+func (b *protoBuffer) packed(field int, v ...uint64) {
+	var res protoBuffer
+	for _, i := range v {
+		res = binary.AppendUvarint(res, i)
+	}
+	b.bytes(field, res)
+}
+
+func (b *protoBuffer) tag(field int, wire uint64) {
+	*b = binary.AppendUvarint(*b, uint64(field)<<3|wire)
+}
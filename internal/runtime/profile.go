@@ -0,0 +1,301 @@
+package runtime
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"runtime/metrics"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// Profile describes where an evaluation spent its time and allocated
+	// memory, as sampled by a Profiler
+	Profile struct {
+		// Start is when the Profiler was started
+		Start time.Time
+
+		// Duration is how long the Profiler ran for
+		Duration time.Duration
+
+		// Period is the time between samples
+		Period time.Duration
+
+		// Samples are ordered from the most frequently sampled stack to the
+		// least frequently sampled
+		Samples []*ProfileSample
+	}
+
+	// ProfileSample describes how often a stack of Ale calls was found to be
+	// running. The Frames of the Stack are ordered from innermost to
+	// outermost, and don't record an ArgCount. The allocations of the whole
+	// process are divided among the stacks that were running when they
+	// were made, so they're an approximation
+	ProfileSample struct {
+		Stack        []*Frame
+		Count        int64
+		AllocBytes   int64
+		AllocObjects int64
+	}
+
+	// Profiler keeps a shadow stack of the Ale calls being made by one
+	// goroutine of an evaluation, and shares a sampler with the rest of the
+	// evaluation's goroutines. A Profiler that was started while another was
+	// already profiling the evaluation also records its calls in that one
+	Profiler struct {
+		*profileSampler
+		parent *Profiler
+		top    atomic.Pointer[profileNode]
+	}
+
+	profileNode struct {
+		frame  *Frame
+		parent *profileNode
+	}
+
+	profileSampler struct {
+		period   time.Duration
+		start    time.Time
+		mu       sync.Mutex
+		active   map[*Profiler]struct{}
+		frames   map[*Frame]uint64
+		samples  map[string]*ProfileSample
+		metrics  []metrics.Sample
+		stopped  atomic.Bool
+		done     chan struct{}
+		finished chan struct{}
+		stop     sync.Once
+		profile  *Profile
+	}
+
+	profilerKey struct{}
+)
+
+// DefaultProfilePeriod is the time between samples when a Profiler is
+// started without one
+const DefaultProfilePeriod = 10 * time.Millisecond
+
+const (
+	allocBytesMetric   = "/gc/heap/allocs:bytes"
+	allocObjectsMetric = "/gc/heap/allocs:objects"
+)
+
+// WithProfiler returns a context that samples the Ale calls made by any
+// evaluation performed with it, including the goroutines that the evaluation
+// starts. If the parent context is already being profiled, its Profile
+// continues to include those calls. The returned function stops the sampling
+// and returns the Profile that was gathered. It can be called more than once.
+//
+// The calls of one goroutine are tracked by a single shadow stack, so
+// evaluations that run concurrently must each be given their own context by
+// ForkContext, rather than sharing the one that is returned
+func WithProfiler(
+	parent context.Context, period time.Duration,
+) (context.Context, func() *Profile) {
+	if period <= 0 {
+		period = DefaultProfilePeriod
+	}
+	s := &profileSampler{
+		period:  period,
+		start:   time.Now(),
+		active:  map[*Profiler]struct{}{},
+		frames:  map[*Frame]uint64{},
+		samples: map[string]*ProfileSample{},
+		metrics: []metrics.Sample{
+			{Name: allocBytesMetric},
+			{Name: allocObjectsMetric},
+		},
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	metrics.Read(s.metrics)
+	go s.run()
+	p := &Profiler{
+		profileSampler: s,
+		parent:         ProfilerOf(parent),
+	}
+	return context.WithValue(parent, profilerKey{}, p), s.finish
+}
+
+// ProfilerOf returns the Profiler of the goroutine of an evaluation that is
+// using the provided context, or nil if it isn't being profiled
+func ProfilerOf(ctx context.Context) *Profiler {
+	p, _ := ctx.Value(profilerKey{}).(*Profiler)
+	return p
+}
+
+// Enter records that a call to the procedure described by the Frame is being
+// made
+func (p *Profiler) Enter(f *Frame) {
+	if p.parent != nil {
+		p.parent.Enter(f)
+	}
+	parent := p.top.Load()
+	p.top.Store(&profileNode{
+		frame:  f,
+		parent: parent,
+	})
+	if parent == nil && !p.stopped.Load() {
+		p.activate(p)
+	}
+}
+
+// Replace records that the current call has been replaced, by a tail call,
+// with a call to the procedure described by the Frame
+func (p *Profiler) Replace(f *Frame) {
+	if p.parent != nil {
+		p.parent.Replace(f)
+	}
+	if top := p.top.Load(); top != nil {
+		p.top.Store(&profileNode{
+			frame:  f,
+			parent: top.parent,
+		})
+	}
+}
+
+// Leave records that the current call has returned
+func (p *Profiler) Leave() {
+	if p.parent != nil {
+		p.parent.Leave()
+	}
+	top := p.top.Load()
+	if top == nil {
+		return
+	}
+	p.top.Store(top.parent)
+	if top.parent == nil {
+		p.deactivate(p)
+	}
+}
+
+func (p *Profiler) fork() *Profiler {
+	res := &Profiler{profileSampler: p.profileSampler}
+	if p.parent != nil {
+		res.parent = p.parent.fork()
+	}
+	return res
+}
+
+func (p *Profiler) stack() []*Frame {
+	var res []*Frame
+	for n := p.top.Load(); n != nil; n = n.parent {
+		res = append(res, n.frame)
+	}
+	return res
+}
+
+func (s *profileSampler) finish() *Profile {
+	s.stop.Do(func() {
+		s.stopped.Store(true)
+		close(s.done)
+		<-s.finished
+		s.profile = s.makeProfile()
+	})
+	return s.profile
+}
+
+func (s *profileSampler) run() {
+	defer close(s.finished)
+	t := time.NewTicker(s.period)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-t.C:
+			s.sample()
+		}
+	}
+}
+
+func (s *profileSampler) activate(p *Profiler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active[p] = struct{}{}
+}
+
+func (s *profileSampler) deactivate(p *Profiler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.active, p)
+}
+
+func (s *profileSampler) sample() {
+	bytes, objects := s.readAllocs()
+	var stacks [][]*Frame
+	s.mu.Lock()
+	for p := range s.active {
+		if st := p.stack(); len(st) > 0 {
+			stacks = append(stacks, st)
+		}
+	}
+	s.mu.Unlock()
+	n := int64(len(stacks))
+	for i, st := range stacks {
+		res := s.sampleFor(st)
+		res.Count++
+		res.AllocBytes += share(bytes, n, i)
+		res.AllocObjects += share(objects, n, i)
+	}
+}
+
+func (s *profileSampler) readAllocs() (int64, int64) {
+	prev := []uint64{
+		s.metrics[0].Value.Uint64(),
+		s.metrics[1].Value.Uint64(),
+	}
+	metrics.Read(s.metrics)
+	return int64(s.metrics[0].Value.Uint64() - prev[0]),
+		int64(s.metrics[1].Value.Uint64() - prev[1])
+}
+
+func (s *profileSampler) sampleFor(stack []*Frame) *ProfileSample {
+	var key []byte
+	for _, f := range stack {
+		id, ok := s.frames[f]
+		if !ok {
+			id = uint64(len(s.frames))
+			s.frames[f] = id
+		}
+		key = binary.AppendUvarint(key, id)
+	}
+	if res, ok := s.samples[string(key)]; ok {
+		return res
+	}
+	res := &ProfileSample{Stack: stack}
+	s.samples[string(key)] = res
+	return res
+}
+
+func (s *profileSampler) makeProfile() *Profile {
+	samples := make([]*ProfileSample, 0, len(s.samples))
+	for _, sample := range s.samples {
+		samples = append(samples, sample)
+	}
+	slices.SortFunc(samples, func(l, r *ProfileSample) int {
+		return cmp.Or(
+			cmp.Compare(r.Count, l.Count),
+			cmp.Compare(r.AllocBytes, l.AllocBytes),
+		)
+	})
+	return &Profile{
+		Start:    s.start,
+		Duration: time.Since(s.start),
+		Period:   s.period,
+		Samples:  samples,
+	}
+}
+
+// share divides an amount among n recipients, giving the remainder to the
+// first of them
+func share(amount, n int64, i int) int64 {
+	res := amount / n
+	if i == 0 {
+		res += amount % n
+	}
+	return res
+}
@@ -0,0 +1,121 @@
+package runtime
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+type profileEntry struct {
+	name  string
+	self  int64
+	cum   int64
+	alloc int64
+}
+
+const (
+	reportSummary = "duration: %s, samples: %d every %s, allocated: %s\n\n"
+	reportHeader  = "%10s %7s %10s %7s %10s  %s\n"
+	reportLine    = "%10s %6.2f%% %10s %6.2f%% %10s  %s\n"
+)
+
+var byteUnits = []string{"B", "KB", "MB", "GB", "TB"}
+
+// WriteReport writes a flat text report of the Profile, listing each Ale
+// procedure with the time spent running it (self), the time spent running
+// it and the procedures that it called (cum), and the memory that was
+// allocated while it was running
+func (p *Profile) WriteReport(w io.Writer) error {
+	var total, alloc int64
+	for _, s := range p.Samples {
+		total += s.Count
+		alloc += s.AllocBytes
+	}
+	_, err := fmt.Fprintf(w, reportSummary,
+		p.Duration.Round(time.Millisecond), total, p.Period,
+		formatBytes(alloc),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, reportHeader,
+		"self", "self%", "cum", "cum%", "alloc", "procedure",
+	)
+	if err != nil {
+		return err
+	}
+	for _, e := range p.flatten() {
+		_, err := fmt.Fprintf(w, reportLine,
+			p.sampleTime(e.self), percent(e.self, total),
+			p.sampleTime(e.cum), percent(e.cum, total),
+			formatBytes(e.alloc), e.name,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Profile) flatten() []*profileEntry {
+	entries := map[string]*profileEntry{}
+	entry := func(f *Frame) *profileEntry {
+		name := f.String()
+		if e, ok := entries[name]; ok {
+			return e
+		}
+		e := &profileEntry{name: name}
+		entries[name] = e
+		return e
+	}
+	for _, s := range p.Samples {
+		leaf := entry(s.Stack[0])
+		leaf.self += s.Count
+		leaf.alloc += s.AllocBytes
+		seen := map[*profileEntry]bool{}
+		for _, f := range s.Stack {
+			if e := entry(f); !seen[e] {
+				seen[e] = true
+				e.cum += s.Count
+			}
+		}
+	}
+	res := make([]*profileEntry, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, e)
+	}
+	slices.SortFunc(res, func(l, r *profileEntry) int {
+		return cmp.Or(
+			cmp.Compare(r.self, l.self),
+			cmp.Compare(r.cum, l.cum),
+			cmp.Compare(l.name, r.name),
+		)
+	})
+	return res
+}
+
+func (p *Profile) sampleTime(count int64) time.Duration {
+	return time.Duration(count) * p.Period
+}
+
+func percent(n, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
+
+func formatBytes(n int64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d%s", n, byteUnits[0])
+	}
+	f := float64(n)
+	unit := 0
+	for f >= 1024 && unit < len(byteUnits)-1 {
+		f /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%.1f%s", f, byteUnits[unit])
+}
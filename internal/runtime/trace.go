@@ -0,0 +1,194 @@
+package runtime
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/debug"
+	"github.com/kode4food/ale/internal/lang"
+)
+
+type (
+	// Frame describes an Ale call frame that an error propagated through. The
+	// Name will be empty if the procedure was anonymous, and the Location will
+	// be nil if the position in the source is not known
+	Frame struct {
+		Name     data.Local
+		Domain   data.Local
+		ArgCount int
+		Location *data.Location
+	}
+
+	// tracedError links each Frame to the trace of the frames that the error
+	// had already propagated through, so that adding a Frame while unwinding
+	// doesn't copy the trace. The Frames are only collected when they're read
+	tracedError struct {
+		error
+		frame *Frame
+		inner *tracedError
+		depth int
+	}
+)
+
+const (
+	traceAt   = "\n\tat "
+	anonymous = "<anonymous>"
+)
+
+// Stack Frame Keys
+const (
+	NameKey   = data.Keyword("name")
+	DomainKey = data.Keyword("domain")
+	ArgsKey   = data.Keyword("args")
+	SourceKey = data.Keyword("source")
+	LineKey   = data.Keyword("line")
+	ColumnKey = data.Keyword("column")
+)
+
+// WithFrame adds a Frame to the trace of a recovered panic value. Only errors
+// can be traced, so any other value is returned as-is
+func WithFrame(rec any, f *Frame) any {
+	switch rec := NormalizeGoRuntimeError(rec).(type) {
+	case *tracedError:
+		return &tracedError{
+			error: rec.error,
+			frame: f,
+			inner: rec,
+			depth: rec.depth + 1,
+		}
+	case error:
+		return &tracedError{
+			error: rec,
+			frame: f,
+			depth: 1,
+		}
+	default:
+		return rec
+	}
+}
+
+// Frames returns the Ale call Frames that an error propagated through, from
+// innermost to outermost
+func Frames(err error) []*Frame {
+	var t *tracedError
+	if errors.As(err, &t) {
+		return t.frames()
+	}
+	return nil
+}
+
+// Stack converts a set of Frames into a Vector of Objects that can be
+// inspected by Ale code
+func Stack(frames []*Frame) data.Vector {
+	res := make(data.Vector, len(frames))
+	for i, f := range frames {
+		res[i] = f.Object()
+	}
+	return res
+}
+
+// Recovered converts a recovered panic value into the Value that is passed to
+// Ale rescue code. Errors are passed along as Errors that carry the stack they
+// propagated through, while raised Values that aren't errors are passed along
+// unchanged
+func Recovered(rec any) ale.Value {
+	frames := framesOf(rec)
+	switch rec := NormalizeGoRuntimeError(Untraced(rec)).(type) {
+	case *raisedValue:
+		return rec.value
+	case error:
+		res := data.WrapError(rec)
+		if len(frames) == 0 || res.Stack() != nil {
+			return res
+		}
+		return res.WithStack(Stack(frames))
+	case ale.Value:
+		return rec
+	default:
+		panic(debug.ProgrammerError("recover returned invalid result"))
+	}
+}
+
+// Untraced strips the trace from a recovered panic value, returning the value
+// that was originally raised
+func Untraced(rec any) any {
+	if t, ok := rec.(*tracedError); ok {
+		return t.error
+	}
+	return rec
+}
+
+func framesOf(rec any) []*Frame {
+	if t, ok := rec.(*tracedError); ok {
+		return t.frames()
+	}
+	return nil
+}
+
+func (t *tracedError) Error() string {
+	var buf strings.Builder
+	buf.WriteString(t.error.Error())
+	for _, f := range t.frames() {
+		buf.WriteString(traceAt)
+		buf.WriteString(f.String())
+	}
+	return buf.String()
+}
+
+func (t *tracedError) frames() []*Frame {
+	res := make([]*Frame, t.depth)
+	for i := t; i != nil; i = i.inner {
+		res[i.depth-1] = i.frame
+	}
+	return res
+}
+
+func (t *tracedError) Unwrap() error {
+	return t.error
+}
+
+// Object returns the Frame as an Object that can be inspected by Ale code
+func (f *Frame) Object() *data.Object {
+	pairs := data.Pairs{
+		data.NewCons(DomainKey, f.Domain),
+		data.NewCons(ArgsKey, data.Integer(f.ArgCount)),
+	}
+	if f.Name != "" {
+		pairs = append(pairs, data.NewCons(NameKey, f.Name))
+	}
+	if l := f.Location; l != nil {
+		pairs = append(pairs,
+			data.NewCons(SourceKey, data.String(l.Source)),
+			data.NewCons(LineKey, data.Integer(l.Line)),
+			data.NewCons(ColumnKey, data.Integer(l.Column)),
+		)
+	}
+	return data.NewObject(pairs...)
+}
+
+func (f *Frame) String() string {
+	var buf strings.Builder
+	buf.WriteString(f.qualifiedName())
+	if f.Location != nil {
+		buf.WriteString(" (")
+		buf.WriteString(f.Location.String())
+		buf.WriteString(")")
+	}
+	return buf.String()
+}
+
+func (f *Frame) qualifiedName() string {
+	var buf strings.Builder
+	if f.Domain != "" {
+		buf.WriteString(string(f.Domain))
+		buf.WriteString(lang.DomainSeparator)
+	}
+	if f.Name != "" {
+		buf.WriteString(string(f.Name))
+	} else {
+		buf.WriteString(anonymous)
+	}
+	return buf.String()
+}
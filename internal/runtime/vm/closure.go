@@ -1,7 +1,7 @@
 package vm
 
 import (
-	"errors"
+	"context"
 	"slices"
 	"sync/atomic"
 
@@ -9,6 +9,7 @@ import (
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/env"
 	"github.com/kode4food/ale/internal/debug"
+	"github.com/kode4food/ale/internal/runtime"
 	"github.com/kode4food/ale/internal/runtime/isa"
 	"github.com/kode4food/ale/internal/sequence"
 	"github.com/kode4food/ale/internal/sync"
@@ -51,15 +52,60 @@ func (c *Closure) Captured() data.Vector {
 	return c.captured
 }
 
-// Call turns Closure into a Procedure, and serves as the virtual machine
+// Call turns Closure into a Procedure. It runs the Closure with a background
+// context, so only calls made through CallContext can be cancelled or limited
 func (c *Closure) Call(args ...ale.Value) ale.Value {
+	return c.CallContext(context.Background(), args...)
+}
+
+// CallContext runs the Closure with the provided context, and serves as the
+// virtual machine. If the context is done when the Closure is entered or
+// re-entered by a tail call, a cancellation error is raised. If the context
+// carries Limits, calls and instructions are metered against them, and if it
+// carries a Profiler, calls are recorded on its shadow stack
+func (c *Closure) CallContext(
+	ctx context.Context, args ...ale.Value,
+) (res ale.Value) {
 	var MEM data.Vector
 	var CODE isa.Instructions
 	var PC, LP, SP int
 	var INST isa.Instruction
 	var AP *argStack
+	var STEPS int
+	DONE := ctx.Done()
+	METER := runtime.MeterOf(ctx)
+	COUNT := METER.Counting()
+	if METER != nil {
+		METER.Enter()
+	}
+	PROFILER := runtime.ProfilerOf(ctx)
+	if PROFILER != nil {
+		PROFILER.Enter(c.profileFrame())
+	}
 
-	defer func() { free(MEM) }()
+	defer func() {
+		free(MEM)
+		if METER != nil {
+			METER.Leave()
+		}
+		if PROFILER != nil {
+			PROFILER.Leave()
+		}
+		if res != nil {
+			if COUNT {
+				METER.Step(STEPS)
+			}
+			return
+		}
+		if COUNT {
+			METER.Flush(STEPS)
+		}
+		// Only a panic leaves the result unset, so a returning frame never
+		// has to recover in order to add itself to the trace
+		if rec := recover(); rec != nil {
+			panic(c.traceFrame(rec, PC, len(args)))
+		}
+	}()
 
 InitMem:
 	MEM = malloc(int(c.StackSize + c.LocalCount))
@@ -71,8 +117,21 @@ InitCode:
 InitState:
 	SP = LP - 1
 	PC = 0
+	if DONE != nil {
+		select {
+		case <-DONE:
+			panic(runtime.ContextError(ctx))
+		default:
+		}
+	}
 
 CurrentPC:
+	if COUNT {
+		if STEPS++; STEPS == runtime.MeterBatch {
+			STEPS = 0
+			METER.Step(runtime.MeterBatch)
+		}
+	}
 	INST = CODE[PC]
 	switch INST.Opcode() {
 
@@ -141,7 +200,7 @@ CurrentPC:
 
 	case isa.EnvValue:
 		SP1 := SP + 1
-		MEM[SP1] = env.MustResolveValue(c.Globals, MEM[SP1].(data.Symbol))
+		MEM[SP1] = c.resolveGlobal(PC, MEM[SP1].(data.Symbol))
 
 	// Reference and Register Operations:
 	case isa.Load:
@@ -208,44 +267,45 @@ CurrentPC:
 		fn := MEM[SP1].(data.Procedure)
 		callArgs := MEM[SP2 : SP2+int(op)]
 		RES := SP1 + int(op)
-		MEM[RES] = fn.Call(callArgs...)
+		MEM[RES] = call(ctx, fn, callArgs...)
 		SP = RES - 1
 
 	case isa.Call0:
 		SP1 := SP + 1
-		MEM[SP1] = MEM[SP1].(data.Procedure).Call()
+		MEM[SP1] = call(ctx, MEM[SP1].(data.Procedure))
 
 	case isa.Call1:
 		SP2 := SP + 2
 		SP++
-		MEM[SP2] = MEM[SP].(data.Procedure).Call(MEM[SP2])
+		MEM[SP2] = call(ctx, MEM[SP].(data.Procedure), MEM[SP2])
 
 	case isa.Call2:
 		SP1 := SP + 1
 		SP3 := SP + 3
 		SP += 2
-		MEM[SP3] = MEM[SP1].(data.Procedure).Call(MEM[SP], MEM[SP3])
+		MEM[SP3] = call(ctx, MEM[SP1].(data.Procedure), MEM[SP], MEM[SP3])
 
 	case isa.Call3:
 		SP1 := SP + 1
 		SP2 := SP + 2
 		SP4 := SP + 4
 		SP += 3
-		MEM[SP4] = MEM[SP1].(data.Procedure).Call(MEM[SP2], MEM[SP], MEM[SP4])
+		fn := MEM[SP1].(data.Procedure)
+		MEM[SP4] = call(ctx, fn, MEM[SP2], MEM[SP], MEM[SP4])
 
 	case isa.CallSelf:
 		op := INST.Operand()
 		SP1 := SP + 1
 		callArgs := MEM[SP1 : SP1+int(op)]
 		RES := SP + int(op)
-		MEM[RES] = c.Call(callArgs...)
+		MEM[RES] = c.CallContext(ctx, callArgs...)
 		SP = RES - 1
 
 	case isa.CallWith:
 		SP1 := SP + 2
 		SP++
 		callArgs := sequence.ToVector(MEM[SP1].(data.Sequence))
-		MEM[SP1] = MEM[SP].(data.Procedure).Call(callArgs...)
+		MEM[SP1] = call(ctx, MEM[SP].(data.Procedure), callArgs...)
 
 	case isa.TailCall: // Fully dynamic tail call
 		op := INST.Operand()
@@ -255,13 +315,16 @@ CurrentPC:
 		callArgs := MEM[SP2 : SP2+int(op)]
 		cl, ok := val.(*Closure)
 		if !ok {
-			return val.(data.Procedure).Call(callArgs...)
+			return call(ctx, val.(data.Procedure), callArgs...)
 		}
 		args = slices.Clone(callArgs)
 		if cl == c {
 			goto InitState
 		}
 		c = cl
+		if PROFILER != nil {
+			PROFILER.Replace(c.profileFrame())
+		}
 		if len(MEM) < int(c.StackSize+c.LocalCount) {
 			free(MEM)
 			goto InitMem
@@ -274,6 +337,9 @@ CurrentPC:
 		SP2 := SP1 + 1
 		c = MEM[SP1].(*Closure)
 		args = slices.Clone(MEM[SP2 : SP2+int(op)])
+		if PROFILER != nil {
+			PROFILER.Replace(c.profileFrame())
+		}
 		if len(MEM) < int(c.StackSize+c.LocalCount) {
 			free(MEM)
 			goto InitMem
@@ -303,7 +369,7 @@ CurrentPC:
 		goto CurrentPC
 
 	case isa.Panic:
-		panic(errors.New(data.ToString(MEM[SP+1])))
+		panic(runtime.RaiseValue(MEM[SP+1]))
 
 	case isa.RetFalse:
 		return data.False
@@ -363,7 +429,7 @@ CurrentPC:
 
 	case isa.LazySeq:
 		SP1 := SP + 1
-		r := sequence.MakeLazyResolver(MEM[SP1].(data.Procedure))
+		r := sequence.MakeLazyResolver(ctx, MEM[SP1].(data.Procedure))
 		MEM[SP1] = sequence.NewLazy(r)
 
 	case isa.Length:
@@ -475,6 +541,19 @@ CurrentPC:
 	goto CurrentPC
 }
 
+// call invokes a Procedure from within the virtual machine, passing along the
+// context of the running Closure to any Procedure that can observe it
+func call(ctx context.Context, fn data.Procedure, args ...ale.Value) ale.Value {
+	switch fn := fn.(type) {
+	case *Closure:
+		return fn.CallContext(ctx, args...)
+	case runtime.ContextCaller:
+		return fn.CallContext(ctx, args...)
+	default:
+		return fn.Call(args...)
+	}
+}
+
 // CheckArity performs a compile-time arity check for the Closure
 func (c *Closure) CheckArity(i int) error {
 	return c.ArityChecker(i)
@@ -502,10 +581,26 @@ func (c *Closure) HashCode() uint64 {
 	return res
 }
 
+func (c *Closure) traceFrame(rec any, pc, argc int) any {
+	loc, _ := c.Positions.Lookup(pc)
+	f := &runtime.Frame{
+		Name:     c.Name,
+		ArgCount: argc,
+		Location: loc,
+	}
+	if c.Globals != nil {
+		f.Domain = c.Globals.Domain()
+	}
+	return runtime.WithFrame(rec, f)
+}
+
 func bindOrShadow(ns env.Namespace, n data.Local, v ale.Value) error {
 	e, in, err := ns.Resolve(n)
 	if err != nil || in != ns {
 		return env.BindPublic(ns, n, v)
 	}
+	if e.IsBound() && ns.Environment().LateBinding() {
+		return ns.Rebind(n, v)
+	}
 	return e.Bind(v)
 }
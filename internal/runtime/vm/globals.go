@@ -0,0 +1,55 @@
+package vm
+
+import (
+	"sync/atomic"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/env"
+)
+
+type (
+	// globalCache holds the entries that a Procedure's EnvValue instructions
+	// have resolved, indexed by the position of the instruction
+	globalCache []atomic.Pointer[cachedEntry]
+
+	cachedEntry struct {
+		entry      *env.Entry
+		generation uint64
+	}
+)
+
+// resolveGlobal resolves the symbol of the EnvValue instruction at the
+// provided position. The resolved entry is reused until the Environment
+// changes, so that its current value is retrieved without resolving the
+// symbol again
+func (p *Procedure) resolveGlobal(pc int, s data.Symbol) ale.Value {
+	gen := p.Globals.Environment().Generation()
+	cache := p.globalCache()
+	if c := cache[pc].Load(); c != nil && c.generation == gen {
+		if v, err := c.entry.Value(); err == nil {
+			return v
+		}
+	}
+	e, _, err := env.ResolveSymbol(p.Globals, s)
+	if err != nil {
+		panic(err)
+	}
+	v, err := e.Value()
+	if err != nil {
+		panic(err)
+	}
+	cache[pc].Store(&cachedEntry{entry: e, generation: gen})
+	return v
+}
+
+func (p *Procedure) globalCache() globalCache {
+	if c := p.globals.Load(); c != nil {
+		return *c
+	}
+	c := make(globalCache, len(p.Code))
+	if p.globals.CompareAndSwap(nil, &c) {
+		return c
+	}
+	return *p.globals.Load()
+}
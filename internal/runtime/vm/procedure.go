@@ -8,15 +8,26 @@ import (
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/basics"
+	"github.com/kode4food/ale/internal/compiler/ir/analysis"
+	"github.com/kode4food/ale/internal/lang/params"
+	"github.com/kode4food/ale/internal/runtime"
 	"github.com/kode4food/ale/internal/runtime/isa"
 	"github.com/kode4food/ale/internal/types"
 )
 
-// Procedure encapsulates the initial environment of an abstract machine
+// Procedure encapsulates the initial environment of an abstract machine.
+// Signatures are only provided when the parameters or results of the
+// Procedure have been annotated with Types, and aren't written to bytecode
 type Procedure struct {
 	ArityChecker data.ArityChecker
+	Arity        *params.Arity
+	Name         data.Local
+	Signatures   []types.Signature
 	isa.Runnable
-	hash atomic.Uint64
+	globals atomic.Pointer[globalCache]
+	result  atomic.Pointer[ale.Type]
+	profile atomic.Pointer[runtime.Frame]
+	hash    atomic.Uint64
 }
 
 var (
@@ -51,11 +62,71 @@ func (p *Procedure) CheckArity(int) error {
 	return nil
 }
 
-// Type makes Procedure a typed value
+// Type makes Procedure a typed value. If the Procedure has Signatures, its
+// Type is an Applicable that describes them
 func (p *Procedure) Type() ale.Type {
+	if s := p.Signatures; len(s) > 0 {
+		return types.MakeApplicable(s[0], s[1:]...)
+	}
 	return types.MakeLiteral(types.BasicProcedure, p)
 }
 
+// ResultType returns the Type of the values that the Procedure returns when
+// called with the specified number of arguments. Without an annotated result
+// to consult, the Type is inferred from the Procedure's instructions the
+// first time it's requested. A Procedure that is requested while its own
+// Type is being inferred is assumed to return any Type
+func (p *Procedure) ResultType(argc int) ale.Type {
+	if s, ok := p.signature(argc); ok {
+		if _, ok := s.Result.(*types.Any); !ok {
+			return s.Result
+		}
+	}
+	if res := p.result.Load(); res != nil {
+		return *res
+	}
+	var res ale.Type = types.BasicAny
+	if !p.result.CompareAndSwap(nil, &res) {
+		return *p.result.Load()
+	}
+	if t, err := analysis.InferResult(&p.Runnable); err == nil {
+		p.result.Store(&t)
+		return t
+	}
+	return res
+}
+
+// profileFrame returns the Frame that identifies the Procedure to a Profiler.
+// Its Location is the first source position of the Procedure's instructions
+func (p *Procedure) profileFrame() *runtime.Frame {
+	if f := p.profile.Load(); f != nil {
+		return f
+	}
+	f := &runtime.Frame{Name: p.Name}
+	if p.Globals != nil {
+		f.Domain = p.Globals.Domain()
+	}
+	for _, pos := range p.Positions {
+		if pos.Location != nil {
+			f.Location = pos.Location
+			break
+		}
+	}
+	if !p.profile.CompareAndSwap(nil, f) {
+		return p.profile.Load()
+	}
+	return f
+}
+
+func (p *Procedure) signature(argc int) (types.Signature, bool) {
+	for _, s := range p.Signatures {
+		if s.AppliesTo(argc) {
+			return s, true
+		}
+	}
+	return types.Signature{}, false
+}
+
 func (p *Procedure) Get(key ale.Value) (ale.Value, bool) {
 	return data.DumpMapped(p).Get(key)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/kode4food/ale/data"
 	"github.com/kode4food/ale/internal/assert"
 	"github.com/kode4food/ale/internal/runtime/vm"
+	"github.com/kode4food/ale/internal/types"
 )
 
 func TestProcedureHashCode(t *testing.T) {
@@ -33,3 +34,22 @@ func TestProcedureCaptured(t *testing.T) {
 	as.False(res[0].Equal(res[3]))
 	as.False(data.HashCode(res[0]) == data.HashCode(res[3]))
 }
+
+func TestProcedureTypes(t *testing.T) {
+	as := assert.New(t)
+	p1 := as.MustEval(`(lambda (x) (* x 2))`).(*vm.Closure)
+	_, ok := p1.Type().(*types.Literal)
+	as.True(ok)
+	as.Equal(types.BasicNumber, p1.ResultType(1))
+
+	p2 := as.MustEval(`
+		(lambda-rec typed
+			[([x :number]) (typed x 2)]
+			[([x :number] [y :string]) :- :vector [x y]])
+	`).(*vm.Closure)
+	as.Equal(
+		"procedure(number->any,number,string->vector)", p2.Type().Name(),
+	)
+	as.Equal(types.BasicVector, p2.ResultType(2))
+	as.Equal(types.BasicVector, p2.ResultType(1))
+}
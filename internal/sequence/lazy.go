@@ -1,8 +1,11 @@
 package sequence
 
 import (
+	"context"
+
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/runtime"
 	"github.com/kode4food/ale/internal/sync"
 	"github.com/kode4food/ale/internal/types"
 )
@@ -36,9 +39,11 @@ func NewLazy(r LazyResolver) data.Sequence {
 	}
 }
 
-func MakeLazyResolver(p data.Procedure) LazyResolver {
+// MakeLazyResolver returns a LazyResolver that calls the provided Procedure
+// with the context of the evaluation that created the lazy Sequence
+func MakeLazyResolver(ctx context.Context, p data.Procedure) LazyResolver {
 	return func() (ale.Value, data.Sequence, bool) {
-		r := p.Call()
+		r := runtime.Call(ctx, p)
 		if r != data.Null {
 			s := r.(data.Sequence)
 			if sf, sr, ok := s.Split(); ok {
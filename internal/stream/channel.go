@@ -1,23 +1,35 @@
 package stream
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"runtime"
+	"sync"
 
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
-	"github.com/kode4food/ale/internal/sync"
+	aleruntime "github.com/kode4food/ale/internal/runtime"
 	"github.com/kode4food/ale/internal/types"
 )
 
 type (
 	chanEmitter struct {
-		ch chan<- ale.Value
-		cl runtime.Cleanup
+		ctx context.Context
+		ch  chan<- ale.Value
+		cl  runtime.Cleanup
 	}
 
 	chanSequence struct {
-		once sync.Action
-		ch   <-chan ale.Value
+		sync.Mutex
+		ctx context.Context
+		ch  <-chan ale.Value
+
+		// claim is non-nil while a receive from ch is in progress on behalf
+		// of this sequence. It is closed when that receive completes or is
+		// abandoned by a Select
+		claim    chan struct{}
+		resolved bool
 
 		result ale.Value
 		rest   data.Sequence
@@ -28,26 +40,33 @@ type (
 var (
 	chanSequenceType = types.MakeBasic("channel-sequence")
 
-	// compile-time check for interface implementation
+	// compile-time checks for interface implementation
 	_ data.Prepender = (*chanSequence)(nil)
+	_ data.Procedure = (*chanEmitter)(nil)
 )
 
 // NewChannel produces an Emitter and Sequence pair
 func NewChannel(size int) *data.Object {
+	return NewChannelContext(context.Background(), size)
+}
+
+// NewChannelContext produces an Emitter and Sequence pair whose blocking
+// operations raise a cancellation error once the provided context is done
+func NewChannelContext(ctx context.Context, size int) *data.Object {
 	ch := make(chan ale.Value, size)
-	e := newEmitter(ch)
-	s := NewChannelSequence(ch)
+	e := newEmitter(ctx, ch)
+	s := newChannelSequence(ctx, ch)
 
 	return data.NewObject(
-		data.NewCons(EmitKey, bindWriter(e.Write)),
+		data.NewCons(EmitKey, e),
 		data.NewCons(CloseKey, bindCloser(e)),
 		data.NewCons(SequenceKey, s),
 	)
 }
 
 // newEmitter produces an Emitter for sending values to a Go chan
-func newEmitter(ch chan<- ale.Value) *chanEmitter {
-	r := &chanEmitter{ch: ch}
+func newEmitter(ctx context.Context, ch chan<- ale.Value) *chanEmitter {
+	r := &chanEmitter{ctx: ctx, ch: ch}
 	r.cl = runtime.AddCleanup(r, func(c chan<- ale.Value) {
 		defer func() { _ = recover() }()
 		close(c)
@@ -57,7 +76,31 @@ func newEmitter(ch chan<- ale.Value) *chanEmitter {
 
 // Write will send a Value to the Go chan
 func (e *chanEmitter) Write(v ale.Value) {
-	e.ch <- v
+	select {
+	case e.ch <- v:
+	case <-e.ctx.Done():
+		panic(aleruntime.ContextError(e.ctx))
+	}
+}
+
+// Call will send each of the provided Values to the Go chan
+func (e *chanEmitter) Call(args ...ale.Value) ale.Value {
+	for _, v := range args {
+		e.Write(v)
+	}
+	return data.Null
+}
+
+func (e *chanEmitter) CheckArity(int) error {
+	return nil
+}
+
+func (e *chanEmitter) Type() ale.Type {
+	return types.MakeLiteral(types.BasicProcedure, e)
+}
+
+func (e *chanEmitter) Equal(other ale.Value) bool {
+	return e == other
 }
 
 // Close will Close the Go chan
@@ -70,24 +113,97 @@ func (e *chanEmitter) Close() (err error) {
 
 // NewChannelSequence produces a new Sequence whose values come from a Go chan
 func NewChannelSequence(ch <-chan ale.Value) data.Sequence {
-	return &chanSequence{
-		once: sync.Once(),
-		ch:   ch,
+	return newChannelSequence(context.Background(), ch)
+}
+
+// NewDoneSequence produces a new Sequence that ends once the provided context
+// is done, and never ends if the context can't be. Each Sequence has its own
+// hook into the context, which is released once the Sequence is unreachable,
+// even if the context never ends
+func NewDoneSequence(ctx context.Context) data.Sequence {
+	ch := make(chan ale.Value)
+	res := newChannelSequence(context.Background(), ch)
+	if ctx.Done() == nil {
+		return res
 	}
+	stop := context.AfterFunc(ctx, func() { close(ch) })
+	runtime.AddCleanup(res, func(stop func() bool) { stop() }, stop)
+	return res
+}
+
+func newChannelSequence(
+	ctx context.Context, ch <-chan ale.Value,
+) *chanSequence {
+	return &chanSequence{ctx: ctx, ch: ch}
 }
 
 func (c *chanSequence) resolve() *chanSequence {
-	c.once(func() {
-		result, ok := <-c.ch
-		if !ok {
-			return
-		}
+	c.Lock()
+	for !c.resolved && c.claim != nil {
+		claim := c.claim
+		c.Unlock()
+		<-claim
+		c.Lock()
+	}
+	if c.resolved {
+		c.Unlock()
+		return c
+	}
+	c.claim = make(chan struct{})
+	c.Unlock()
+
+	select {
+	case result, ok := <-c.ch:
+		c.Lock()
+		c.settle(result, ok)
+		c.Unlock()
+		return c
+	case <-c.ctx.Done():
+		c.release()
+		panic(aleruntime.ContextError(c.ctx))
+	}
+}
+
+// tryClaim attempts to reserve the right to receive from this sequence's Go
+// chan. If another receive is already in progress, its claim is returned so
+// that the caller can wait for it to complete
+func (c *chanSequence) tryClaim() (bool, <-chan struct{}) {
+	c.Lock()
+	defer c.Unlock()
+	if c.resolved {
+		return false, nil
+	}
+	if c.claim != nil {
+		return false, c.claim
+	}
+	c.claim = make(chan struct{})
+	return true, nil
+}
+
+// release abandons a claim made by tryClaim if nothing has been received
+func (c *chanSequence) release() {
+	c.Lock()
+	defer c.Unlock()
+	if !c.resolved {
+		close(c.claim)
+		c.claim = nil
+	}
+}
+
+// settle resolves the sequence with a received value and releases its claim.
+// The caller must hold the sequence's lock
+func (c *chanSequence) settle(result ale.Value, ok bool) {
+	c.resolved = true
+	if !ok {
+		c.result = data.Null
+		c.rest = data.Null
+	} else {
 		c.ok = ok
 		c.result = result
-		c.rest = NewChannelSequence(c.ch)
-	})
-
-	return c
+		c.rest = newChannelSequence(c.ctx, c.ch)
+	}
+	close(c.claim)
+	c.claim = nil
 }
 
 func (c *chanSequence) IsEmpty() bool {
@@ -109,10 +225,10 @@ func (c *chanSequence) Split() (ale.Value, data.Sequence, bool) {
 
 func (c *chanSequence) Prepend(v ale.Value) data.Sequence {
 	return &chanSequence{
-		once:   sync.Never(),
-		ok:     true,
-		result: v,
-		rest:   c,
+		resolved: true,
+		ok:       true,
+		result:   v,
+		rest:     c,
 	}
 }
 
@@ -127,3 +243,10 @@ func (c *chanSequence) Equal(other ale.Value) bool {
 func (c *chanSequence) Get(key ale.Value) (ale.Value, bool) {
 	return data.DumpMapped(c).Get(key)
 }
+
+// Format writes the sequence as Ale would print it, so that it doesn't appear
+// as a raw struct when formatted by Go. It can't be a fmt.Stringer, because
+// the sequence's literal Type would then name it using that String
+func (c *chanSequence) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, data.DumpString(c))
+}
@@ -1,6 +1,9 @@
 package stream_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -28,6 +31,7 @@ func TestChannel(t *testing.T) {
 	v, _ = ch.Get(stream.SequenceKey)
 	seq = v.(data.Prepender).Prepend(F(1))
 	as.Contains(":type channel-sequence", seq)
+	as.Contains(":type channel-sequence", S(fmt.Sprint(seq)))
 
 	var wg sync.WaitGroup
 
@@ -71,3 +75,57 @@ func TestChannel(t *testing.T) {
 	go check()
 	wg.Wait()
 }
+
+func TestChannelContext(t *testing.T) {
+	as := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := stream.NewChannelContext(ctx, 0)
+	emit := as.MustGet(ch, stream.EmitKey).(data.Procedure)
+	cl := as.MustGet(ch, stream.CloseKey).(data.Procedure)
+	seq := as.MustGet(ch, stream.SequenceKey).(data.Sequence)
+
+	go emit.Call(S("hello"))
+	as.String("hello", seq.Car())
+
+	go cancel()
+	as.Panics(func() { seq.Cdr().(data.Sequence).Car() },
+		errors.New("context canceled"),
+	)
+	as.Panics(func() { emit.Call(S("blocked")) },
+		errors.New("context canceled"),
+	)
+
+	cl.Call()
+	as.String("hello", seq.Car())
+}
+
+func TestClosedChannel(t *testing.T) {
+	as := assert.New(t)
+
+	ch := stream.NewChannel(0)
+	as.MustGet(ch, stream.CloseKey).(data.Procedure).Call()
+	seq := as.MustGet(ch, stream.SequenceKey).(data.Sequence)
+	as.True(seq.IsEmpty())
+	as.Equal(data.Null, seq.Car())
+	as.Equal(data.Null, seq.Cdr())
+}
+
+func TestDoneSequence(t *testing.T) {
+	as := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s1 := stream.NewDoneSequence(ctx)
+	s2 := stream.NewDoneSequence(ctx)
+	as.NotIdentical(s1, s2)
+
+	cancel()
+	as.True(s1.IsEmpty())
+	as.True(s2.IsEmpty())
+
+	never := stream.NewDoneSequence(context.Background())
+	idx, _ := stream.Select([]stream.SelectCase{
+		stream.RecvCase(never),
+	}, time.Millisecond)
+	as.Equal(-1, idx)
+}
@@ -0,0 +1,223 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+)
+
+// JSONKeys converts the keys of a decoded JSON object into Values
+type JSONKeys func(string) ale.Value
+
+const (
+	// ErrUnsupportedJSON is raised when a value can't be represented as JSON
+	ErrUnsupportedJSON = "value can't be represented as JSON: %s"
+
+	// ErrUnsupportedJSONKey is raised when an object key can't be represented
+	// as a JSON object key
+	ErrUnsupportedJSONKey = "key can't be represented as JSON: %s"
+
+	// ErrTrailingJSON is raised when JSON text contains more than one value
+	ErrTrailingJSON = "unexpected data after JSON value"
+)
+
+// JSONErrorKind identifies an error raised when JSON text can't be decoded, or
+// when a Value can't be encoded as JSON
+const JSONErrorKind = data.Keyword("json-error")
+
+// KeywordKeys decodes the keys of JSON objects as Keywords
+func KeywordKeys(k string) ale.Value {
+	return data.Keyword(k)
+}
+
+// StringKeys decodes the keys of JSON objects as Strings
+func StringKeys(k string) ale.Value {
+	return data.String(k)
+}
+
+// ReadJSON decodes a single JSON value into its Ale equivalent. Objects become
+// Objects, arrays become Vectors, and numbers become Integers or Floats. A
+// failure is reported as an Error of JSONErrorKind
+func ReadJSON(b []byte, keys JSONKeys) (ale.Value, error) {
+	res, err := readJSON(b, keys)
+	if err != nil {
+		return nil, jsonError(err)
+	}
+	return res, nil
+}
+
+// WriteJSON encodes a Value as JSON text. If an indent is provided, the text
+// is pretty-printed using it. A failure is reported as an Error of
+// JSONErrorKind
+func WriteJSON(v ale.Value, indent string) (string, error) {
+	res, err := writeJSON(v, indent)
+	if err != nil {
+		return "", jsonError(err)
+	}
+	return res, nil
+}
+
+func readJSON(b []byte, keys JSONKeys) (ale.Value, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New(ErrTrailingJSON)
+	}
+	return fromJSON(v, keys)
+}
+
+func writeJSON(v ale.Value, indent string) (string, error) {
+	j, err := toJSON(v)
+	if err != nil {
+		return "", err
+	}
+	var buf strings.Builder
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(j); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// JSONInput creates an InputFunc that decodes newline-delimited JSON, one
+// value per line. Blank lines are skipped
+func JSONInput(keys JSONKeys) InputFunc {
+	return func(r *bufio.Reader) (ale.Value, bool) {
+		for {
+			l, ok := LineInput(r)
+			if !ok {
+				return data.Null, false
+			}
+			if s := strings.TrimSpace(string(l.(data.String))); s != "" {
+				res, err := ReadJSON([]byte(s), keys)
+				if err != nil {
+					panic(err)
+				}
+				return res, true
+			}
+		}
+	}
+}
+
+func fromJSON(v any, keys JSONKeys) (ale.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return data.Null, nil
+	case bool:
+		return data.Bool(v), nil
+	case string:
+		return data.String(v), nil
+	case json.Number:
+		if strings.ContainsAny(string(v), ".eE") {
+			return data.ParseFloat(string(v))
+		}
+		return data.ParseInteger(string(v))
+	case []any:
+		res := make(data.Vector, len(v))
+		for i, e := range v {
+			ev, err := fromJSON(e, keys)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = ev
+		}
+		return res, nil
+	case map[string]any:
+		res := make([]data.Pair, 0, len(v))
+		for k, e := range v {
+			ev, err := fromJSON(e, keys)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, data.NewCons(keys(k), ev))
+		}
+		return data.NewObject(res...), nil
+	default:
+		return nil, fmt.Errorf(ErrUnsupportedJSON, v)
+	}
+}
+
+func toJSON(v ale.Value) (any, error) {
+	switch v := v.(type) {
+	case data.Bool:
+		return bool(v), nil
+	case data.Integer:
+		return int64(v), nil
+	case *data.BigInt:
+		return json.Number(v.String()), nil
+	case data.Float:
+		if v.IsNaN() || v.IsPosInf() || v.IsNegInf() {
+			return nil, fmt.Errorf(ErrUnsupportedJSON, v)
+		}
+		return json.Number(v.String()), nil
+	case *data.Ratio:
+		f, _ := (*big.Rat)(v).Float64()
+		return f, nil
+	case data.String:
+		return string(v), nil
+	case data.Keyword:
+		return string(v), nil
+	case data.Symbol:
+		return data.ToString(v), nil
+	case *data.Object:
+		return objectToJSON(v)
+	case data.Sequence:
+		if v == data.Null {
+			return nil, nil
+		}
+		return sequenceToJSON(v)
+	default:
+		return nil, fmt.Errorf(ErrUnsupportedJSON, data.ToString(v))
+	}
+}
+
+func objectToJSON(o *data.Object) (any, error) {
+	res := make(map[string]any, o.Count())
+	for _, p := range o.Pairs() {
+		var k string
+		switch pk := p.Car().(type) {
+		case data.Keyword:
+			k = string(pk)
+		case data.String:
+			k = string(pk)
+		default:
+			return nil, fmt.Errorf(ErrUnsupportedJSONKey, data.ToString(pk))
+		}
+		v, err := toJSON(p.Cdr())
+		if err != nil {
+			return nil, err
+		}
+		res[k] = v
+	}
+	return res, nil
+}
+
+func sequenceToJSON(s data.Sequence) (any, error) {
+	res := []any{}
+	for f, r, ok := s.Split(); ok; f, r, ok = r.Split() {
+		v, err := toJSON(f)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func jsonError(err error) error {
+	return data.NewError(JSONErrorKind, err.Error(), data.Null).WithCause(err)
+}
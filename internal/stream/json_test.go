@@ -0,0 +1,81 @@
+package stream_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/stream"
+)
+
+func TestReadJSON(t *testing.T) {
+	as := assert.New(t)
+
+	v, err := stream.ReadJSON(
+		[]byte(`{"a": [1, 2.5, null, true, "s"], "b": {"c": -1}}`),
+		stream.KeywordKeys,
+	)
+	as.Nil(err)
+	as.Equal(O(
+		C(K("a"), V(I(1), F(2.5), data.Null, data.True, S("s"))),
+		C(K("b"), O(C(K("c"), I(-1)))),
+	), v)
+
+	v, err = stream.ReadJSON([]byte(`{"a": 1}`), stream.StringKeys)
+	as.Nil(err)
+	as.Equal(O(C(S("a"), I(1))), v)
+
+	v, err = stream.ReadJSON([]byte(`12345678901234567890`), nil)
+	as.Nil(err)
+	as.String("12345678901234567890", v)
+	_, ok := v.(*data.BigInt)
+	as.True(ok)
+
+	_, err = stream.ReadJSON([]byte(`[1`), stream.KeywordKeys)
+	as.EqualError(err, "unexpected EOF")
+	_, err = stream.ReadJSON([]byte(`1 2`), stream.KeywordKeys)
+	as.EqualError(err, stream.ErrTrailingJSON)
+	as.Equal(stream.JSONErrorKind, err.(*data.Error).Kind())
+}
+
+func TestWriteJSON(t *testing.T) {
+	as := assert.New(t)
+
+	s, err := stream.WriteJSON(O(
+		C(K("b"), V(I(1), F(2), R(1, 2), data.Null)),
+		C(S("a"), L(K("kw"), LS("sym"), S("<&>"))),
+	), "")
+	as.Nil(err)
+	as.String(`{"a":["kw","sym","<&>"],"b":[1,2.0,0.5,null]}`, s)
+
+	s, err = stream.WriteJSON(V(I(1), O(C(K("a"), data.True))), "  ")
+	as.Nil(err)
+	as.String("[\n  1,\n  {\n    \"a\": true\n  }\n]", s)
+
+	_, err = stream.WriteJSON(data.Float(math.Inf(1)), "")
+	as.EqualError(err, fmt.Sprintf(stream.ErrUnsupportedJSON, "+inf"))
+	_, err = stream.WriteJSON(O(C(I(1), I(2))), "")
+	as.EqualError(err, fmt.Sprintf(stream.ErrUnsupportedJSONKey, "1"))
+	as.Equal(stream.JSONErrorKind, err.(*data.Error).Kind())
+}
+
+func TestJSONInput(t *testing.T) {
+	as := assert.New(t)
+
+	b := []byte("{\"a\": 1}\n\n  \n[true]\n\"last\"")
+	input := stream.JSONInput(stream.KeywordKeys)
+	r := stream.NewReader(bytes.NewReader(b), input)
+	as.Equal(O(C(K("a"), I(1))), r.Car())
+	r = r.Cdr().(data.Sequence)
+	as.Equal(V(data.True), r.Car())
+	r = r.Cdr().(data.Sequence)
+	as.String("last", r.Car())
+	as.True(r.Cdr().(data.Sequence).IsEmpty())
+
+	bad := stream.NewReader(bytes.NewReader([]byte("{")), input)
+	as.Panics(func() { bad.IsEmpty() })
+}
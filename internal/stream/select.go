@@ -0,0 +1,167 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	aleruntime "github.com/kode4food/ale/internal/runtime"
+)
+
+// SelectCase is a channel operation that Select can wait on
+type SelectCase struct {
+	seq   data.Sequence
+	emit  *chanEmitter
+	value ale.Value
+}
+
+// Forever can be passed to Select in order to wait without a timeout
+const Forever time.Duration = -1
+
+// ErrExpectedEmitter is raised when a send case is created for a Value that
+// isn't a channel emitter
+const ErrExpectedEmitter = "expected channel emitter, got: %s"
+
+const (
+	selectExpired   = -1
+	selectRetry     = -2
+	selectCancelled = -3
+)
+
+// RecvCase creates a SelectCase that receives the first element of a
+// Sequence. Sequences that aren't backed by a channel are always ready
+func RecvCase(s data.Sequence) SelectCase {
+	return SelectCase{seq: s}
+}
+
+// SendCase creates a SelectCase that sends a Value to a channel emitter
+func SendCase(e ale.Value, v ale.Value) (SelectCase, error) {
+	if e, ok := e.(*chanEmitter); ok {
+		return SelectCase{emit: e, value: v}, nil
+	}
+	return SelectCase{}, fmt.Errorf(ErrExpectedEmitter, data.ToString(e))
+}
+
+// Select waits until one of the provided cases can proceed, performs it, and
+// returns the case's index along with any received Value. A receive from a
+// closed channel proceeds with a Null Value. Given a timeout of zero, Select
+// won't block, and given Forever, it won't time out. If no case proceeds in
+// time, the returned index is -1
+func Select(cases []SelectCase, timeout time.Duration) (int, ale.Value) {
+	return SelectContext(context.Background(), cases, timeout)
+}
+
+// SelectContext performs a Select that raises a cancellation error if the
+// provided context is done before any case proceeds
+func SelectContext(
+	ctx context.Context, cases []SelectCase, timeout time.Duration,
+) (int, ale.Value) {
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		deadline = t.C
+	}
+	for {
+		idx, res := selectOnce(ctx, cases, timeout == 0, deadline)
+		switch idx {
+		case selectRetry:
+			continue
+		case selectCancelled:
+			panic(aleruntime.ContextError(ctx))
+		default:
+			return idx, res
+		}
+	}
+}
+
+func selectOnce(
+	ctx context.Context, cases []SelectCase, poll bool,
+	deadline <-chan time.Time,
+) (int, ale.Value) {
+	var claimed []*chanSequence
+	defer func() {
+		for _, c := range claimed {
+			c.release()
+		}
+	}()
+
+	sc := make([]reflect.SelectCase, 0, len(cases)+2)
+	idx := make([]int, 0, len(cases)+2)
+	for i, c := range cases {
+		if c.emit != nil {
+			sc = append(sc, reflect.SelectCase{
+				Dir:  reflect.SelectSend,
+				Chan: reflect.ValueOf(c.emit.ch),
+				Send: reflect.ValueOf(&c.value).Elem(),
+			})
+			idx = append(idx, i)
+			continue
+		}
+		cs, ok := c.seq.(*chanSequence)
+		if !ok {
+			return i, headOf(c.seq)
+		}
+		ok, claim := cs.tryClaim()
+		switch {
+		case ok:
+			claimed = append(claimed, cs)
+			sc = append(sc, recvCase(cs.ch))
+			idx = append(idx, i)
+		case claim != nil:
+			sc = append(sc, recvCase(claim))
+			idx = append(idx, selectRetry)
+		default:
+			return i, headOf(cs)
+		}
+	}
+
+	if done := ctx.Done(); done != nil {
+		sc = append(sc, recvCase(done))
+		idx = append(idx, selectCancelled)
+	}
+	if deadline != nil {
+		sc = append(sc, recvCase(deadline))
+		idx = append(idx, selectExpired)
+	}
+	if poll {
+		sc = append(sc, reflect.SelectCase{Dir: reflect.SelectDefault})
+		idx = append(idx, selectExpired)
+	}
+
+	chosen, recv, recvOK := reflect.Select(sc)
+	i := idx[chosen]
+	if i < 0 {
+		return i, data.Null
+	}
+	if cases[i].emit != nil {
+		return i, data.Null
+	}
+
+	cs := cases[i].seq.(*chanSequence)
+	var res ale.Value = data.Null
+	if v, ok := recv.Interface().(ale.Value); ok {
+		res = v
+	}
+	cs.Lock()
+	cs.settle(res, recvOK)
+	cs.Unlock()
+	return i, res
+}
+
+func recvCase[T any](ch <-chan T) reflect.SelectCase {
+	return reflect.SelectCase{
+		Dir:  reflect.SelectRecv,
+		Chan: reflect.ValueOf(ch),
+	}
+}
+
+func headOf(s data.Sequence) ale.Value {
+	if f, _, ok := s.Split(); ok {
+		return f
+	}
+	return data.Null
+}
@@ -0,0 +1,141 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/stream"
+)
+
+func getChannel(size int) (data.Procedure, data.Procedure, data.Sequence) {
+	ch := stream.NewChannel(size)
+	emit, _ := ch.Get(stream.EmitKey)
+	cl, _ := ch.Get(stream.CloseKey)
+	seq, _ := ch.Get(stream.SequenceKey)
+	return emit.(data.Procedure), cl.(data.Procedure), seq.(data.Sequence)
+}
+
+func TestSelectRecv(t *testing.T) {
+	as := assert.New(t)
+	_, _, s1 := getChannel(0)
+	e2, _, s2 := getChannel(0)
+
+	go e2.Call(S("hello"))
+	idx, res := stream.Select([]stream.SelectCase{
+		stream.RecvCase(s1), stream.RecvCase(s2),
+	}, stream.Forever)
+	as.Equal(1, idx)
+	as.String("hello", res)
+	as.String("hello", s2.Car())
+
+	idx, res = stream.Select([]stream.SelectCase{
+		stream.RecvCase(s1), stream.RecvCase(s2),
+	}, stream.Forever)
+	as.Equal(1, idx)
+	as.String("hello", res)
+
+	idx, res = stream.Select([]stream.SelectCase{
+		stream.RecvCase(s1), stream.RecvCase(V(I(1), I(2))),
+	}, stream.Forever)
+	as.Equal(1, idx)
+	as.Equal(I(1), res)
+}
+
+func TestSelectExpiry(t *testing.T) {
+	as := assert.New(t)
+	e, cl, s := getChannel(0)
+
+	idx, res := stream.Select([]stream.SelectCase{stream.RecvCase(s)}, 0)
+	as.Equal(-1, idx)
+	as.Equal(data.Null, res)
+
+	start := time.Now()
+	idx, _ = stream.Select(
+		[]stream.SelectCase{stream.RecvCase(s)}, 20*time.Millisecond,
+	)
+	as.Equal(-1, idx)
+	as.True(time.Since(start) >= 20*time.Millisecond)
+
+	// abandoned receives must not lose values
+	go func() {
+		e.Call(I(42))
+		cl.Call()
+	}()
+	as.Equal(I(42), s.Car())
+	as.True(s.Cdr().(data.Sequence).IsEmpty())
+
+	idx, res = stream.Select(
+		[]stream.SelectCase{stream.RecvCase(s.Cdr().(data.Sequence))},
+		stream.Forever,
+	)
+	as.Equal(0, idx)
+	as.Equal(data.Null, res)
+}
+
+func TestSelectSend(t *testing.T) {
+	as := assert.New(t)
+	e, _, s := getChannel(1)
+
+	sc, err := stream.SendCase(e, I(1))
+	as.NoError(err)
+	idx, _ := stream.Select([]stream.SelectCase{sc}, 0)
+	as.Equal(0, idx)
+	idx, _ = stream.Select([]stream.SelectCase{sc}, 0)
+	as.Equal(-1, idx)
+	as.Equal(I(1), s.Car())
+
+	_, err = stream.SendCase(I(1), I(2))
+	as.EqualError(err, fmt.Sprintf(stream.ErrExpectedEmitter, "1"))
+}
+
+func TestSelectContention(t *testing.T) {
+	as := assert.New(t)
+	e, cl, s := getChannel(2)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	for range 2 {
+		go func() {
+			defer wg.Done()
+			_, res := stream.Select(
+				[]stream.SelectCase{stream.RecvCase(s)}, stream.Forever,
+			)
+			as.Equal(I(1), res)
+		}()
+	}
+	go func() {
+		defer wg.Done()
+		as.Equal(I(1), s.Car())
+	}()
+
+	e.Call(I(1), I(2))
+	cl.Call()
+	wg.Wait()
+	as.Equal(I(2), s.Cdr().(data.Sequence).Car())
+}
+
+func TestSelectContext(t *testing.T) {
+	as := assert.New(t)
+	_, _, s1 := getChannel(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	as.Panics(func() {
+		stream.SelectContext(ctx, []stream.SelectCase{
+			stream.RecvCase(s1),
+		}, stream.Forever)
+	}, errors.New("context deadline exceeded"))
+
+	idx, res := stream.SelectContext(
+		context.Background(), []stream.SelectCase{stream.RecvCase(s1)}, 0,
+	)
+	as.Equal(-1, idx)
+	as.Equal(data.Null, res)
+}
@@ -0,0 +1,181 @@
+package stream
+
+import (
+	"bufio"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+)
+
+// WritableFS is a file system that can also create, modify, and remove files
+type WritableFS interface {
+	fs.StatFS
+	OpenFile(name string, flag int, perm fs.FileMode) (io.WriteCloser, error)
+	Mkdir(name string, perm fs.FileMode) error
+	Remove(name string) error
+	Rename(oldName, newName string) error
+}
+
+type rootFS struct {
+	fs.StatFS
+	root *os.Root
+}
+
+const (
+	CreateKey   = data.Keyword("create")
+	AppendKey   = data.Keyword("append")
+	TruncateKey = data.Keyword("truncate")
+	MkdirKey    = data.Keyword("mkdir")
+	RemoveKey   = data.Keyword("remove")
+	RenameKey   = data.Keyword("rename")
+	StatKey     = data.Keyword("stat")
+	GlobKey     = data.Keyword("glob")
+
+	NameKey    = data.Keyword("name")
+	SizeKey    = data.Keyword("size")
+	ModeKey    = data.Keyword("mode")
+	ModTimeKey = data.Keyword("mtime")
+	TypeKey    = data.Keyword("type")
+)
+
+const (
+	defaultFilePerm = 0666
+	defaultDirPerm  = 0777
+)
+
+// RootFS returns a WritableFS whose operations are confined to the directory
+// tree of the provided os.Root
+func RootFS(r *os.Root) WritableFS {
+	return &rootFS{
+		StatFS: r.FS().(fs.StatFS),
+		root:   r,
+	}
+}
+
+func (r *rootFS) OpenFile(
+	name string, flag int, perm fs.FileMode,
+) (io.WriteCloser, error) {
+	return r.root.OpenFile(name, flag, perm)
+}
+
+func (r *rootFS) Mkdir(name string, perm fs.FileMode) error {
+	return r.root.Mkdir(name, perm)
+}
+
+func (r *rootFS) Remove(name string) error {
+	return r.root.Remove(name)
+}
+
+func (r *rootFS) Rename(oldName, newName string) error {
+	return r.root.Rename(oldName, newName)
+}
+
+// WrapWritableFileSystem wraps a WritableFS, exposing the same read operations
+// as WrapFileSystem alongside operations that modify the file system
+func WrapWritableFileSystem(fs WritableFS) *data.Object {
+	return data.NewObject(
+		data.NewCons(ListKey, bindList(fs)),
+		data.NewCons(OpenKey, bindOpen(fs)),
+		data.NewCons(CreateKey, bindOpenWriter(fs, os.O_CREATE|os.O_EXCL)),
+		data.NewCons(AppendKey, bindOpenWriter(fs, os.O_CREATE|os.O_APPEND)),
+		data.NewCons(TruncateKey, bindOpenWriter(fs, os.O_CREATE|os.O_TRUNC)),
+		data.NewCons(MkdirKey, bindMkdir(fs)),
+		data.NewCons(RemoveKey, bindRemove(fs)),
+		data.NewCons(RenameKey, bindRename(fs)),
+		data.NewCons(StatKey, bindStat(fs)),
+		data.NewCons(GlobKey, bindGlob(fs)),
+	)
+}
+
+// FileOutput writes Bytes to a Writer as-is, and all other values as strings
+func FileOutput(w *bufio.Writer, v ale.Value) {
+	if b, ok := v.(data.Bytes); ok {
+		_, _ = w.Write(b)
+		return
+	}
+	StrOutput(w, v)
+}
+
+func bindOpenWriter(fs WritableFS, flag int) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		path := args[0].(data.String)
+		f, err := fs.OpenFile(path.String(), flag|os.O_WRONLY, defaultFilePerm)
+		if err != nil {
+			panic(err)
+		}
+		return NewWriter(f, FileOutput)
+	}, 1)
+}
+
+func bindMkdir(fs WritableFS) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		path := args[0].(data.String)
+		if err := fs.Mkdir(path.String(), defaultDirPerm); err != nil {
+			panic(err)
+		}
+		return data.Null
+	}, 1)
+}
+
+func bindRemove(fs WritableFS) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		path := args[0].(data.String)
+		if err := fs.Remove(path.String()); err != nil {
+			panic(err)
+		}
+		return data.Null
+	}, 1)
+}
+
+func bindRename(fs WritableFS) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		from := args[0].(data.String)
+		to := args[1].(data.String)
+		if err := fs.Rename(from.String(), to.String()); err != nil {
+			panic(err)
+		}
+		return data.Null
+	}, 2)
+}
+
+func bindStat(fs WritableFS) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		path := args[0].(data.String)
+		s, err := fs.Stat(path.String())
+		if err != nil {
+			panic(err)
+		}
+		return data.NewObject(
+			data.NewCons(NameKey, data.String(s.Name())),
+			data.NewCons(SizeKey, data.Integer(s.Size())),
+			data.NewCons(ModeKey, data.Integer(s.Mode().Perm())),
+			data.NewCons(ModTimeKey, data.Integer(s.ModTime().UnixNano())),
+			data.NewCons(TypeKey, getFileInfoType(s)),
+		)
+	}, 1)
+}
+
+func getFileInfoType(s fs.FileInfo) data.Keyword {
+	if s.IsDir() {
+		return Dir
+	}
+	return File
+}
+
+func bindGlob(f WritableFS) data.Procedure {
+	return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+		pattern := args[0].(data.String)
+		matches, err := fs.Glob(f, pattern.String())
+		if err != nil {
+			panic(err)
+		}
+		res := make(data.Vector, len(matches))
+		for i, m := range matches {
+			res[i] = data.String(m)
+		}
+		return res
+	}, 1)
+}
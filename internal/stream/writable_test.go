@@ -0,0 +1,90 @@
+package stream_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/stream"
+)
+
+func makeWritableFS(t *testing.T) *data.Object {
+	t.Helper()
+	root, err := os.OpenRoot(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = root.Close() })
+	return stream.WrapWritableFileSystem(stream.RootFS(root))
+}
+
+func fsCall(fs *data.Object, key data.Keyword, args ...ale.Value) ale.Value {
+	c, _ := fs.Get(key)
+	return c.(data.Procedure).Call(args...)
+}
+
+func writeAndClose(w ale.Value, args ...ale.Value) {
+	o := w.(*data.Object)
+	write, _ := o.Get(stream.WriteKey)
+	write.(data.Procedure).Call(args...)
+	cl, _ := o.Get(stream.CloseKey)
+	cl.(data.Procedure).Call()
+}
+
+func TestWritableFileSystemWrite(t *testing.T) {
+	as := assert.New(t)
+	fs := makeWritableFS(t)
+
+	w := fsCall(fs, stream.CreateKey, S("test.txt"))
+	writeAndClose(w, S("hello"), I(42))
+	writeAndClose(
+		fsCall(fs, stream.AppendKey, S("test.txt")), data.Bytes(" there"),
+	)
+	as.String("hello42 there",
+		fsCall(fs, stream.OpenKey, S("test.txt"), stream.ReadString),
+	)
+
+	writeAndClose(fsCall(fs, stream.TruncateKey, S("test.txt")), S("bye"))
+	as.String("bye",
+		fsCall(fs, stream.OpenKey, S("test.txt"), stream.ReadString),
+	)
+
+	as.Panics(func() {
+		_ = fsCall(fs, stream.CreateKey, S("test.txt"))
+	}, "openat test.txt: file exists")
+	as.Panics(func() {
+		_ = fsCall(fs, stream.CreateKey, S("../escape.txt"))
+	}, "openat ../escape.txt: path escapes from parent")
+}
+
+func TestWritableFileSystemManage(t *testing.T) {
+	as := assert.New(t)
+	fs := makeWritableFS(t)
+
+	as.Equal(data.Null, fsCall(fs, stream.MkdirKey, S("dir")))
+	writeAndClose(fsCall(fs, stream.CreateKey, S("dir/a.txt")), S("12345"))
+	writeAndClose(fsCall(fs, stream.CreateKey, S("dir/b.dat")), S("1"))
+	fsCall(fs, stream.RenameKey, S("dir/b.dat"), S("dir/b.txt"))
+
+	as.Equal(
+		V(S("dir/a.txt"), S("dir/b.txt")),
+		fsCall(fs, stream.GlobKey, S("dir/*.txt")),
+	)
+
+	s := fsCall(fs, stream.StatKey, S("dir/a.txt")).(*data.Object)
+	as.Equal(S("a.txt"), as.MustGet(s, stream.NameKey))
+	as.Equal(I(5), as.MustGet(s, stream.SizeKey))
+	as.Equal(stream.File, as.MustGet(s, stream.TypeKey))
+	_, ok := as.MustGet(s, stream.ModTimeKey).(data.Integer)
+	as.True(ok)
+
+	s = fsCall(fs, stream.StatKey, S("dir")).(*data.Object)
+	as.Equal(stream.Dir, as.MustGet(s, stream.TypeKey))
+
+	fsCall(fs, stream.RemoveKey, S("dir/a.txt"))
+	fsCall(fs, stream.RemoveKey, S("dir/b.txt"))
+	as.Equal(data.EmptyObject, fsCall(fs, stream.ListKey, S("dir")))
+}
@@ -0,0 +1,168 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/runtime"
+	"github.com/kode4food/ale/internal/types"
+)
+
+type (
+	// An Atom is a shared reference to a Value that can be safely changed by
+	// multiple goroutines
+	Atom struct {
+		value   atomic.Pointer[atomValue]
+		mu      sync.Mutex
+		watches []*atomWatch
+	}
+
+	atomValue struct {
+		ale.Value
+	}
+
+	atomWatch struct {
+		key ale.Value
+		fn  data.Procedure
+	}
+)
+
+var AtomType = types.MakeBasic("atom")
+
+// NewAtom instantiates a new Atom that refers to the provided Value
+func NewAtom(v ale.Value) *Atom {
+	res := &Atom{}
+	res.value.Store(&atomValue{v})
+	return res
+}
+
+// Deref returns the Value that the Atom currently refers to
+func (a *Atom) Deref() ale.Value {
+	return a.value.Load().Value
+}
+
+// Reset unconditionally changes the Value that the Atom refers to
+func (a *Atom) Reset(v ale.Value) ale.Value {
+	return a.ResetContext(context.Background(), v)
+}
+
+// ResetContext unconditionally changes the Value that the Atom refers to,
+// passing the provided context along to its watches
+func (a *Atom) ResetContext(ctx context.Context, v ale.Value) ale.Value {
+	old := a.value.Swap(&atomValue{v})
+	a.notify(ctx, old.Value, v)
+	return v
+}
+
+// Swap changes the Value that the Atom refers to by calling the provided
+// Procedure with the current Value and any additional arguments. If the Atom
+// is changed by another goroutine before the call completes, the call is
+// retried with the new Value
+func (a *Atom) Swap(fn data.Procedure, args ...ale.Value) ale.Value {
+	return a.SwapContext(context.Background(), fn, args...)
+}
+
+// SwapContext performs a Swap, passing the provided context along to the
+// Procedure and to the Atom's watches
+func (a *Atom) SwapContext(
+	ctx context.Context, fn data.Procedure, args ...ale.Value,
+) ale.Value {
+	callArgs := make(data.Vector, len(args)+1)
+	copy(callArgs[1:], args)
+	for {
+		old := a.value.Load()
+		callArgs[0] = old.Value
+		res := runtime.Call(ctx, fn, callArgs...)
+		if a.value.CompareAndSwap(old, &atomValue{res}) {
+			a.notify(ctx, old.Value, res)
+			return res
+		}
+	}
+}
+
+// CompareAndSet changes the Value that the Atom refers to, but only if its
+// current Value is equal to the expected one
+func (a *Atom) CompareAndSet(expect, v ale.Value) bool {
+	return a.CompareAndSetContext(context.Background(), expect, v)
+}
+
+// CompareAndSetContext performs a CompareAndSet, passing the provided context
+// along to the Atom's watches
+func (a *Atom) CompareAndSetContext(
+	ctx context.Context, expect, v ale.Value,
+) bool {
+	for {
+		old := a.value.Load()
+		if !old.Value.Equal(expect) {
+			return false
+		}
+		if a.value.CompareAndSwap(old, &atomValue{v}) {
+			a.notify(ctx, old.Value, v)
+			return true
+		}
+	}
+}
+
+// AddWatch registers a Procedure to be called whenever the Atom is changed.
+// The Procedure is called with the key, the Atom, the old Value, and the new
+// Value. Adding a watch with an existing key replaces that watch
+func (a *Atom) AddWatch(key ale.Value, fn data.Procedure) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	w := &atomWatch{key: key, fn: fn}
+	if i := a.watchIndex(key); i >= 0 {
+		a.watches = slices.Clone(a.watches)
+		a.watches[i] = w
+		return
+	}
+	a.watches = append(slices.Clip(a.watches), w)
+}
+
+// RemoveWatch unregisters the watch Procedure associated with a key
+func (a *Atom) RemoveWatch(key ale.Value) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if i := a.watchIndex(key); i >= 0 {
+		a.watches = slices.Delete(slices.Clone(a.watches), i, i+1)
+	}
+}
+
+func (a *Atom) watchIndex(key ale.Value) int {
+	return slices.IndexFunc(a.watches, func(w *atomWatch) bool {
+		return w.key.Equal(key)
+	})
+}
+
+func (a *Atom) notify(ctx context.Context, old, v ale.Value) {
+	a.mu.Lock()
+	watches := a.watches
+	a.mu.Unlock()
+	for _, w := range watches {
+		runtime.Call(ctx, w.fn, w.key, a, old, v)
+	}
+}
+
+// String returns a representation of the Atom that shows its current Value,
+// such as #<atom 1>
+func (a *Atom) String() string {
+	return fmt.Sprintf(
+		"#<%s %s>", AtomType.Name(), data.ToQuotedString(a.Deref()),
+	)
+}
+
+func (a *Atom) Type() ale.Type {
+	return types.MakeLiteral(AtomType, a)
+}
+
+func (a *Atom) Equal(other ale.Value) bool {
+	return a == other
+}
+
+func (a *Atom) Get(key ale.Value) (ale.Value, bool) {
+	return data.DumpMapped(a).Get(key)
+}
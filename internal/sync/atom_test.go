@@ -0,0 +1,80 @@
+package sync_test
+
+import (
+	gosync "sync"
+	"testing"
+
+	"github.com/kode4food/ale"
+	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/assert"
+	. "github.com/kode4food/ale/internal/assert/helpers"
+	"github.com/kode4food/ale/internal/sync"
+)
+
+var atomAdd = data.MakeProcedure(func(args ...ale.Value) ale.Value {
+	res := args[0].(data.Number)
+	for _, a := range args[1:] {
+		res = res.Add(a.(data.Number))
+	}
+	return res
+}, 1, data.OrMore)
+
+func TestAtom(t *testing.T) {
+	as := assert.New(t)
+	a := sync.NewAtom(I(1))
+	as.Equal(I(1), a.Deref())
+	as.Equal(I(5), a.Reset(I(5)))
+	as.Equal(I(8), a.Swap(atomAdd, I(1), I(2)))
+	as.False(a.CompareAndSet(I(5), I(10)))
+	as.True(a.CompareAndSet(I(8), I(10)))
+	as.Equal(I(10), a.Deref())
+	as.True(a.Equal(a))
+	as.False(a.Equal(sync.NewAtom(I(10))))
+	as.String("#<atom 10>", a)
+	as.String(`#<atom "ten">`, sync.NewAtom(S("ten")))
+}
+
+func TestAtomConcurrentSwap(t *testing.T) {
+	as := assert.New(t)
+	a := sync.NewAtom(I(0))
+
+	var wg gosync.WaitGroup
+	for range 100 {
+		wg.Go(func() {
+			a.Swap(atomAdd, I(1))
+		})
+	}
+	wg.Wait()
+	as.Equal(I(100), a.Deref())
+}
+
+func TestAtomWatches(t *testing.T) {
+	as := assert.New(t)
+	a := sync.NewAtom(I(0))
+
+	var calls data.Vector
+	watch := func(name string) data.Procedure {
+		return data.MakeProcedure(func(args ...ale.Value) ale.Value {
+			as.Identical(a, args[1])
+			calls = append(calls, V(S(name), args[0], args[2], args[3]))
+			return data.Null
+		}, 4)
+	}
+
+	a.AddWatch(K("first"), watch("a"))
+	a.AddWatch(K("second"), watch("b"))
+	a.Reset(I(1))
+	a.AddWatch(K("first"), watch("c"))
+	a.Swap(atomAdd, I(1))
+	a.RemoveWatch(K("second"))
+	a.CompareAndSet(I(2), I(3))
+	a.CompareAndSet(I(2), I(4))
+
+	as.Equal(V(
+		V(S("a"), K("first"), I(0), I(1)),
+		V(S("b"), K("second"), I(0), I(1)),
+		V(S("c"), K("first"), I(1), I(2)),
+		V(S("b"), K("second"), I(1), I(2)),
+		V(S("c"), K("first"), I(2), I(3)),
+	), calls)
+}
@@ -1,8 +1,11 @@
 package sync
 
 import (
+	"context"
+
 	"github.com/kode4food/ale"
 	"github.com/kode4food/ale/data"
+	"github.com/kode4food/ale/internal/runtime"
 	"github.com/kode4food/ale/internal/types"
 )
 
@@ -36,6 +39,12 @@ func NewPromise(resolver data.Procedure) *Promise {
 }
 
 func (p *Promise) Call(...ale.Value) ale.Value {
+	return p.CallContext(context.Background())
+}
+
+// CallContext resolves the Promise if it hasn't already been resolved, passing
+// the provided context along to its resolver
+func (p *Promise) CallContext(ctx context.Context, _ ...ale.Value) ale.Value {
 	p.once(func() {
 		defer func() {
 			if rec := recover(); rec != nil {
@@ -43,7 +52,7 @@ func (p *Promise) Call(...ale.Value) ale.Value {
 				p.status = promiseFailed
 			}
 		}()
-		p.result = p.resolver.Call()
+		p.result = runtime.Call(ctx, p.resolver)
 		p.status = promiseResolved
 	})
 
@@ -41,6 +41,17 @@ func (a *Applicable) Signatures() []Signature {
 	return a.signatures
 }
 
+// Signature returns the first of the Applicable's Signatures that can be
+// applied to the specified number of arguments
+func (a *Applicable) Signature(argc int) (Signature, bool) {
+	for _, s := range a.signatures {
+		if s.AppliesTo(argc) {
+			return s, true
+		}
+	}
+	return Signature{}, false
+}
+
 func (a *Applicable) Name() string {
 	return fmt.Sprintf("%s(%s)", a.Basic.Name(), a.name())
 }
@@ -77,6 +88,25 @@ func (a *Applicable) Equal(other ale.Type) bool {
 	return false
 }
 
+// AppliesTo returns whether the Signature can be applied to the specified
+// number of arguments. If the Signature takes a rest argument, its final
+// Param describes each of the arguments that it collects
+func (s Signature) AppliesTo(argc int) bool {
+	if s.TakesRest {
+		return argc >= len(s.Params)-1
+	}
+	return argc == len(s.Params)
+}
+
+// ParamType returns the Type that the Signature expects of the argument at
+// the specified index
+func (s Signature) ParamType(idx int) ale.Type {
+	if l := len(s.Params) - 1; s.TakesRest && idx >= l {
+		return s.Params[l]
+	}
+	return s.Params[idx]
+}
+
 func (s Signature) name() string {
 	return fmt.Sprintf("%s->%s", s.argNames(), s.Result.Name())
 }
@@ -152,3 +152,35 @@ func TestApplicableEqual(t *testing.T) {
 	as.False(a3.Equal(a4))
 	as.False(a3.Equal(a5))
 }
+
+func TestApplicableSignature(t *testing.T) {
+	as := assert.New(t)
+
+	a1 := types.MakeApplicable(
+		types.Signature{
+			Params: []ale.Type{types.BasicNumber},
+			Result: types.BasicBoolean,
+		},
+		types.Signature{
+			Params:    []ale.Type{types.BasicString, types.BasicKeyword},
+			Result:    types.BasicString,
+			TakesRest: true,
+		},
+	).(*types.Applicable)
+
+	s, ok := a1.Signature(1)
+	as.True(ok)
+	as.Equal(types.BasicBoolean, s.Result)
+	as.Equal(types.BasicNumber, s.ParamType(0))
+
+	_, ok = a1.Signature(0)
+	as.False(ok)
+
+	s, ok = a1.Signature(4)
+	as.True(ok)
+	as.True(s.AppliesTo(2))
+	as.False(s.AppliesTo(0))
+	as.Equal(types.BasicString, s.ParamType(0))
+	as.Equal(types.BasicKeyword, s.ParamType(1))
+	as.Equal(types.BasicKeyword, s.ParamType(3))
+}
@@ -26,8 +26,10 @@ type (
 var (
 	BasicBoolean   = makeBasic("boolean")
 	BasicBytes     = makeBasic("bytes")
+	BasicError     = makeBasic("error")
 	BasicKeyword   = makeBasic("keyword")
 	BasicProcedure = makeBasic("procedure")
+	BasicRegex     = makeBasic("regex")
 	BasicNull      = makeBasic("null")
 	BasicNumber    = makeBasic("number")
 	BasicString    = makeBasic("string")
@@ -31,6 +31,35 @@ func MakeUnion(first ale.Type, rest ...ale.Type) ale.Type {
 	}
 }
 
+// MakeNamedUnion declares a *Union in the same way as MakeUnion, but gives it
+// the provided name, so that it's described in the way that it was declared
+func MakeNamedUnion(name string, first ale.Type, rest ...ale.Type) ale.Type {
+	res := MakeUnion(first, rest...)
+	if u, ok := res.(*Union); ok {
+		return &Union{
+			Basic:   u.Basic,
+			name:    name,
+			options: u.options,
+		}
+	}
+	return res
+}
+
+// Intersects returns whether a Value could possibly satisfy both of the
+// provided Types. If either is a Union, at least one of its options must
+// intersect with the other Type
+func Intersects(l, r ale.Type) bool {
+	if u, ok := l.(*Union); ok {
+		return slices.ContainsFunc(u.options, func(o ale.Type) bool {
+			return Intersects(o, r)
+		})
+	}
+	if u, ok := r.(*Union); ok {
+		return Intersects(u, l)
+	}
+	return l.Accepts(r) || r.Accepts(l)
+}
+
 func unionBasicType(t typeList) Basic {
 	if res, ok := t.basicType(); ok {
 		return res
@@ -53,6 +53,23 @@ func TestUnionAccepts(t *testing.T) {
 	as.True(ok)
 }
 
+func TestNamedUnion(t *testing.T) {
+	as := assert.New(t)
+
+	u1 := types.MakeNamedUnion("list", types.BasicList, types.BasicNull)
+	u2 := types.MakeUnion(types.BasicList, types.BasicNull)
+	as.Equal("list", u1.Name())
+	as.True(u1.Accepts(u2))
+	as.True(u2.Accepts(u1))
+	as.False(u1.Accepts(types.BasicVector))
+	as.Equal("union(list,null,string)",
+		types.MakeUnion(u1, types.BasicString).Name(),
+	)
+	as.Equal(types.BasicList,
+		types.MakeNamedUnion("only", types.BasicList),
+	)
+}
+
 func TestUnionEqual(t *testing.T) {
 	as := assert.New(t)
 
@@ -71,3 +88,19 @@ func TestUnionEqual(t *testing.T) {
 	as.False(types.BasicNumber.Equal(u1))
 	as.True(u3.Equal(u4))
 }
+
+func TestUnionIntersects(t *testing.T) {
+	as := assert.New(t)
+
+	u1 := types.MakeUnion(types.BasicKeyword, types.BasicNumber)
+	u2 := types.MakeUnion(types.BasicList, types.BasicNull)
+
+	as.True(types.Intersects(u1, types.BasicNumber))
+	as.True(types.Intersects(types.BasicKeyword, u1))
+	as.False(types.Intersects(u1, types.BasicString))
+	as.False(types.Intersects(u1, u2))
+	as.True(types.Intersects(u1, types.MakeUnion(types.BasicNull, u1)))
+	as.True(types.Intersects(types.BasicAny, u2))
+	as.True(types.Intersects(u2, types.BasicAny))
+	as.False(types.Intersects(types.BasicNumber, types.BasicString))
+}
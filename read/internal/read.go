@@ -11,6 +11,9 @@ type (
 	FromString     func(env.Namespace, data.String) (data.Sequence, error)
 	MustFromString func(env.Namespace, data.String) data.Sequence
 	MustTokenizer  func(data.String) data.Sequence
+
+	FromSource     func(env.Namespace, string, data.String) (data.Sequence, error)
+	MustFromSource func(env.Namespace, string, data.String) data.Sequence
 )
 
 func MakeTokenizer(matcher lex.Matcher) parse.Tokenizer {
@@ -23,7 +26,14 @@ func MakeFromString(fn parse.Tokenizer) FromString {
 	return func(ns env.Namespace, src data.String) (data.Sequence, error) {
 		return parse.FromString(ns, fn, src)
 	}
+}
 
+func MakeFromSource(fn parse.Tokenizer) FromSource {
+	return func(
+		ns env.Namespace, name string, src data.String,
+	) (data.Sequence, error) {
+		return parse.FromSource(ns, fn, name, src)
+	}
 }
 
 func MakeMustFromString(fn FromString) MustFromString {
@@ -36,6 +46,16 @@ func MakeMustFromString(fn FromString) MustFromString {
 	}
 }
 
+func MakeMustFromSource(fn FromSource) MustFromSource {
+	return func(ns env.Namespace, name string, str data.String) data.Sequence {
+		seq, err := fn(ns, name, str)
+		if err != nil {
+			panic(err)
+		}
+		return seq
+	}
+}
+
 func MakeMustTokenizer(fn parse.Tokenizer) MustTokenizer {
 	return func(str data.String) data.Sequence {
 		seq, err := fn(str)
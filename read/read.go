@@ -17,4 +17,6 @@ var (
 	MustTokenize   = internal.MakeMustTokenizer(Tokenize)
 	FromString     = internal.MakeFromString(Tokenize)
 	MustFromString = internal.MakeMustFromString(FromString)
+	FromSource     = internal.MakeFromSource(Tokenize)
+	MustFromSource = internal.MakeMustFromSource(FromSource)
 )